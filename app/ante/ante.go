@@ -1,6 +1,10 @@
 package ante
 
 import (
+	"bytes"
+	"encoding/hex"
+
+	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/x/auth"
@@ -12,10 +16,19 @@ import (
 	evmtypes "github.com/cosmos/ethermint/x/evm/types"
 
 	tmcrypto "github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/multisig"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 )
 
+// simSecp256k1Pubkey is the public key used to estimate the gas consumption of the signers
+// without a public key on simulations, as on the SDK SetPubKeyDecorator.
+var simSecp256k1Pubkey secp256k1.PubKeySecp256k1
+
 func init() {
 	ethsecp256k1.RegisterCodec(types.ModuleCdc)
+
+	bz, _ := hex.DecodeString("035AD6810A47F073553FF30D2FCC7E0D3B1C0B74B61A1AAA2582344037151E143A")
+	copy(simSecp256k1Pubkey[:], bz)
 }
 
 const (
@@ -28,8 +41,9 @@ const (
 // NewAnteHandler returns an ante handler responsible for attempting to route an
 // Ethereum or SDK transaction to an internal ante handler for performing
 // transaction-level processing (e.g. fee payment, signature verification) before
-// being passed onto it's respective handler.
-func NewAnteHandler(ak auth.AccountKeeper, evmKeeper EVMKeeper, sk types.SupplyKeeper) sdk.AnteHandler {
+// being passed onto it's respective handler. The NonceQueue allows Ethereum transactions with
+// future nonces on the mempool. It can be nil to only accept the next nonce of the sender.
+func NewAnteHandler(ak auth.AccountKeeper, evmKeeper EVMKeeper, sk types.SupplyKeeper, nq *NonceQueue) sdk.AnteHandler {
 	return func(
 		ctx sdk.Context, tx sdk.Tx, sim bool,
 	) (newCtx sdk.Context, err error) {
@@ -43,7 +57,7 @@ func NewAnteHandler(ak auth.AccountKeeper, evmKeeper EVMKeeper, sk types.SupplyK
 				authante.NewValidateBasicDecorator(),
 				authante.NewValidateMemoDecorator(ak),
 				authante.NewConsumeGasForTxSizeDecorator(ak),
-				authante.NewSetPubKeyDecorator(ak), // SetPubKeyDecorator must be called before all signature verification decorators
+				NewSetPubKeyDecorator(ak), // SetPubKeyDecorator must be called before all signature verification decorators
 				authante.NewValidateSigCountDecorator(ak),
 				authante.NewDeductFeeDecorator(ak, sk),
 				authante.NewSigGasConsumeDecorator(ak, sigGasConsumer),
@@ -56,11 +70,14 @@ func NewAnteHandler(ak auth.AccountKeeper, evmKeeper EVMKeeper, sk types.SupplyK
 				NewEthSetupContextDecorator(), // outermost AnteDecorator. EthSetUpContext must be called first
 				NewEthMempoolFeeDecorator(evmKeeper),
 				authante.NewValidateBasicDecorator(),
-				NewEthSigVerificationDecorator(),
+				NewEthSigVerificationDecorator(evmKeeper),
+				NewEthDeployerAllowlistDecorator(evmKeeper),
+				NewEthBlocklistDecorator(evmKeeper),
 				NewAccountVerificationDecorator(ak, evmKeeper),
-				NewNonceVerificationDecorator(ak),
+				NewNonceVerificationDecorator(ak, nq),
+				NewEthBlockGasLimitDecorator(evmKeeper),
 				NewEthGasConsumeDecorator(ak, sk, evmKeeper),
-				NewIncrementSenderSequenceDecorator(ak), // innermost AnteDecorator.
+				NewIncrementSenderSequenceDecorator(ak, nq), // innermost AnteDecorator.
 			)
 		default:
 			return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "invalid transaction type: %T", tx)
@@ -71,14 +88,22 @@ func NewAnteHandler(ak auth.AccountKeeper, evmKeeper EVMKeeper, sk types.SupplyK
 }
 
 // sigGasConsumer overrides the DefaultSigVerificationGasConsumer from the x/auth
-// module on the SDK. It doesn't allow ed25519 nor multisig thresholds.
+// module on the SDK. It doesn't allow ed25519 and it only allows multisig thresholds
+// composed of ethsecp256k1 keys, which consume the gas of each signature.
 func sigGasConsumer(
-	meter sdk.GasMeter, _ []byte, pubkey tmcrypto.PubKey, _ types.Params,
+	meter sdk.GasMeter, sig []byte, pubkey tmcrypto.PubKey, params types.Params,
 ) error {
-	switch pubkey.(type) {
+	switch pubkey := pubkey.(type) {
 	case ethsecp256k1.PubKey:
 		meter.ConsumeGas(secp256k1VerifyCost, "ante verify: secp256k1")
 		return nil
+	case multisig.PubKeyMultisigThreshold:
+		var multisignature multisig.Multisignature
+		if err := codec.Cdc.UnmarshalBinaryBare(sig, &multisignature); err != nil {
+			return sdkerrors.Wrap(sdkerrors.ErrTxDecode, "invalid multisignature")
+		}
+
+		return consumeMultisignatureVerificationGas(meter, multisignature, pubkey, params)
 	case tmcrypto.PubKey:
 		meter.ConsumeGas(secp256k1VerifyCost, "ante verify: tendermint secp256k1")
 		return nil
@@ -87,6 +112,102 @@ func sigGasConsumer(
 	}
 }
 
+// consumeMultisignatureVerificationGas consumes the gas of each of the signatures of the
+// multisignature. All the keys of the multisig threshold must be ethsecp256k1 keys.
+func consumeMultisignatureVerificationGas(
+	meter sdk.GasMeter, sig multisig.Multisignature, pubkey multisig.PubKeyMultisigThreshold, params types.Params,
+) error {
+	for _, pk := range pubkey.PubKeys {
+		if _, ok := pk.(ethsecp256k1.PubKey); !ok {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidPubKey, "invalid multisig public key type: %T", pk)
+		}
+	}
+
+	if sig.BitArray == nil || sig.BitArray.Size() != len(pubkey.PubKeys) {
+		return sdkerrors.Wrap(sdkerrors.ErrTxDecode, "multisignature size doesn't match the number of public keys")
+	}
+
+	sigIndex := 0
+	for i := 0; i < sig.BitArray.Size(); i++ {
+		if !sig.BitArray.GetIndex(i) {
+			continue
+		}
+
+		if sigIndex >= len(sig.Sigs) {
+			return sdkerrors.Wrap(sdkerrors.ErrTxDecode, "multisignature is missing signatures")
+		}
+
+		if err := sigGasConsumer(meter, sig.Sigs[sigIndex], pubkey.PubKeys[i], params); err != nil {
+			return err
+		}
+
+		sigIndex++
+	}
+
+	return nil
+}
+
+// SetPubKeyDecorator sets the public keys of the signers that don't have one on the state. It
+// replaces the SetPubKeyDecorator from the x/auth module on the SDK, which derives the signer
+// addresses with the Address method of the public keys, in order to support the multisig
+// threshold public keys composed of ethsecp256k1 keys (see ethsecp256k1.PubKeyAddress).
+type SetPubKeyDecorator struct {
+	ak auth.AccountKeeper
+}
+
+// NewSetPubKeyDecorator creates a new SetPubKeyDecorator instance
+func NewSetPubKeyDecorator(ak auth.AccountKeeper) SetPubKeyDecorator {
+	return SetPubKeyDecorator{
+		ak: ak,
+	}
+}
+
+// AnteHandle checks that the public keys of the tx match the signer addresses and sets them on
+// the signer accounts.
+func (spkd SetPubKeyDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	sigTx, ok := tx.(authante.SigVerifiableTx)
+	if !ok {
+		return ctx, sdkerrors.Wrap(sdkerrors.ErrTxDecode, "invalid tx type")
+	}
+
+	pubkeys := sigTx.GetPubKeys()
+	signers := sigTx.GetSigners()
+
+	for i, pk := range pubkeys {
+		// the public key is omitted if it's already set on the account
+		if pk == nil {
+			if !simulate {
+				continue
+			}
+			pk = simSecp256k1Pubkey
+		}
+
+		if !simulate && !bytes.Equal(ethsecp256k1.PubKeyAddress(pk), signers[i]) {
+			return ctx, sdkerrors.Wrapf(
+				sdkerrors.ErrInvalidPubKey,
+				"pubKey does not match signer address %s with signer index: %d", signers[i], i,
+			)
+		}
+
+		acc, err := authante.GetSignerAcc(ctx, spkd.ak, signers[i])
+		if err != nil {
+			return ctx, err
+		}
+
+		if acc.GetPubKey() != nil {
+			continue
+		}
+
+		if err := acc.SetPubKey(pk); err != nil {
+			return ctx, sdkerrors.Wrap(sdkerrors.ErrInvalidPubKey, err.Error())
+		}
+
+		spkd.ak.SetAccount(ctx, acc)
+	}
+
+	return next(ctx, tx, simulate)
+}
+
 // AccountSetupDecorator sets an account to state if it's not stored already. This only applies for MsgEthermint.
 type AccountSetupDecorator struct {
 	ak auth.AccountKeeper
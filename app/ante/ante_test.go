@@ -1,6 +1,7 @@
 package ante_test
 
 import (
+	"fmt"
 	"math/big"
 	"testing"
 	"time"
@@ -11,11 +12,14 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	tmcrypto "github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/multisig"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/cosmos/ethermint/app"
 	"github.com/cosmos/ethermint/app/ante"
+	"github.com/cosmos/ethermint/crypto/ethsecp256k1"
 	"github.com/cosmos/ethermint/types"
 	evmtypes "github.com/cosmos/ethermint/x/evm/types"
 )
@@ -88,6 +92,68 @@ func (suite *AnteTestSuite) TestValidTx() {
 	requireValidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
 }
 
+func (suite *AnteTestSuite) TestMultisigTx() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	_, priv1 := newTestAddrKey()
+	_, priv2 := newTestAddrKey()
+	_, priv3 := newTestAddrKey()
+
+	pubkey := multisig.NewPubKeyMultisigThreshold(
+		2, []tmcrypto.PubKey{priv1.PubKey(), priv2.PubKey(), priv3.PubKey()},
+	).(multisig.PubKeyMultisigThreshold)
+
+	addr := sdk.AccAddress(ethsecp256k1.PubKeyAddress(pubkey))
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr)
+	_ = acc.SetCoins(newTestCoins())
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	fee := newTestStdFee()
+	msgs := []sdk.Msg{newTestMsg(addr)}
+
+	// below the threshold
+	tx := newTestMultisigTx(suite.ctx, msgs, pubkey, []tmcrypto.PrivKey{priv1}, acc.GetAccountNumber(), 0, fee)
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
+
+	// the gas is consumed for each one of the signatures
+	gasConsumed := func(tx sdk.Tx) uint64 {
+		cacheCtx, _ := suite.ctx.CacheContext()
+		ctx, err := suite.anteHandler(cacheCtx, tx, false)
+		suite.Require().NoError(err)
+		return ctx.GasMeter().GasConsumed()
+	}
+
+	tx = newTestMultisigTx(suite.ctx, msgs, pubkey, []tmcrypto.PrivKey{priv1, priv2, priv3}, acc.GetAccountNumber(), 0, fee)
+	gasThreeSigs := gasConsumed(tx)
+
+	tx = newTestMultisigTx(suite.ctx, msgs, pubkey, []tmcrypto.PrivKey{priv1, priv3}, acc.GetAccountNumber(), 0, fee)
+	suite.Require().Equal(uint64(21000), gasThreeSigs-gasConsumed(tx))
+
+	requireValidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
+	acc = suite.app.AccountKeeper.GetAccount(suite.ctx, addr)
+	suite.Require().Equal(uint64(1), acc.GetSequence())
+	suite.Require().Equal(pubkey, acc.GetPubKey())
+
+	// the account with the multisig public key can be encoded
+	_, err := suite.app.Codec().MarshalJSON(acc)
+	suite.Require().NoError(err)
+
+	// the multisig keys must be ethsecp256k1 keys
+	tmPriv := secp256k1.GenPrivKey()
+	pubkey = multisig.NewPubKeyMultisigThreshold(
+		1, []tmcrypto.PubKey{priv1.PubKey(), tmPriv.PubKey()},
+	).(multisig.PubKeyMultisigThreshold)
+
+	addr = sdk.AccAddress(ethsecp256k1.PubKeyAddress(pubkey))
+	acc = suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr)
+	_ = acc.SetCoins(newTestCoins())
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	msgs = []sdk.Msg{newTestMsg(addr)}
+	tx = newTestMultisigTx(suite.ctx, msgs, pubkey, []tmcrypto.PrivKey{tmPriv}, acc.GetAccountNumber(), 0, fee)
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
+}
+
 func (suite *AnteTestSuite) TestSDKInvalidSigs() {
 	suite.ctx = suite.ctx.WithBlockHeight(1)
 
@@ -256,7 +322,7 @@ func (suite *AnteTestSuite) TestEthInvalidMempoolFees() {
 	suite.ctx = suite.app.BaseApp.NewContext(true, abci.Header{Height: 1, ChainID: "ethermint-3", Time: time.Now().UTC()})
 	suite.app.EvmKeeper.SetParams(suite.ctx, evmtypes.DefaultParams())
 
-	suite.anteHandler = ante.NewAnteHandler(suite.app.AccountKeeper, suite.app.EvmKeeper, suite.app.SupplyKeeper)
+	suite.anteHandler = ante.NewAnteHandler(suite.app.AccountKeeper, suite.app.EvmKeeper, suite.app.SupplyKeeper, nil)
 	suite.ctx = suite.ctx.WithMinGasPrices(sdk.NewDecCoins(types.NewPhotonDecCoin(sdk.NewInt(500000))))
 	addr1, priv1 := newTestAddrKey()
 	addr2, _ := newTestAddrKey()
@@ -298,3 +364,380 @@ func (suite *AnteTestSuite) TestEthInvalidChainID() {
 	ctx := suite.ctx.WithChainID("bad-chain-id")
 	requireInvalidTx(suite.T(), suite.anteHandler, ctx, tx, false)
 }
+
+func (suite *AnteTestSuite) TestEthExceedsBlockGasLimit() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+	suite.ctx = suite.ctx.WithConsensusParams(&abci.ConsensusParams{
+		Block: &abci.BlockParams{MaxGas: 50000},
+	})
+
+	addr1, priv1 := newTestAddrKey()
+	addr2, _ := newTestAddrKey()
+
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	_ = acc.SetCoins(newTestCoins())
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	to := ethcmn.BytesToAddress(addr2.Bytes())
+	amt := big.NewInt(32)
+	gas := big.NewInt(20)
+
+	// require a tx with a gas limit greater than the block gas limit to fail
+	ethMsg := evmtypes.NewMsgEthereumTx(0, &to, amt, 60000, gas, []byte("test"))
+
+	tx, err := newTestEthTx(suite.ctx, ethMsg, priv1)
+	suite.Require().NoError(err)
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx.WithIsCheckTx(true), tx, false)
+
+	// require a tx with a gas limit greater than the remaining block gas to fail
+	suite.app.EvmKeeper.AddBlockGasUsed(30000)
+	ethMsg = evmtypes.NewMsgEthereumTx(0, &to, amt, 22000, gas, []byte("test"))
+
+	tx, err = newTestEthTx(suite.ctx, ethMsg, priv1)
+	suite.Require().NoError(err)
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
+
+	// the block gas used is not known during CheckTx
+	requireValidTx(suite.T(), suite.anteHandler, suite.ctx.WithIsCheckTx(true), tx, false)
+}
+
+func (suite *AnteTestSuite) TestEthFutureNonce() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+	checkCtx := suite.ctx.WithIsCheckTx(true)
+
+	released := make(chan string, 10)
+	nq := ante.NewNonceQueue(3)
+	nq.SetLifetime(5)
+	nq.SetBroadcaster(func(txBytes []byte) error {
+		released <- string(txBytes)
+		return nil
+	})
+
+	anteHandler := ante.NewAnteHandler(suite.app.AccountKeeper, suite.app.EvmKeeper, suite.app.SupplyKeeper, nq)
+
+	addr1, priv1 := newTestAddrKey()
+	addr2, _ := newTestAddrKey()
+
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	_ = acc.SetCoins(newTestCoins())
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	to := ethcmn.BytesToAddress(addr2.Bytes())
+	amt := big.NewInt(32)
+	gas := big.NewInt(20)
+
+	// runTx runs the ante handler with the nonce as the tx bytes, to identify the released txs
+	runTx := func(ctx sdk.Context, nonce uint64, sim bool) error {
+		ethMsg := evmtypes.NewMsgEthereumTx(nonce, &to, amt, 22000, gas, []byte("test"))
+		tx, err := newTestEthTx(suite.ctx, ethMsg, priv1)
+		suite.Require().NoError(err)
+
+		_, err = anteHandler(ctx.WithTxBytes([]byte(fmt.Sprint(nonce))), tx, sim)
+		return err
+	}
+
+	sequence := func() uint64 {
+		return suite.app.AccountKeeper.GetAccount(suite.ctx, addr1).GetSequence()
+	}
+
+	requireReleased := func(nonces ...string) {
+		for _, nonce := range nonces {
+			select {
+			case txBytes := <-released:
+				suite.Require().Equal(nonce, txBytes)
+			case <-time.After(time.Second):
+				suite.FailNow("queued tx not released", nonce)
+			}
+		}
+
+		select {
+		case txBytes := <-released:
+			suite.FailNow("unexpected released tx", txBytes)
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	// a nonce within the window is queued, out of the mempool, without updating the sequence
+	err := runTx(checkCtx, 2, false)
+	suite.Require().True(types.ErrTxQueued.Is(err), err)
+	suite.Require().True(types.ErrTxQueued.Is(runTx(checkCtx, 1, false)))
+	suite.Require().Equal(uint64(0), sequence())
+
+	// a future nonce can't be rechecked, as it's not on the mempool
+	suite.Require().Error(runTx(checkCtx.WithIsReCheckTx(true), 1, false))
+
+	// nonces outside of the window, simulations and DeliverTx are rejected
+	for _, err := range []error{
+		runTx(checkCtx, 4, false),
+		runTx(checkCtx, 3, true),
+		runTx(suite.ctx, 3, false),
+	} {
+		suite.Require().Error(err)
+		suite.Require().False(types.ErrTxQueued.Is(err))
+	}
+
+	// filling the gap broadcasts the queued txs again, in nonce order
+	suite.Require().NoError(runTx(checkCtx, 0, false))
+	suite.Require().Equal(uint64(1), sequence())
+	requireReleased("1", "2")
+
+	suite.Require().NoError(runTx(checkCtx, 1, false))
+	suite.Require().NoError(runTx(checkCtx, 2, false))
+	suite.Require().Equal(uint64(3), sequence())
+	requireReleased()
+
+	// the gap can also be filled by a tx included on a block
+	suite.Require().True(types.ErrTxQueued.Is(runTx(checkCtx, 5, false)))
+	suite.Require().True(types.ErrTxQueued.Is(runTx(checkCtx, 4, false)))
+	suite.Require().NoError(runTx(suite.ctx, 3, false))
+	requireReleased("4", "5")
+
+	suite.Require().NoError(runTx(checkCtx, 4, false))
+	suite.Require().NoError(runTx(checkCtx, 5, false))
+	suite.Require().Equal(uint64(6), sequence())
+
+	// the queued txs expire after the queue lifetime
+	suite.Require().True(types.ErrTxQueued.Is(runTx(checkCtx, 7, false)))
+	suite.Require().True(types.ErrTxQueued.Is(runTx(checkCtx.WithBlockHeight(7), 8, false)))
+	suite.Require().NoError(runTx(checkCtx.WithBlockHeight(7), 6, false))
+	requireReleased()
+}
+
+func (suite *AnteTestSuite) TestEthReplaceByFee() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	nq := ante.NewNonceQueue(0)
+	nq.SetPriceBump(10)
+	anteHandler := ante.NewAnteHandler(suite.app.AccountKeeper, suite.app.EvmKeeper, suite.app.SupplyKeeper, nq)
+
+	addr1, priv1 := newTestAddrKey()
+	addr2, _ := newTestAddrKey()
+
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	_ = acc.SetCoins(newTestCoins())
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	to := ethcmn.BytesToAddress(addr2.Bytes())
+	amt := big.NewInt(32)
+
+	// the tx hashes are computed from the tx bytes of the context
+	newTx := func(nonce uint64, gasPrice int64) (sdk.Context, sdk.Tx) {
+		ethMsg := evmtypes.NewMsgEthereumTx(nonce, &to, amt, 22000, big.NewInt(gasPrice), []byte("test"))
+		tx, err := newTestEthTx(suite.ctx, ethMsg, priv1)
+		suite.Require().NoError(err)
+
+		txBytes := suite.app.Codec().MustMarshalBinaryLengthPrefixed(tx)
+		return suite.ctx.WithIsCheckTx(true).WithTxBytes(txBytes), tx
+	}
+
+	setSequence := func(seq uint64) {
+		acc := suite.app.AccountKeeper.GetAccount(suite.ctx, addr1)
+		suite.Require().NoError(acc.SetSequence(seq))
+		suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+	}
+
+	ctx, tx := newTx(0, 20)
+	requireValidTx(suite.T(), anteHandler, ctx, tx, false)
+
+	// the gas price must be bumped by at least 10%
+	ctx, underpriced := newTx(0, 21)
+	requireInvalidTx(suite.T(), anteHandler, ctx, underpriced, false)
+
+	replaceCtx, replacement := newTx(0, 22)
+	requireValidTx(suite.T(), anteHandler, replaceCtx, replacement, false)
+	suite.Require().Equal(uint64(1), suite.app.AccountKeeper.GetAccount(suite.ctx, addr1).GetSequence())
+
+	// the check state is reset after a block is committed and the mempool txs are rechecked
+	setSequence(0)
+	ctx, _ = newTx(0, 20)
+	requireInvalidTx(suite.T(), anteHandler, ctx.WithIsReCheckTx(true), tx, false)
+	requireValidTx(suite.T(), anteHandler, replaceCtx.WithIsReCheckTx(true), replacement, false)
+	suite.Require().Equal(uint64(1), suite.app.AccountKeeper.GetAccount(suite.ctx, addr1).GetSequence())
+
+	// the pending txs are removed once a tx with a greater or equal nonce is delivered
+	ctx, tx = newTx(1, 20)
+	requireValidTx(suite.T(), anteHandler, ctx.WithIsCheckTx(false), tx, false)
+
+	ctx, tx = newTx(0, 30)
+	requireInvalidTx(suite.T(), anteHandler, ctx, tx, false)
+}
+
+func (suite *AnteTestSuite) TestEthSponsoredFee() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+	checkCtx := suite.ctx.WithIsCheckTx(true)
+
+	addr1, priv1 := newTestAddrKey()
+	addr2, _ := newTestAddrKey()
+	paymaster, _ := newTestAddrKey()
+
+	// the sender can only pay for the value transferred
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	_ = acc.SetCoins(sdk.NewCoins(types.NewPhotonCoinInt64(32)))
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	paymasterAcc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, paymaster)
+	_ = paymasterAcc.SetCoins(newTestCoins())
+	suite.app.AccountKeeper.SetAccount(suite.ctx, paymasterAcc)
+
+	sender := ethcmn.BytesToAddress(addr1.Bytes())
+	to := ethcmn.BytesToAddress(addr2.Bytes())
+	amt := big.NewInt(32)
+	gas := big.NewInt(20)
+	fee := big.NewInt(20 * 22000)
+
+	ethMsg := evmtypes.NewMsgEthereumTx(0, &to, amt, 22000, gas, []byte("test"))
+	tx, err := newTestEthTx(suite.ctx, ethMsg, priv1)
+	suite.Require().NoError(err)
+
+	// the fee allowance is ignored if the paymaster is not set
+	suite.app.EvmKeeper.SetFeeAllowance(suite.ctx, sender, to, sdk.NewIntFromBigInt(fee))
+	requireInvalidTx(suite.T(), suite.anteHandler, checkCtx, tx, false)
+
+	params := suite.app.EvmKeeper.GetParams(suite.ctx)
+	params.Paymaster = paymaster.String()
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	// the fee allowance doesn't cover the fee
+	suite.app.EvmKeeper.SetFeeAllowance(suite.ctx, sender, to, sdk.NewIntFromBigInt(fee).SubRaw(1))
+	requireInvalidTx(suite.T(), suite.anteHandler, checkCtx, tx, false)
+
+	suite.app.EvmKeeper.SetFeeAllowance(suite.ctx, sender, to, sdk.NewIntFromBigInt(fee).AddRaw(1))
+	newCtx, err := suite.anteHandler(suite.ctx, tx, false)
+	suite.Require().NoError(err)
+	suite.Require().Equal(paymaster, evmtypes.FeePayer(newCtx))
+
+	// the paymaster paid the fee from the allowance and the sender kept its balance
+	paymasterBalance := suite.app.AccountKeeper.GetAccount(suite.ctx, paymaster).GetCoins().AmountOf(types.AttoPhoton)
+	suite.Require().Equal(newTestCoins().AmountOf(types.AttoPhoton).Sub(sdk.NewIntFromBigInt(fee)), paymasterBalance)
+	suite.Require().Equal(int64(32), suite.app.AccountKeeper.GetAccount(suite.ctx, addr1).GetCoins().AmountOf(types.AttoPhoton).Int64())
+
+	allowance, found := suite.app.EvmKeeper.GetFeeAllowance(suite.ctx, sender, to)
+	suite.Require().True(found)
+	suite.Require().Equal(int64(1), allowance.Int64())
+
+	// contract creations are not sponsored
+	ethMsg = evmtypes.NewMsgEthereumTx(1, nil, amt, 60000, gas, []byte("test"))
+	tx, err = newTestEthTx(suite.ctx, ethMsg, priv1)
+	suite.Require().NoError(err)
+	requireInvalidTx(suite.T(), suite.anteHandler, checkCtx, tx, false)
+}
+
+func (suite *AnteTestSuite) TestEthDeployerAllowlist() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	addr1, priv1 := newTestAddrKey()
+	addr2, _ := newTestAddrKey()
+
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	_ = acc.SetCoins(newTestCoins())
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	params := suite.app.EvmKeeper.GetParams(suite.ctx)
+	params.AllowedDeployers = []string{ethcmn.BytesToAddress(addr2.Bytes()).Hex()}
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	amt := big.NewInt(32)
+	gas := big.NewInt(20)
+
+	// contract creations from a deployer that isn't allowed are rejected
+	ethMsg := evmtypes.NewMsgEthereumTx(0, nil, amt, 60000, gas, []byte("test"))
+	tx, err := newTestEthTx(suite.ctx, ethMsg, priv1)
+	suite.Require().NoError(err)
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
+
+	// calls are not restricted
+	to := ethcmn.BytesToAddress(addr2.Bytes())
+	ethMsg = evmtypes.NewMsgEthereumTx(0, &to, amt, 22000, gas, []byte("test"))
+	tx, err = newTestEthTx(suite.ctx, ethMsg, priv1)
+	suite.Require().NoError(err)
+	requireValidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
+
+	params.AllowedDeployers = append(params.AllowedDeployers, ethcmn.BytesToAddress(addr1.Bytes()).Hex())
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	ethMsg = evmtypes.NewMsgEthereumTx(1, nil, amt, 60000, gas, []byte("test"))
+	tx, err = newTestEthTx(suite.ctx, ethMsg, priv1)
+	suite.Require().NoError(err)
+	requireValidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
+}
+
+func (suite *AnteTestSuite) TestEthBlocklist() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	addr1, priv1 := newTestAddrKey()
+	addr2, _ := newTestAddrKey()
+	addr3, _ := newTestAddrKey()
+
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	_ = acc.SetCoins(newTestCoins())
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	blocked := ethcmn.BytesToAddress(addr2.Bytes())
+	params := suite.app.EvmKeeper.GetParams(suite.ctx)
+	params.BlockedAddresses = []string{blocked.Hex()}
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	amt := big.NewInt(32)
+	gas := big.NewInt(20)
+
+	// value transfers to a blocked address are rejected and recorded on DeliverTx
+	ethMsg := evmtypes.NewMsgEthereumTx(0, &blocked, amt, 22000, gas, []byte("test"))
+	tx, err := newTestEthTx(suite.ctx, ethMsg, priv1)
+	suite.Require().NoError(err)
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx.WithIsCheckTx(true), tx, false)
+	suite.Require().Empty(suite.app.EvmKeeper.BlockedTransfers)
+
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx.WithIsCheckTx(false), tx, false)
+	suite.Require().Len(suite.app.EvmKeeper.BlockedTransfers, 1)
+	suite.Require().Equal(blocked, suite.app.EvmKeeper.BlockedTransfers[0].Blocked)
+
+	// transfers without value are not restricted
+	ethMsg = evmtypes.NewMsgEthereumTx(0, &blocked, big.NewInt(0), 22000, gas, []byte("test"))
+	tx, err = newTestEthTx(suite.ctx, ethMsg, priv1)
+	suite.Require().NoError(err)
+	requireValidTx(suite.T(), suite.anteHandler, suite.ctx.WithIsCheckTx(true), tx, false)
+
+	to := ethcmn.BytesToAddress(addr3.Bytes())
+	ethMsg = evmtypes.NewMsgEthereumTx(1, &to, amt, 22000, gas, []byte("test"))
+	tx, err = newTestEthTx(suite.ctx, ethMsg, priv1)
+	suite.Require().NoError(err)
+	requireValidTx(suite.T(), suite.anteHandler, suite.ctx.WithIsCheckTx(true), tx, false)
+}
+
+func (suite *AnteTestSuite) TestEthChainIDParam() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	addr1, priv1 := newTestAddrKey()
+	addr2, _ := newTestAddrKey()
+
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	_ = acc.SetCoins(newTestCoins())
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	to := ethcmn.BytesToAddress(addr2.Bytes())
+	amt := big.NewInt(32)
+	gas := big.NewInt(20)
+
+	// txs signed with the chain-id epoch are valid while the ChainID param isn't set
+	ethMsg := evmtypes.NewMsgEthereumTx(0, &to, amt, 22000, gas, []byte("test"))
+	tx, err := newTestEthTx(suite.ctx, ethMsg, priv1)
+	suite.Require().NoError(err)
+	requireValidTx(suite.T(), suite.anteHandler, suite.ctx.WithIsCheckTx(true), tx, false)
+
+	params := suite.app.EvmKeeper.GetParams(suite.ctx)
+	params.ChainID = 9000
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	// the param takes precedence over the chain-id epoch
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx.WithIsCheckTx(true), tx, false)
+
+	privkey, ok := priv1.(ethsecp256k1.PrivKey)
+	suite.Require().True(ok)
+
+	ethMsg = evmtypes.NewMsgEthereumTx(1, &to, amt, 22000, gas, []byte("test"))
+	suite.Require().NoError(ethMsg.Sign(big.NewInt(9000), privkey.ToECDSA()))
+
+	// the Cosmos chain-id doesn't need to follow the {identifier}-{epoch} format
+	requireValidTx(suite.T(), suite.anteHandler, suite.ctx.WithChainID("ethermint").WithIsCheckTx(true), ethMsg, false)
+}
@@ -15,11 +15,18 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	ethcore "github.com/ethereum/go-ethereum/core"
+
+	tmtypes "github.com/tendermint/tendermint/types"
 )
 
 // EVMKeeper defines the expected keeper interface used on the Eth AnteHandler
 type EVMKeeper interface {
 	GetParams(ctx sdk.Context) evmtypes.Params
+	BlockGasUsed() uint64
+	GetFeeSponsor(ctx sdk.Context, sender, contract common.Address, fee *big.Int) (sdk.AccAddress, bool)
+	UseFeeAllowance(ctx sdk.Context, sender, contract common.Address, fee *big.Int)
+	AddBlockedTransfer(txHash common.Hash, transfer evmtypes.BlockedTransfer)
+	ChainID(ctx sdk.Context) (*big.Int, error)
 }
 
 // EthSetupContextDecorator sets the infinite GasMeter in the Context and wraps
@@ -125,11 +132,15 @@ func (emfd EthMempoolFeeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simula
 }
 
 // EthSigVerificationDecorator validates an ethereum signature
-type EthSigVerificationDecorator struct{}
+type EthSigVerificationDecorator struct {
+	evmKeeper EVMKeeper
+}
 
 // NewEthSigVerificationDecorator creates a new EthSigVerificationDecorator
-func NewEthSigVerificationDecorator() EthSigVerificationDecorator {
-	return EthSigVerificationDecorator{}
+func NewEthSigVerificationDecorator(ek EVMKeeper) EthSigVerificationDecorator {
+	return EthSigVerificationDecorator{
+		evmKeeper: ek,
+	}
 }
 
 // AnteHandle validates the signature and returns sender address
@@ -139,8 +150,8 @@ func (esvd EthSigVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, s
 		return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "invalid transaction type: %T", tx)
 	}
 
-	// parse the chainID from a string to a base-10 integer
-	chainIDEpoch, err := ethermint.ParseChainID(ctx.ChainID())
+	// get the EIP-155 chain ID from the params or the chain-id epoch
+	chainIDEpoch, err := esvd.evmKeeper.ChainID(ctx)
 	if err != nil {
 		return ctx, err
 	}
@@ -157,6 +168,91 @@ func (esvd EthSigVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, s
 	return next(ctx, msgEthTx, simulate)
 }
 
+// EthDeployerAllowlistDecorator validates that the sender of a contract creation
+// transaction is allowed to deploy contracts.
+type EthDeployerAllowlistDecorator struct {
+	evmKeeper EVMKeeper
+}
+
+// NewEthDeployerAllowlistDecorator creates a new EthDeployerAllowlistDecorator
+func NewEthDeployerAllowlistDecorator(ek EVMKeeper) EthDeployerAllowlistDecorator {
+	return EthDeployerAllowlistDecorator{
+		evmKeeper: ek,
+	}
+}
+
+// AnteHandle rejects the contract creation transactions from senders that aren't on the
+// AllowedDeployers parameter. The deployments from inside contracts and the code hash
+// allowlist are enforced during the EVM execution.
+func (edad EthDeployerAllowlistDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, err error) {
+	msgEthTx, ok := tx.(evmtypes.MsgEthereumTx)
+	if !ok {
+		return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "invalid transaction type: %T", tx)
+	}
+
+	if msgEthTx.To() != nil {
+		return next(ctx, tx, simulate)
+	}
+
+	// sender address should be in the tx cache from the previous AnteHandle call
+	sender := common.BytesToAddress(msgEthTx.From().Bytes())
+	if !edad.evmKeeper.GetParams(ctx).IsDeployerAllowed(sender) {
+		return ctx, sdkerrors.Wrapf(evmtypes.ErrDeployerNotAllowed, "%s", sender)
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// EthBlocklistDecorator validates that neither the sender nor the recipient of the
+// transaction value are blocked.
+type EthBlocklistDecorator struct {
+	evmKeeper EVMKeeper
+}
+
+// NewEthBlocklistDecorator creates a new EthBlocklistDecorator
+func NewEthBlocklistDecorator(ek EVMKeeper) EthBlocklistDecorator {
+	return EthBlocklistDecorator{
+		evmKeeper: ek,
+	}
+}
+
+// AnteHandle rejects the transactions that transfer value from or to an address on the
+// BlockedAddresses parameter. The rejected transfers are recorded on DeliverTx in order
+// to be emitted as events on EndBlock. The internal value transfers are blocked during
+// the EVM execution.
+func (ebd EthBlocklistDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, err error) {
+	msgEthTx, ok := tx.(evmtypes.MsgEthereumTx)
+	if !ok {
+		return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "invalid transaction type: %T", tx)
+	}
+
+	params := ebd.evmKeeper.GetParams(ctx)
+	if len(params.BlockedAddresses) == 0 {
+		return next(ctx, tx, simulate)
+	}
+
+	// sender address should be in the tx cache from the previous AnteHandle call
+	sender := common.BytesToAddress(msgEthTx.From().Bytes())
+
+	// the recipient of a contract creation is the zero address, as the contract address
+	// can't be blocked before its deployment
+	var recipient common.Address
+	if to := msgEthTx.To(); to != nil {
+		recipient = *to
+	}
+
+	blocked := evmtypes.NewBlockedTransfer(params, sender, recipient, msgEthTx.Data.Amount.BigInt())
+	if blocked == nil {
+		return next(ctx, tx, simulate)
+	}
+
+	if !ctx.IsCheckTx() && !simulate {
+		ebd.evmKeeper.AddBlockedTransfer(common.BytesToHash(tmtypes.Tx(ctx.TxBytes()).Hash()), *blocked)
+	}
+
+	return ctx, blocked
+}
+
 // AccountVerificationDecorator validates an account balance checks
 type AccountVerificationDecorator struct {
 	ak        auth.AccountKeeper
@@ -204,12 +300,18 @@ func (avd AccountVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, s
 
 	evmDenom := avd.evmKeeper.GetParams(ctx).EvmDenom
 
+	// the sender only pays for the value transferred if the fees are sponsored
+	cost := msgEthTx.Cost()
+	if _, sponsored := feeSponsor(ctx, avd.evmKeeper, msgEthTx, msgEthTx.Fee()); sponsored {
+		cost = msgEthTx.Data.Amount.BigInt()
+	}
+
 	// validate sender has enough funds to pay for gas cost
 	balance := acc.GetCoins().AmountOf(evmDenom)
-	if balance.BigInt().Cmp(msgEthTx.Cost()) < 0 {
+	if balance.BigInt().Cmp(cost) < 0 {
 		return ctx, sdkerrors.Wrapf(
 			sdkerrors.ErrInsufficientFunds,
-			"sender balance < tx gas cost (%s%s < %s%s)", balance.String(), evmDenom, msgEthTx.Cost().String(), evmDenom,
+			"sender balance < tx gas cost (%s%s < %s%s)", balance.String(), evmDenom, cost.String(), evmDenom,
 		)
 	}
 
@@ -217,20 +319,35 @@ func (avd AccountVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, s
 }
 
 // NonceVerificationDecorator checks that the account nonce from the transaction matches
-// the sender account sequence.
+// the sender account sequence. During CheckTx, transactions with a nonce within the
+// window of the NonceQueue above the sender sequence are accepted as well, and so are the
+// transactions that replace a pending transaction with the same nonce.
 type NonceVerificationDecorator struct {
-	ak auth.AccountKeeper
+	ak         auth.AccountKeeper
+	nonceQueue *NonceQueue
 }
 
-// NewNonceVerificationDecorator creates a new NonceVerificationDecorator
-func NewNonceVerificationDecorator(ak auth.AccountKeeper) NonceVerificationDecorator {
+// NewNonceVerificationDecorator creates a new NonceVerificationDecorator. A nil
+// NonceQueue disables the future nonces.
+func NewNonceVerificationDecorator(ak auth.AccountKeeper, nq *NonceQueue) NonceVerificationDecorator {
 	return NonceVerificationDecorator{
-		ak: ak,
+		ak:         ak,
+		nonceQueue: nq,
 	}
 }
 
 // AnteHandle validates that the transaction nonce is valid (equivalent to the sender account’s
 // current nonce).
+//
+// NOTE: if the NonceQueue is enabled, a CheckTx transaction with a nonce greater than the sender
+// sequence passes the verification if it's within the queue window. It's then held on the
+// NonceQueue, out of the mempool, by the IncrementSenderSequenceDecorator. The rechecked
+// transactions must match the sequence, as the queued ones aren't on the mempool.
+//
+// NOTE: if the replacements are enabled, a CheckTx transaction with the nonce of a pending
+// transaction is accepted if its gas price is bumped enough. The replaced transaction remains on
+// the mempool until it's rechecked (after the next block is committed), when it's rejected. If
+// it's included on the next block, the replacement is the one that fails instead.
 func (nvd NonceVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, err error) {
 	msgEthTx, ok := tx.(evmtypes.MsgEthereumTx)
 	if !ok {
@@ -252,13 +369,101 @@ func (nvd NonceVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, sim
 	}
 
 	seq := acc.GetSequence()
-	// if multiple transactions are submitted in succession with increasing nonces,
-	// all will be rejected except the first, since the first needs to be included in a block
-	// before the sequence increments
-	if msgEthTx.Data.AccountNonce != seq {
+	nonce := msgEthTx.Data.AccountNonce
+
+	replace := replaceTxs(ctx, simulate, nvd.nonceQueue)
+	if replace && ctx.IsReCheckTx() && nvd.nonceQueue.Dropped(address, tmtypes.Tx(ctx.TxBytes()).Hash()) {
+		return ctx, sdkerrors.Wrapf(
+			sdkerrors.ErrInvalidSequence,
+			"transaction with nonce %d has been replaced", nonce,
+		)
+	}
+
+	// if multiple transactions are submitted out of order, the ones with a nonce greater than
+	// the sequence are queued (during CheckTx) until the transaction that fills the gap arrives
+	if nonce > seq && queueNonces(ctx, simulate, nvd.nonceQueue) && !ctx.IsReCheckTx() &&
+		nvd.nonceQueue.CanQueue(seq, nonce) {
+		return next(ctx, tx, simulate)
+	}
+
+	// a new transaction with the nonce of a pending one replaces it if the gas price is bumped
+	if nonce != seq && replace && !ctx.IsReCheckTx() {
+		if minPrice, ok := nvd.nonceQueue.ReplacementPrice(address, nonce); ok {
+			if msgEthTx.Data.Price.BigInt().Cmp(minPrice) < 0 {
+				return ctx, sdkerrors.Wrapf(
+					sdkerrors.ErrInsufficientFee,
+					"replacement transaction underpriced; got %s, minimum %s", msgEthTx.Data.Price, minPrice,
+				)
+			}
+
+			return next(ctx, tx, simulate)
+		}
+	}
+
+	if nonce != seq {
 		return ctx, sdkerrors.Wrapf(
 			sdkerrors.ErrInvalidSequence,
-			"invalid nonce; got %d, expected %d", msgEthTx.Data.AccountNonce, seq,
+			"invalid nonce; got %d, expected %d", nonce, seq,
+		)
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// queueNonces returns true if the nonces of the transaction can be queued, i.e the queue
+// is enabled and the transaction is being checked for the mempool.
+func queueNonces(ctx sdk.Context, simulate bool, nq *NonceQueue) bool {
+	return ctx.IsCheckTx() && !simulate && nq.Enabled()
+}
+
+// replaceTxs returns true if the pending transactions can be replaced, i.e the replacements
+// are enabled and the transaction is being checked for the mempool.
+func replaceTxs(ctx sdk.Context, simulate bool, nq *NonceQueue) bool {
+	return ctx.IsCheckTx() && !simulate && nq.ReplaceEnabled()
+}
+
+// EthBlockGasLimitDecorator validates that the Ethereum tx gas limit doesn't exceed
+// the gas that is still available on the current block.
+type EthBlockGasLimitDecorator struct {
+	evmKeeper EVMKeeper
+}
+
+// NewEthBlockGasLimitDecorator creates a new EthBlockGasLimitDecorator
+func NewEthBlockGasLimitDecorator(ek EVMKeeper) EthBlockGasLimitDecorator {
+	return EthBlockGasLimitDecorator{
+		evmKeeper: ek,
+	}
+}
+
+// AnteHandle rejects the transaction if its gas limit is greater than the remaining
+// block gas, i.e the block gas limit from the consensus params minus the cumulative gas
+// used by the EVM transactions on the block.
+//
+// NOTE: the cumulative gas used is only known during DeliverTx, so the gas limit is
+// validated against the total block gas limit during CheckTx.
+func (ebgd EthBlockGasLimitDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, err error) {
+	msgEthTx, ok := tx.(evmtypes.MsgEthereumTx)
+	if !ok {
+		return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "invalid transaction type: %T", tx)
+	}
+
+	blockGasLimit := ethermint.BlockGasLimit(ctx)
+
+	var blockGasUsed uint64
+	if !ctx.IsCheckTx() {
+		blockGasUsed = ebgd.evmKeeper.BlockGasUsed()
+	}
+
+	var remainingGas uint64
+	if blockGasLimit > blockGasUsed {
+		remainingGas = blockGasLimit - blockGasUsed
+	}
+
+	gasLimit := msgEthTx.GetGas()
+	if gasLimit > remainingGas {
+		return ctx, sdkerrors.Wrapf(
+			sdkerrors.ErrOutOfGas,
+			"tx gas limit exceeds the remaining block gas: %d > %d", gasLimit, remainingGas,
 		)
 	}
 
@@ -284,6 +489,8 @@ func NewEthGasConsumeDecorator(ak auth.AccountKeeper, sk types.SupplyKeeper, ek
 
 // AnteHandle validates that the Ethereum tx message has enough to cover intrinsic gas
 // (during CheckTx only) and that the sender has enough balance to pay for the gas cost.
+// If the paymaster has granted a fee allowance to the sender for the called contract, the
+// gas cost is paid by the paymaster instead and deducted from the allowance.
 //
 // Intrinsic gas for a transaction is the amount of gas
 // that the transaction uses before the transaction is executed. The gas is a
@@ -336,7 +543,16 @@ func (egcd EthGasConsumeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simula
 			sdk.NewCoin(evmDenom, sdk.NewIntFromBigInt(cost)),
 		)
 
-		err = auth.DeductFees(egcd.sk, ctx, senderAcc, feeAmt)
+		payerAcc := senderAcc
+		if paymaster, sponsored := feeSponsor(ctx, egcd.evmKeeper, msgEthTx, cost); sponsored {
+			payerAcc = egcd.ak.GetAccount(ctx, paymaster)
+			egcd.evmKeeper.UseFeeAllowance(ctx, common.BytesToAddress(address.Bytes()), *msgEthTx.To(), cost)
+
+			// the unused gas is refunded to the paymaster
+			ctx = evmtypes.WithFeePayer(ctx, paymaster)
+		}
+
+		err = auth.DeductFees(egcd.sk, ctx, payerAcc, feeAmt)
 		if err != nil {
 			return ctx, err
 		}
@@ -347,23 +563,41 @@ func (egcd EthGasConsumeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simula
 	return next(newCtx, tx, simulate)
 }
 
+// feeSponsor returns the paymaster that pays the given fee on behalf of the sender of the
+// transaction. Contract creations are never sponsored.
+func feeSponsor(ctx sdk.Context, ek EVMKeeper, msgEthTx evmtypes.MsgEthereumTx, fee *big.Int) (sdk.AccAddress, bool) {
+	to := msgEthTx.To()
+	if to == nil {
+		return nil, false
+	}
+
+	return ek.GetFeeSponsor(ctx, common.BytesToAddress(msgEthTx.From().Bytes()), *to, fee)
+}
+
 // IncrementSenderSequenceDecorator increments the sequence of the signers. The
 // main difference with the SDK's IncrementSequenceDecorator is that the MsgEthereumTx
 // doesn't implement the SigVerifiableTx interface.
 //
 // CONTRACT: must be called after msg.VerifySig in order to cache the sender address.
 type IncrementSenderSequenceDecorator struct {
-	ak auth.AccountKeeper
+	ak         auth.AccountKeeper
+	nonceQueue *NonceQueue
 }
 
 // NewIncrementSenderSequenceDecorator creates a new IncrementSenderSequenceDecorator.
-func NewIncrementSenderSequenceDecorator(ak auth.AccountKeeper) IncrementSenderSequenceDecorator {
+func NewIncrementSenderSequenceDecorator(ak auth.AccountKeeper, nq *NonceQueue) IncrementSenderSequenceDecorator {
 	return IncrementSenderSequenceDecorator{
-		ak: ak,
+		ak:         ak,
+		nonceQueue: nq,
 	}
 }
 
-// AnteHandle handles incrementing the sequence of the sender.
+// AnteHandle handles incrementing the sequence of the sender. During CheckTx, the transactions
+// with a nonce greater than the sequence are held on the NonceQueue instead and rejected from the
+// mempool with ErrTxQueued, so that they can't be included on a block before the nonce gap is
+// filled. Once a transaction fills the gap, either on the mempool or on a block, the queued
+// transactions with the following nonces are broadcasted again. The replacements of pending
+// transactions don't update the sequence.
 func (issd IncrementSenderSequenceDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
 	// get and set account must be called with an infinite gas meter in order to prevent
 	// additional gas from being deducted.
@@ -376,10 +610,43 @@ func (issd IncrementSenderSequenceDecorator) AnteHandle(ctx sdk.Context, tx sdk.
 		return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "invalid transaction type: %T", tx)
 	}
 
+	queue := queueNonces(ctx, simulate, issd.nonceQueue)
+	replace := replaceTxs(ctx, simulate, issd.nonceQueue)
+	deliver := !ctx.IsCheckTx() && !simulate
+	nonce := msgEthTx.Data.AccountNonce
+
 	// increment sequence of all signers
 	for _, addr := range msgEthTx.GetSigners() {
 		acc := issd.ak.GetAccount(ctx, addr)
-		if err := acc.SetSequence(acc.GetSequence() + 1); err != nil {
+		seq := acc.GetSequence()
+
+		if replace {
+			issd.nonceQueue.Track(addr, nonce, tmtypes.Tx(ctx.TxBytes()).Hash(), msgEthTx.Data.Price.BigInt())
+		}
+
+		if deliver {
+			issd.nonceQueue.Prune(addr, nonce)
+			issd.nonceQueue.Release(addr, nonce)
+		}
+
+		switch {
+		case replace && nonce < seq:
+			// the replaced transaction already updated the pending sequence
+			continue
+		case queue && nonce > seq:
+			// the transaction is broadcasted again once the nonce gap is filled
+			issd.nonceQueue.Queue(addr, nonce, ctx.TxBytes(), ctx.BlockHeight())
+			return ctx.WithGasMeter(gasMeter), sdkerrors.Wrapf(
+				ethermint.ErrTxQueued,
+				"nonce %d is queued until the nonce %d is received", nonce, seq,
+			)
+		case queue && !ctx.IsReCheckTx():
+			issd.nonceQueue.Release(addr, nonce)
+		}
+
+		seq++
+
+		if err := acc.SetSequence(seq); err != nil {
 			panic(err)
 		}
 		issd.ak.SetAccount(ctx, acc)
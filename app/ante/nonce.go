@@ -0,0 +1,347 @@
+package ante
+
+import (
+	"math/big"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DefaultPriceBump is the default minimum gas price increase, in percent, to replace a
+// pending transaction on the mempool (same as the geth txpool).
+const DefaultPriceBump uint64 = 10
+
+// DefaultQueueLifetime is the default number of blocks a queued transaction is kept for
+// while its nonce gap isn't filled.
+const DefaultQueueLifetime int64 = 600
+
+// TxBroadcaster broadcasts the bytes of a transaction to the node mempool.
+type TxBroadcaster func(txBytes []byte) error
+
+// NonceQueue holds, for each sender, the Ethereum transactions submitted with a nonce
+// greater than the sender's pending nonce (i.e the check state sequence). It allows a
+// sender to submit transactions out of order within a window of MaxGap nonces above its
+// pending nonce.
+//
+// The queued transactions are kept out of the mempool, so that they can't be included
+// on a block before the transaction that fills the nonce gap, in which case they would
+// fail. Once the gap is filled, they are broadcasted again to the mempool in nonce order.
+// The transactions whose gap isn't filled within the queue lifetime are dropped.
+//
+// If the price bump is enabled, it also tracks the pending transaction of each sender
+// and nonce, so that a transaction can be replaced on the mempool by another one with
+// the same nonce and a gas price at least PriceBump percent higher.
+//
+// The queue is only used during CheckTx and it's not part of the consensus state.
+type NonceQueue struct {
+	mtx       sync.Mutex
+	maxGap    uint64
+	priceBump uint64
+	lifetime  int64
+	broadcast TxBroadcaster
+	// height of the last expiration of the queued transactions
+	expiredHeight int64
+	queued        map[string]map[uint64]queuedTx
+	pending       map[string]map[uint64]pendingTx
+	replaced      map[string]map[string]uint64
+}
+
+// queuedTx defines the bytes of a queued transaction and the height it was queued at.
+type queuedTx struct {
+	txBytes []byte
+	height  int64
+}
+
+// pendingTx defines the hash and gas price of a transaction accepted on the mempool.
+type pendingTx struct {
+	hash     string
+	gasPrice *big.Int
+}
+
+// NewNonceQueue creates a new NonceQueue that accepts nonces up to maxGap above the
+// sender's pending nonce. A maxGap of 0 disables the queue. The replacements use the
+// DefaultPriceBump and the queued transactions the DefaultQueueLifetime.
+func NewNonceQueue(maxGap uint64) *NonceQueue {
+	return &NonceQueue{
+		maxGap:    maxGap,
+		priceBump: DefaultPriceBump,
+		lifetime:  DefaultQueueLifetime,
+		queued:    make(map[string]map[uint64]queuedTx),
+		pending:   make(map[string]map[uint64]pendingTx),
+		replaced:  make(map[string]map[string]uint64),
+	}
+}
+
+// SetMaxGap sets the maximum gap between a queued nonce and the sender's pending nonce.
+func (nq *NonceQueue) SetMaxGap(maxGap uint64) {
+	nq.mtx.Lock()
+	defer nq.mtx.Unlock()
+
+	nq.maxGap = maxGap
+}
+
+// SetPriceBump sets the minimum gas price increase, in percent, for a transaction to
+// replace the pending transaction with the same sender and nonce. A price bump of 0
+// disables the replacements.
+func (nq *NonceQueue) SetPriceBump(priceBump uint64) {
+	nq.mtx.Lock()
+	defer nq.mtx.Unlock()
+
+	nq.priceBump = priceBump
+}
+
+// SetLifetime sets the number of blocks a queued transaction is kept for while its nonce
+// gap isn't filled.
+func (nq *NonceQueue) SetLifetime(lifetime int64) {
+	nq.mtx.Lock()
+	defer nq.mtx.Unlock()
+
+	nq.lifetime = lifetime
+}
+
+// SetBroadcaster sets the function that broadcasts the queued transactions to the mempool
+// once their nonce gap is filled. Without a broadcaster, those transactions are dropped.
+func (nq *NonceQueue) SetBroadcaster(broadcast TxBroadcaster) {
+	nq.mtx.Lock()
+	defer nq.mtx.Unlock()
+
+	nq.broadcast = broadcast
+}
+
+// Enabled returns true if the queue accepts nonces above the sender's pending nonce.
+func (nq *NonceQueue) Enabled() bool {
+	if nq == nil {
+		return false
+	}
+
+	nq.mtx.Lock()
+	defer nq.mtx.Unlock()
+
+	return nq.maxGap > 0
+}
+
+// CanQueue returns true if the nonce is within the queue window above the pending
+// nonce. A queued nonce can be queued again, which replaces its transaction.
+func (nq *NonceQueue) CanQueue(pendingNonce, nonce uint64) bool {
+	nq.mtx.Lock()
+	defer nq.mtx.Unlock()
+
+	return nonce > pendingNonce && nonce-pendingNonce <= nq.maxGap
+}
+
+// Queue adds the transaction to the queue of the sender, at the given block height. The
+// queued transactions that have outlived the queue lifetime are dropped.
+func (nq *NonceQueue) Queue(sender sdk.AccAddress, nonce uint64, txBytes []byte, height int64) {
+	nq.mtx.Lock()
+	defer nq.mtx.Unlock()
+
+	nq.expire(height)
+
+	txs, ok := nq.queued[sender.String()]
+	if !ok {
+		txs = make(map[uint64]queuedTx)
+		nq.queued[sender.String()] = txs
+	}
+
+	txs[nonce] = queuedTx{
+		txBytes: append([]byte{}, txBytes...),
+		height:  height,
+	}
+}
+
+// expire drops the queued transactions that have outlived the queue lifetime. It runs
+// once per block height.
+func (nq *NonceQueue) expire(height int64) {
+	if height <= nq.expiredHeight {
+		return
+	}
+
+	nq.expiredHeight = height
+
+	for sender, txs := range nq.queued {
+		for nonce, tx := range txs {
+			if height-tx.height > nq.lifetime {
+				delete(txs, nonce)
+			}
+		}
+
+		if len(txs) == 0 {
+			delete(nq.queued, sender)
+		}
+	}
+}
+
+// Release removes from the queue of the sender the transactions with contiguous nonces
+// after the given one, once the transaction with that nonce has been accepted on the
+// mempool, and broadcasts them in nonce order. The queued transactions with a lower
+// nonce are dropped. It returns the number of released transactions.
+func (nq *NonceQueue) Release(sender sdk.AccAddress, nonce uint64) int {
+	if nq == nil {
+		return 0
+	}
+
+	nq.mtx.Lock()
+	defer nq.mtx.Unlock()
+
+	txs, ok := nq.queued[sender.String()]
+	if !ok {
+		return 0
+	}
+
+	var released [][]byte
+	for next := nonce + 1; ; next++ {
+		tx, queued := txs[next]
+		if !queued {
+			break
+		}
+
+		released = append(released, tx.txBytes)
+		delete(txs, next)
+	}
+
+	for n := range txs {
+		if n <= nonce {
+			delete(txs, n)
+		}
+	}
+
+	if len(txs) == 0 {
+		delete(nq.queued, sender.String())
+	}
+
+	if len(released) == 0 || nq.broadcast == nil {
+		return len(released)
+	}
+
+	// the transactions are broadcasted asynchronously as the mempool is checking the
+	// transaction that filled the gap, and sequentially to keep the nonce order
+	broadcast := nq.broadcast
+	go func() {
+		for _, txBytes := range released {
+			if err := broadcast(txBytes); err != nil {
+				return
+			}
+		}
+	}()
+
+	return len(released)
+}
+
+// ReplaceEnabled returns true if the pending transactions can be replaced.
+func (nq *NonceQueue) ReplaceEnabled() bool {
+	if nq == nil {
+		return false
+	}
+
+	nq.mtx.Lock()
+	defer nq.mtx.Unlock()
+
+	return nq.priceBump > 0
+}
+
+// ReplacementPrice returns the minimum gas price for a transaction to replace the
+// pending transaction of the sender with the given nonce. It returns false if there's
+// no pending transaction with that nonce.
+func (nq *NonceQueue) ReplacementPrice(sender sdk.AccAddress, nonce uint64) (*big.Int, bool) {
+	nq.mtx.Lock()
+	defer nq.mtx.Unlock()
+
+	tx, ok := nq.pending[sender.String()][nonce]
+	if !ok {
+		return nil, false
+	}
+
+	// price * (100 + bump) / 100, and strictly greater than the current price
+	minPrice := new(big.Int).Mul(tx.gasPrice, new(big.Int).SetUint64(100+nq.priceBump))
+	minPrice.Quo(minPrice, big.NewInt(100))
+	if minPrice.Cmp(tx.gasPrice) <= 0 {
+		minPrice = new(big.Int).Add(tx.gasPrice, big.NewInt(1))
+	}
+
+	return minPrice, true
+}
+
+// Track sets the transaction as the pending transaction of the sender with the given
+// nonce. The transaction that was previously pending with the same nonce, if any, is
+// marked as replaced.
+func (nq *NonceQueue) Track(sender sdk.AccAddress, nonce uint64, hash []byte, gasPrice *big.Int) {
+	nq.mtx.Lock()
+	defer nq.mtx.Unlock()
+
+	key := sender.String()
+
+	txs, ok := nq.pending[key]
+	if !ok {
+		txs = make(map[uint64]pendingTx)
+		nq.pending[key] = txs
+	}
+
+	if prev, ok := txs[nonce]; ok && prev.hash != string(hash) {
+		replaced, ok := nq.replaced[key]
+		if !ok {
+			replaced = make(map[string]uint64)
+			nq.replaced[key] = replaced
+		}
+
+		replaced[prev.hash] = nonce
+	}
+
+	txs[nonce] = pendingTx{
+		hash:     string(hash),
+		gasPrice: new(big.Int).Set(gasPrice),
+	}
+}
+
+// Dropped returns true if the transaction has been replaced by another transaction of
+// the sender. The replaced transaction is forgotten, as it's expected to be removed from
+// the mempool.
+func (nq *NonceQueue) Dropped(sender sdk.AccAddress, hash []byte) bool {
+	nq.mtx.Lock()
+	defer nq.mtx.Unlock()
+
+	replaced := nq.replaced[sender.String()]
+	if _, ok := replaced[string(hash)]; !ok {
+		return false
+	}
+
+	delete(replaced, string(hash))
+	if len(replaced) == 0 {
+		delete(nq.replaced, sender.String())
+	}
+
+	return true
+}
+
+// Prune removes the pending and replaced transactions of the sender with a nonce lower
+// or equal to the given one, after the transaction with that nonce has been included on
+// a block.
+func (nq *NonceQueue) Prune(sender sdk.AccAddress, nonce uint64) {
+	if nq == nil {
+		return
+	}
+
+	nq.mtx.Lock()
+	defer nq.mtx.Unlock()
+
+	key := sender.String()
+
+	for n := range nq.pending[key] {
+		if n <= nonce {
+			delete(nq.pending[key], n)
+		}
+	}
+
+	for hash, n := range nq.replaced[key] {
+		if n <= nonce {
+			delete(nq.replaced[key], hash)
+		}
+	}
+
+	if len(nq.pending[key]) == 0 {
+		delete(nq.pending, key)
+	}
+
+	if len(nq.replaced[key]) == 0 {
+		delete(nq.replaced, key)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/suite"
 
+	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 
@@ -20,6 +21,7 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	tmcrypto "github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/multisig"
 )
 
 type AnteTestSuite struct {
@@ -39,7 +41,7 @@ func (suite *AnteTestSuite) SetupTest() {
 	suite.ctx = suite.app.BaseApp.NewContext(checkTx, abci.Header{Height: 1, ChainID: "ethermint-3", Time: time.Now().UTC()})
 	suite.app.EvmKeeper.SetParams(suite.ctx, evmtypes.DefaultParams())
 
-	suite.anteHandler = ante.NewAnteHandler(suite.app.AccountKeeper, suite.app.EvmKeeper, suite.app.SupplyKeeper)
+	suite.anteHandler = ante.NewAnteHandler(suite.app.AccountKeeper, suite.app.EvmKeeper, suite.app.SupplyKeeper, nil)
 }
 
 func TestAnteTestSuite(t *testing.T) {
@@ -89,6 +91,36 @@ func newTestSDKTx(
 	return auth.NewStdTx(msgs, fee, sigs, "")
 }
 
+// newTestMultisigTx returns a tx signed by the given keys of the multisig threshold public key.
+func newTestMultisigTx(
+	ctx sdk.Context, msgs []sdk.Msg, pubkey multisig.PubKeyMultisigThreshold, privs []tmcrypto.PrivKey,
+	accNum uint64, seq uint64, fee auth.StdFee,
+) sdk.Tx {
+
+	signBytes := auth.StdSignBytes(ctx.ChainID(), accNum, seq, fee, msgs, "")
+
+	multisignature := multisig.NewMultisig(len(pubkey.PubKeys))
+	for _, priv := range privs {
+		sig, err := priv.Sign(signBytes)
+		if err != nil {
+			panic(err)
+		}
+
+		if err := multisignature.AddSignatureFromPubKey(sig, priv.PubKey(), pubkey.PubKeys); err != nil {
+			panic(err)
+		}
+	}
+
+	sigs := []auth.StdSignature{
+		{
+			PubKey:    pubkey,
+			Signature: codec.Cdc.MustMarshalBinaryBare(multisignature),
+		},
+	}
+
+	return auth.NewStdTx(msgs, fee, sigs, "")
+}
+
 func newTestEthTx(ctx sdk.Context, msg evmtypes.MsgEthereumTx, priv tmcrypto.PrivKey) (sdk.Tx, error) {
 	chainIDEpoch, err := ethermint.ParseChainID(ctx.ChainID())
 	if err != nil {
@@ -129,6 +129,9 @@ type EthermintApp struct {
 
 	// simulation manager
 	sm *module.SimulationManager
+
+	// queue of the future nonces and pending txs accepted on the mempool
+	nonceQueue *ante.NonceQueue
 }
 
 // NewEthermintApp returns a reference to a new initialized Ethermint application.
@@ -209,7 +212,8 @@ func NewEthermintApp(
 	)
 	app.UpgradeKeeper = upgrade.NewKeeper(skipUpgradeHeights, keys[upgrade.StoreKey], app.cdc)
 	app.EvmKeeper = evm.NewKeeper(
-		app.cdc, keys[evm.StoreKey], app.subspaces[evm.ModuleName], app.AccountKeeper,
+		app.cdc, keys[evm.StoreKey], keys[auth.StoreKey], app.subspaces[evm.ModuleName], app.AccountKeeper,
+		&stakingKeeper, app.SupplyKeeper,
 	)
 
 	// create evidence keeper with router
@@ -303,7 +307,8 @@ func NewEthermintApp(
 	// initialize BaseApp
 	app.SetInitChainer(app.InitChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
-	app.SetAnteHandler(ante.NewAnteHandler(app.AccountKeeper, app.EvmKeeper, app.SupplyKeeper))
+	app.nonceQueue = ante.NewNonceQueue(0)
+	app.SetAnteHandler(ante.NewAnteHandler(app.AccountKeeper, app.EvmKeeper, app.SupplyKeeper, app.nonceQueue))
 	app.SetEndBlocker(app.EndBlocker)
 
 	if loadLatest {
@@ -366,6 +371,32 @@ func (app *EthermintApp) SimulationManager() *module.SimulationManager {
 	return app.sm
 }
 
+// SetMaxNonceGap sets the maximum gap between the nonce of an Ethereum transaction and the
+// sender's pending nonce for the transaction to be accepted on the mempool. A value of 0
+// only accepts the pending nonce.
+func (app *EthermintApp) SetMaxNonceGap(maxGap uint64) {
+	app.nonceQueue.SetMaxGap(maxGap)
+}
+
+// SetTxPriceBump sets the minimum gas price increase, in percent, for an Ethereum transaction
+// to replace the pending transaction with the same sender and nonce on the mempool. A value of
+// 0 disables the replacements.
+func (app *EthermintApp) SetTxPriceBump(priceBump uint64) {
+	app.nonceQueue.SetPriceBump(priceBump)
+}
+
+// SetTxQueueLifetime sets the number of blocks an Ethereum transaction with a future nonce is
+// held for, out of the mempool, while the gap to the sender's pending nonce isn't filled.
+func (app *EthermintApp) SetTxQueueLifetime(lifetime int64) {
+	app.nonceQueue.SetLifetime(lifetime)
+}
+
+// SetTxBroadcaster sets the function that broadcasts to the mempool the queued Ethereum
+// transactions once the gap to the sender's pending nonce is filled.
+func (app *EthermintApp) SetTxBroadcaster(broadcast ante.TxBroadcaster) {
+	app.nonceQueue.SetBroadcaster(broadcast)
+}
+
 // GetKey returns the KVStoreKey for the provided store key.
 //
 // NOTE: This is solely to be used for testing purposes.
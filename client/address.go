@@ -0,0 +1,109 @@
+package client
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+
+	ethermint "github.com/cosmos/ethermint/types"
+)
+
+// hexAddressArgs are the indexes of the positional arguments that take an account address on
+// the SDK query and tx commands, keyed by the command path without the root command. The EVM
+// commands parse the hex addresses themselves and aren't listed.
+var hexAddressArgs = map[string][]int{
+	"query account":                       {0},
+	"query auth account":                  {0},
+	"query staking delegation":            {0},
+	"query staking delegations":           {0},
+	"query staking unbonding-delegation":  {0},
+	"query staking unbonding-delegations": {0},
+	"query staking redelegation":          {0},
+	"query staking redelegations":         {0},
+	"query distribution rewards":          {0},
+	"query gov vote":                      {1},
+	"query gov deposit":                   {1},
+	"tx send":                             {0, 1},
+	"tx distribution set-withdraw-addr":   {0},
+}
+
+// hexAddressFlags are the string flags that take an account address, keyed by the command
+// path without the root command. The flags of the empty path apply to every command.
+var hexAddressFlags = map[string][]string{
+	"":                    {flags.FlagFrom},
+	"query gov proposals": {"depositor", "voter"},
+}
+
+// HexAddressArgs wraps the RunE function of a cobra command and of all of its subcommands
+// in order to accept 0x prefixed Ethereum hex (EIP-55) addresses on the account address
+// arguments and flags of the SDK commands (see hexAddressArgs and hexAddressFlags). The hex
+// addresses are converted to the Bech32 format before running the command. The other
+// arguments and flags (e.g calldata, hashes or keys) are left as they are.
+func HexAddressArgs(baseCmd *cobra.Command) *cobra.Command {
+	for _, cmd := range baseCmd.Commands() {
+		HexAddressArgs(cmd)
+	}
+
+	// Copy base run command to be used after the conversion
+	baseRunE := baseCmd.RunE
+	if baseRunE == nil {
+		return baseCmd
+	}
+
+	// Function to replace command's RunE function
+	convertFn := func(cmd *cobra.Command, args []string) error {
+		path := commandPath(cmd)
+
+		for _, i := range hexAddressArgs[path] {
+			if i >= len(args) {
+				continue
+			}
+
+			addr, err := ethermint.ConvertHexAddress(args[i])
+			if err != nil {
+				return err
+			}
+
+			args[i] = addr
+		}
+
+		for _, name := range append(hexAddressFlags[""], hexAddressFlags[path]...) {
+			if err := convertHexAddressFlag(cmd.Flags().Lookup(name)); err != nil {
+				return err
+			}
+		}
+
+		return baseRunE(cmd, args)
+	}
+
+	baseCmd.RunE = convertFn
+	return baseCmd
+}
+
+// commandPath returns the path of the command without the root command.
+func commandPath(cmd *cobra.Command) string {
+	path := strings.SplitN(cmd.CommandPath(), " ", 2)
+	if len(path) < 2 {
+		return ""
+	}
+
+	return path[1]
+}
+
+// convertHexAddressFlag converts the hex address of the given string flag to the Bech32 format.
+// The flags that aren't set are skipped.
+func convertHexAddressFlag(flag *pflag.Flag) error {
+	if flag == nil || !flag.Changed || flag.Value.Type() != "string" {
+		return nil
+	}
+
+	addr, err := ethermint.ConvertHexAddress(flag.Value.String())
+	if err != nil || addr == flag.Value.String() {
+		return err
+	}
+
+	return flag.Value.Set(addr)
+}
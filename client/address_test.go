@@ -0,0 +1,161 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+func TestHexAddressArgs(t *testing.T) {
+	hexAddr := "0x756F45E3FA69347A9A973A725E3C98bC4db0b5a0"
+	bech32Addr := sdk.AccAddress(ethcmn.HexToAddress(hexAddr).Bytes()).String()
+	hexAddr2 := ethcmn.HexToAddress("0x2cc70e9bb1d3c0df95a4b3fd8d5f4c5d2f0a0e8b").Hex()
+	bech32Addr2 := sdk.AccAddress(ethcmn.HexToAddress(hexAddr2).Bytes()).String()
+	txHash := "0x3f2a64a5f7a4c3f04e0b0e4f8b8f4d1a0f6c1e2d3b4a5968778695a4b3c2d1e0"
+	calldata := "0xa9059cbb000000000000000000000000756f45e3fa69347a9a973a725e3c98bc4db0b5a0"
+
+	testCases := []struct {
+		name     string
+		args     []string
+		expArgs  []string
+		expFlags map[string]string
+		expPass  bool
+	}{
+		{
+			"account arg",
+			[]string{"query", "account", hexAddr},
+			[]string{bech32Addr},
+			map[string]string{},
+			true,
+		},
+		{
+			"Bech32 account arg",
+			[]string{"query", "account", bech32Addr},
+			[]string{bech32Addr},
+			map[string]string{},
+			true,
+		},
+		{
+			"gov vote voter arg",
+			[]string{"query", "gov", "vote", "1", hexAddr},
+			[]string{"1", bech32Addr},
+			map[string]string{},
+			true,
+		},
+		{
+			"send args and from flag",
+			[]string{"tx", "send", hexAddr, hexAddr2, "10aphoton", "--from", hexAddr},
+			[]string{bech32Addr, bech32Addr2, "10aphoton"},
+			map[string]string{flags.FlagFrom: bech32Addr},
+			true,
+		},
+		{
+			"gov proposals flags",
+			[]string{"query", "gov", "proposals", "--depositor", hexAddr, "--voter", hexAddr2},
+			[]string{},
+			map[string]string{"depositor": bech32Addr, "voter": bech32Addr2},
+			true,
+		},
+		{
+			"tx hash arg unchanged",
+			[]string{"query", "tx", txHash},
+			[]string{txHash},
+			map[string]string{},
+			true,
+		},
+		{
+			"unlisted address arg unchanged",
+			[]string{"query", "evm", "code", hexAddr},
+			[]string{hexAddr},
+			map[string]string{},
+			true,
+		},
+		{
+			"calldata arg and flag unchanged",
+			[]string{"tx", "evm", "call", hexAddr, calldata, "--data", calldata},
+			[]string{hexAddr, calldata},
+			map[string]string{"data": calldata},
+			true,
+		},
+		{
+			"unlisted flag of a listed command unchanged",
+			[]string{"query", "gov", "proposals", "--status", txHash},
+			[]string{},
+			map[string]string{"status": txHash},
+			true,
+		},
+		{
+			"invalid checksum address arg",
+			[]string{"query", "account", "0x756f45E3FA69347A9A973A725E3C98bC4db0b5a0"},
+			nil,
+			nil,
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				gotArgs []string
+				gotCmd  *cobra.Command
+			)
+
+			runE := func(cmd *cobra.Command, args []string) error {
+				gotCmd = cmd
+				gotArgs = args
+				return nil
+			}
+
+			rootCmd := newTestCmd("ethermintcli", nil)
+			rootCmd.PersistentFlags().String(flags.FlagFrom, "", "")
+
+			queryCmd := newTestCmd("query", nil)
+			govQueryCmd := newTestCmd("gov", nil)
+			proposalsCmd := newTestCmd("proposals", runE)
+			proposalsCmd.Flags().String("depositor", "", "")
+			proposalsCmd.Flags().String("voter", "", "")
+			proposalsCmd.Flags().String("status", "", "")
+			govQueryCmd.AddCommand(newTestCmd("vote", runE), proposalsCmd)
+			evmQueryCmd := newTestCmd("evm", nil)
+			evmQueryCmd.AddCommand(newTestCmd("code", runE))
+			queryCmd.AddCommand(newTestCmd("account", runE), newTestCmd("tx", runE), govQueryCmd, evmQueryCmd)
+
+			txCmd := newTestCmd("tx", nil)
+			evmTxCmd := newTestCmd("evm", nil)
+			callCmd := newTestCmd("call", runE)
+			callCmd.Flags().String("data", "", "")
+			evmTxCmd.AddCommand(callCmd)
+			txCmd.AddCommand(newTestCmd("send", runE), evmTxCmd)
+
+			rootCmd.AddCommand(queryCmd, txCmd)
+			rootCmd.SetArgs(tc.args)
+
+			err := HexAddressArgs(rootCmd).Execute()
+			if !tc.expPass {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expArgs, gotArgs)
+			for name, value := range tc.expFlags {
+				require.Equal(t, value, gotCmd.Flags().Lookup(name).Value.String(), name)
+			}
+		})
+	}
+}
+
+func newTestCmd(use string, runE func(cmd *cobra.Command, args []string) error) *cobra.Command {
+	return &cobra.Command{
+		Use:           use,
+		RunE:          runE,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+}
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/debug"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/cosmos/ethermint/crypto/ethsecp256k1"
+	ethermint "github.com/cosmos/ethermint/types"
+)
+
+// DebugCmd returns the debug subcommands to convert addresses and public keys between their
+// Bech32, hex and Ethereum formats.
+func DebugCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Tool for helping with debugging your application",
+		RunE:  sdkclient.ValidateCmd,
+	}
+
+	cmd.AddCommand(
+		AddrCmd(),
+		debug.RawBytesCmd(),
+	)
+
+	return cmd
+}
+
+// AddrCmd converts an address or a public key to the Bech32, hex and Ethereum address formats.
+func AddrCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "addr [address]",
+		Short: "Convert an address between Bech32, hex and Ethereum (EIP-55) formats",
+		Long: fmt.Sprintf(`Convert an address between Bech32, hex and Ethereum (EIP-55) formats. The
+address of an eth_secp256k1 public key is derived if a Bech32 or hex encoded public key is given.
+
+Example:
+$ %s debug addr eth10jmp6sgh4cc6zt3e8gw05wavvejgr5pw2unfju
+$ %s debug addr 0x7cB61D4117AE31a12E393a1Cfa3BaC666481D02E
+$ %s debug addr 02f8ef0f7607a41f98514d89f29ee8a5d59c75e82a111804286581d5e8b8b6481f
+`, version.ClientName, version.ClientName, version.ClientName),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, pubKey, err := parseAddressOrPubKey(args[0])
+			if err != nil {
+				return err
+			}
+
+			if pubKey != nil {
+				accPub, err := sdk.Bech32ifyPubKey(sdk.Bech32PubKeyTypeAccPub, pubKey)
+				if err != nil {
+					return err
+				}
+
+				cmd.Printf("Public key (hex): %X\n", pubKey)
+				cmd.Println("Public key (Bech32):", accPub)
+			}
+
+			cmd.Printf("Address (hex): %X\n", addr.Bytes())
+			cmd.Println("Address (EIP-55):", ethcmn.BytesToAddress(addr.Bytes()).Hex())
+			cmd.Println("Bech32 Acc:", sdk.AccAddress(addr.Bytes()).String())
+			cmd.Println("Bech32 Val:", sdk.ValAddress(addr.Bytes()).String())
+			return nil
+		},
+	}
+}
+
+// parseAddressOrPubKey parses an address from its Bech32 account or validator format, its
+// 0x prefixed EIP-55 format or its raw hex format. It derives the address if a Bech32 or hex
+// encoded eth_secp256k1 public key is given instead.
+func parseAddressOrPubKey(value string) (tmcrypto.Address, tmcrypto.PubKey, error) {
+	if strings.HasPrefix(value, "0x") {
+		addr, err := ethermint.ParseHexAddress(value)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return addr.Bytes(), nil, nil
+	}
+
+	if bz, err := hex.DecodeString(value); err == nil {
+		switch len(bz) {
+		case ethcmn.AddressLength:
+			return bz, nil, nil
+		default:
+			// the address derivation panics on invalid public keys
+			if _, err := ethcrypto.DecompressPubkey(bz); err != nil {
+				return nil, nil, fmt.Errorf("invalid hex address or compressed public key: %w", err)
+			}
+
+			pubKey := ethsecp256k1.PubKey(bz)
+			return pubKey.Address(), pubKey, nil
+		}
+	}
+
+	if addr, err := sdk.AccAddressFromBech32(value); err == nil {
+		return addr.Bytes(), nil, nil
+	}
+
+	if addr, err := sdk.ValAddressFromBech32(value); err == nil {
+		return addr.Bytes(), nil, nil
+	}
+
+	if pubKey, err := sdk.GetPubKeyFromBech32(sdk.Bech32PubKeyTypeAccPub, value); err == nil {
+		return pubKey.Address(), pubKey, nil
+	}
+
+	return nil, nil, fmt.Errorf("expected a Bech32, hex or EIP-55 address or public key, got %s", value)
+}
@@ -64,13 +64,12 @@ func main() {
 	rootCmd.AddCommand(
 		clientrpc.StatusCommand(),
 		sdkclient.ConfigCmd(app.DefaultCLIHome),
-		queryCmd(cdc),
-		txCmd(cdc),
-		client.ValidateChainID(
-			rpc.ServeCmd(cdc),
-		),
+		client.HexAddressArgs(queryCmd(cdc)),
+		client.HexAddressArgs(txCmd(cdc)),
+		rpc.ServeCmd(cdc),
 		flags.LineBreak,
 		client.KeyCommands(),
+		client.DebugCmd(),
 		flags.LineBreak,
 		version.Cmd,
 		flags.NewCompletionCmd(rootCmd, true),
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/cli"
+
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/ethermint/app"
+	ethermint "github.com/cosmos/ethermint/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	flagExportHeight         = "height"
+	flagExportStart          = "start"
+	flagExportLimit          = "limit"
+	flagExportExcludeCode    = "exclude-code"
+	flagExportExcludeStorage = "exclude-storage"
+)
+
+// ExportEVMCmd returns the command to dump the EVM accounts of the application state at a
+// given height, in the geth iterative dump format.
+func ExportEVMCmd(ctx *server.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-evm",
+		Short: "Export the EVM accounts state to JSON, in the geth dump format",
+		Long: `Export the EthAccounts of the application state at the given height, with their balance
+of the EVM denomination, nonce, code hash, code and storage, in the iterative (line by line)
+format of 'geth dump --iterative'. The first line is the state root, followed by a line per
+account, sorted by address, so that the state of different nodes can be diffed.
+
+As the EVM state isn't stored on a Merkle Patricia trie, the root is the zero hash and the
+storage entries are keyed by their store key (i.e the hash of the address and the slot), as
+the slot preimages are not persisted.
+
+The export can be paginated with the --start and --limit flags, in which case the address of
+the next account is printed to stderr. The node must be stopped while the state is exported.
+
+Example:
+$ ethermintd export-evm --height 1000 --limit 10000 > dump.json
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := ctx.Config
+			config.SetRoot(viper.GetString(cli.HomeFlag))
+
+			var start []byte
+			if startAddr := viper.GetString(flagExportStart); startAddr != "" {
+				addr, err := ethermint.ParseHexAddress(startAddr)
+				if err != nil {
+					return fmt.Errorf("invalid start address: %w", err)
+				}
+
+				start = addr.Bytes()
+			}
+
+			db, err := sdk.NewLevelDB("application", filepath.Join(config.RootDir, "data"))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			var ethermintApp *app.EthermintApp
+
+			if height := viper.GetInt64(flagExportHeight); height != -1 {
+				ethermintApp = app.NewEthermintApp(ctx.Logger, db, nil, false, map[int64]bool{}, 0)
+				if err := ethermintApp.LoadHeight(height); err != nil {
+					return err
+				}
+			} else {
+				ethermintApp = app.NewEthermintApp(ctx.Logger, db, nil, true, map[int64]bool{}, 0)
+			}
+
+			sdkCtx := ethermintApp.NewContext(true, abci.Header{Height: ethermintApp.LastBlockHeight()})
+			csdb := ethermintApp.EvmKeeper.CommitStateDB.WithContext(sdkCtx)
+
+			output := bufio.NewWriter(os.Stdout)
+			nextKey := csdb.IterativeDump(
+				viper.GetBool(flagExportExcludeCode), viper.GetBool(flagExportExcludeStorage),
+				start, viper.GetInt(flagExportLimit), json.NewEncoder(output),
+			)
+
+			if err := output.Flush(); err != nil {
+				return err
+			}
+
+			if nextKey != nil {
+				// stdout holds the dump, so the next address is printed to stderr
+				fmt.Fprintf(os.Stderr, "next: %s\n", ethcmn.BytesToAddress(nextKey).String())
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64(flagExportHeight, -1, "Export the state from a particular height (-1 means latest height)")
+	cmd.Flags().String(flagExportStart, "", "Hex address of the first account to export")
+	cmd.Flags().Int(flagExportLimit, 0, "Maximum number of accounts to export (0 exports every account)")
+	cmd.Flags().Bool(flagExportExcludeCode, false, "Omit the code of the accounts")
+	cmd.Flags().Bool(flagExportExcludeStorage, false, "Omit the storage of the accounts")
+	return cmd
+}
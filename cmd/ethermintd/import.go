@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime/pprof"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tendermint/tendermint/libs/cli"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/server"
+
+	"github.com/cosmos/ethermint/importer"
+
+	ethcore "github.com/ethereum/go-ethereum/core"
+)
+
+const (
+	flagImportChain         = "chain"
+	flagImportGenesis       = "genesis"
+	flagImportDataDir       = "datadir"
+	flagImportCheckInterval = "check-interval"
+	flagImportBalances      = "balances"
+	flagImportCPUProfile    = "cpu-profile"
+)
+
+// ImportCmd returns the command to replay the blocks of an Ethereum chain export against
+// the EVM state.
+func ImportCmd(ctx *server.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [export-file]",
+		Short: "Replay the blocks of an Ethereum RLP chain export against the EVM state",
+		Long: `Replay the blocks of an Ethereum RLP chain export (i.e created with 'geth export') against
+the auth and EVM module stores, in order to benchmark the EVM against the Ethereum history.
+
+The gas used of every block is verified against its header, and the receipts root of the
+Byzantium blocks is verified every --check-interval blocks, where the import metrics are logged.
+The expected account balances of the --balances JSON file are verified after their block, eg:
+
+{"46147": {"0xA1E4380A3B1f749673E270229993eE55F35663b4": "2000000000000000000"}}
+
+Each block is committed to the database, so an interrupted import resumes from the last
+imported block when the command is run again with the same --datadir.
+
+Example:
+$ ethermintd import mainnet.rlp --chain mainnet --check-interval 10000
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := ctx.Config
+			config.SetRoot(viper.GetString(cli.HomeFlag))
+
+			genesis, err := importGenesis(viper.GetString(flagImportChain), viper.GetString(flagImportGenesis))
+			if err != nil {
+				return err
+			}
+
+			var balances importer.Balances
+			if path := viper.GetString(flagImportBalances); path != "" {
+				bz, err := ioutil.ReadFile(path)
+				if err != nil {
+					return err
+				}
+
+				if balances, err = importer.ParseBalances(bz); err != nil {
+					return err
+				}
+			}
+
+			if path := viper.GetString(flagImportCPUProfile); path != "" {
+				f, err := os.Create(path)
+				if err != nil {
+					return fmt.Errorf("failed to create CPU profile: %w", err)
+				}
+
+				if err := pprof.StartCPUProfile(f); err != nil {
+					return fmt.Errorf("failed to start CPU profile: %w", err)
+				}
+				defer pprof.StopCPUProfile()
+			}
+
+			dataDir := viper.GetString(flagImportDataDir)
+			if dataDir == "" {
+				dataDir = config.DBDir()
+			}
+
+			db, err := dbm.NewGoLevelDB("import", dataDir)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			im, err := importer.NewImporter(db, importer.Config{
+				Genesis:       genesis,
+				CheckInterval: viper.GetUint64(flagImportCheckInterval),
+				Balances:      balances,
+			}, ctx.Logger)
+			if err != nil {
+				return err
+			}
+
+			input, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer input.Close()
+
+			// stop the import after the current block on interrupt, so it can be resumed
+			goCtx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			sigs := make(chan os.Signal, 1)
+			signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+			defer signal.Stop(sigs)
+
+			go func() {
+				select {
+				case <-sigs:
+					cancel()
+				case <-goCtx.Done():
+				}
+			}()
+
+			metrics, err := im.Import(goCtx, input)
+			ctx.Logger.Info("imported blocks", "last-block", im.LastBlock(), "metrics", metrics.String())
+
+			if errors.Is(err, context.Canceled) {
+				ctx.Logger.Info("import interrupted, run the command again to resume it")
+				return nil
+			}
+
+			return err
+		},
+	}
+
+	cmd.Flags().String(flagImportChain, "mainnet", "Ethereum network of the export (mainnet, ropsten, rinkeby or goerli)")
+	cmd.Flags().String(flagImportGenesis, "", "Path to a geth genesis JSON file of the export, overrides --chain")
+	cmd.Flags().String(flagImportDataDir, "", fmt.Sprintf("Directory of the import database (default %s)", filepath.Join("<home>", "data")))
+	cmd.Flags().Uint64(flagImportCheckInterval, 1000, "Verify the receipts root and log the import metrics every N blocks (0 disables the checkpoints)")
+	cmd.Flags().String(flagImportBalances, "", "Path to a JSON file with the expected account balances by block number")
+	cmd.Flags().String(flagImportCPUProfile, "", "Write a CPU profile of the import to the given file")
+	return cmd
+}
+
+// importGenesis returns the genesis of the given Ethereum network, or the one defined on the
+// geth genesis file if the path is not empty.
+func importGenesis(chain, path string) (*ethcore.Genesis, error) {
+	if path == "" {
+		return importer.ChainGenesis(chain)
+	}
+
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	genesis := new(ethcore.Genesis)
+	if err := json.Unmarshal(bz, genesis); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal genesis file %s: %w", path, err)
+	}
+
+	return genesis, nil
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 
 	"github.com/spf13/cobra"
@@ -11,6 +12,7 @@ import (
 	tmamino "github.com/tendermint/tendermint/crypto/encoding/amino"
 	"github.com/tendermint/tendermint/libs/cli"
 	"github.com/tendermint/tendermint/libs/log"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
 	tmtypes "github.com/tendermint/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 
@@ -28,15 +30,26 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/staking"
 
 	"github.com/cosmos/ethermint/app"
+	"github.com/cosmos/ethermint/app/ante"
 	"github.com/cosmos/ethermint/client"
 	"github.com/cosmos/ethermint/codec"
 	"github.com/cosmos/ethermint/crypto/ethsecp256k1"
 	ethermint "github.com/cosmos/ethermint/types"
 )
 
-const flagInvCheckPeriod = "inv-check-period"
+const (
+	flagInvCheckPeriod = "inv-check-period"
+	flagMaxNonceGap    = "max-nonce-gap"
+	flagTxPriceBump    = "tx-price-bump"
+	flagTxQueueLife    = "tx-queue-lifetime"
+)
 
-var invCheckPeriod uint
+var (
+	invCheckPeriod uint
+	maxNonceGap    uint64
+	txPriceBump    uint64
+	txQueueLife    int64
+)
 
 func main() {
 	cobra.EnableCommandSorting = false
@@ -80,6 +93,12 @@ func main() {
 		client.TestnetCmd(ctx, cdc, app.ModuleBasics, auth.GenesisAccountIterator{}),
 		// AddGenesisAccountCmd allows users to add accounts to the genesis file
 		AddGenesisAccountCmd(ctx, cdc, app.DefaultNodeHome, app.DefaultCLIHome),
+		// ImportCmd replays the blocks of an Ethereum chain export against the EVM state
+		ImportCmd(ctx),
+		// MigrateEthStateCmd migrates the accounts of an Ethereum state to the genesis file
+		MigrateEthStateCmd(ctx, cdc, app.DefaultNodeHome),
+		// ExportEVMCmd dumps the EVM accounts state at a given height
+		ExportEVMCmd(ctx),
 		flags.NewCompletionCmd(rootCmd, true),
 	)
 
@@ -90,6 +109,12 @@ func main() {
 	executor := cli.PrepareBaseCmd(rootCmd, "EM", app.DefaultNodeHome)
 	rootCmd.PersistentFlags().UintVar(&invCheckPeriod, flagInvCheckPeriod,
 		0, "Assert registered invariants every N blocks")
+	rootCmd.PersistentFlags().Uint64Var(&maxNonceGap, flagMaxNonceGap,
+		0, "Accept Ethereum txs on the mempool with a nonce up to N above the sender's pending nonce")
+	rootCmd.PersistentFlags().Uint64Var(&txPriceBump, flagTxPriceBump,
+		ante.DefaultPriceBump, "Minimum gas price bump percentage to replace a pending Ethereum tx with the same nonce (0 disables replacements)")
+	rootCmd.PersistentFlags().Int64Var(&txQueueLife, flagTxQueueLife,
+		ante.DefaultQueueLifetime, "Number of blocks an Ethereum tx with a future nonce is queued for until the nonce gap is filled")
 	err := executor.Execute()
 	if err != nil {
 		panic(err)
@@ -97,7 +122,7 @@ func main() {
 }
 
 func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application {
-	return app.NewEthermintApp(
+	ethermintApp := app.NewEthermintApp(
 		logger,
 		db,
 		traceStore,
@@ -108,6 +133,44 @@ func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application
 		baseapp.SetMinGasPrices(viper.GetString(server.FlagMinGasPrices)),
 		baseapp.SetHaltHeight(uint64(viper.GetInt(server.FlagHaltHeight))),
 	)
+
+	ethermintApp.SetMaxNonceGap(maxNonceGap)
+	ethermintApp.SetTxPriceBump(txPriceBump)
+	ethermintApp.SetTxQueueLifetime(txQueueLife)
+
+	if maxNonceGap > 0 {
+		ethermintApp.SetTxBroadcaster(newTxBroadcaster(logger))
+	}
+
+	return ethermintApp
+}
+
+// newTxBroadcaster returns a broadcaster that submits the queued Ethereum txs to the mempool
+// of the node through its RPC server once their nonce gap is filled.
+func newTxBroadcaster(logger log.Logger) ante.TxBroadcaster {
+	logger = logger.With("module", "nonce-queue")
+
+	return func(txBytes []byte) error {
+		node, err := rpchttp.New(viper.GetString("rpc.laddr"), "/websocket")
+		if err != nil {
+			logger.Error("failed to connect to the node", "error", err)
+			return err
+		}
+
+		res, err := node.BroadcastTxSync(txBytes)
+		if err != nil {
+			logger.Error("failed to broadcast queued tx", "error", err)
+			return err
+		}
+
+		if res.Code != abci.CodeTypeOK {
+			err = fmt.Errorf("queued tx %s rejected: %s", res.Hash, res.Log)
+			logger.Error(err.Error())
+			return err
+		}
+
+		return nil
+	}
 }
 
 func exportAppStateAndTMValidators(
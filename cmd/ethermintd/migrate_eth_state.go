@@ -0,0 +1,171 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/cli"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+
+	"github.com/cosmos/ethermint/app"
+	evmtypes "github.com/cosmos/ethermint/x/evm/types"
+)
+
+const flagMigrateChainID = "set-chain-id"
+
+// MigrateEthStateCmd returns the command to migrate the state of an Ethereum network to the
+// auth and EVM genesis states.
+func MigrateEthStateCmd(ctx *server.Context, cdc *codec.Codec, defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate-eth-state [state-file]",
+		Short: "Migrate the state of an Ethereum network to genesis.json",
+		Long: `Migrate the accounts of an Ethereum network state to genesis.json. The state file can be a
+geth genesis file, its alloc field or a geth state dump (i.e created with 'geth dump', in
+either the collected or the iterative format).
+
+Every account is added to the auth genesis state as an EthAccount with the same balance (in
+the EVM denomination), nonce and code hash, and the accounts with code are added to the EVM
+genesis accounts with their storage. The resulting genesis state is validated and the EVM
+balance, nonce and supply invariants are verified before the genesis file is written.
+
+Example:
+$ ethermintd migrate-eth-state dump.json --set-chain-id
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := ctx.Config
+			config.SetRoot(viper.GetString(cli.HomeFlag))
+
+			bz, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			ethGenesis, err := evmtypes.ParseEthState(bz)
+			if err != nil {
+				return err
+			}
+
+			genFile := config.GenesisFile()
+			appState, genDoc, err := genutil.GenesisStateFromGenFile(cdc, genFile)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal genesis state: %w", err)
+			}
+
+			authGenState := auth.GetGenesisStateFromAppState(cdc, appState)
+
+			var evmGenState evmtypes.GenesisState
+			if err := cdc.UnmarshalJSON(appState[evmtypes.ModuleName], &evmGenState); err != nil {
+				return fmt.Errorf("failed to unmarshal evm genesis state: %w", err)
+			}
+
+			authAccounts, evmAccounts, err := evmtypes.EthStateToGenesis(ethGenesis.Alloc, evmGenState.Params.EvmDenom)
+			if err != nil {
+				return err
+			}
+
+			for _, account := range authAccounts {
+				if authGenState.Accounts.Contains(account.GetAddress()) {
+					return fmt.Errorf("cannot add account at existing address %s", account.GetAddress())
+				}
+			}
+
+			for _, account := range evmAccounts {
+				for _, existing := range evmGenState.Accounts {
+					if existing.Address == account.Address {
+						return fmt.Errorf("cannot add evm account at existing address %s", account.Address)
+					}
+				}
+			}
+
+			if viper.GetBool(flagMigrateChainID) {
+				if ethGenesis.Config == nil || ethGenesis.Config.ChainID == nil {
+					return fmt.Errorf("the state file %s doesn't define a chain ID", args[0])
+				}
+
+				evmGenState.Params.ChainID = ethGenesis.Config.ChainID.Uint64()
+			}
+
+			authGenState.Accounts = append(authGenState.Accounts, authAccounts...)
+			authGenState.Accounts = auth.SanitizeGenesisAccounts(authGenState.Accounts)
+			evmGenState.Accounts = append(evmGenState.Accounts, evmAccounts...)
+
+			if err := auth.ValidateGenesis(authGenState); err != nil {
+				return fmt.Errorf("invalid auth genesis state: %w", err)
+			}
+
+			if err := evmGenState.Validate(); err != nil {
+				return fmt.Errorf("invalid evm genesis state: %w", err)
+			}
+
+			if appState[auth.ModuleName], err = cdc.MarshalJSON(authGenState); err != nil {
+				return fmt.Errorf("failed to marshal auth genesis state: %w", err)
+			}
+
+			if appState[evmtypes.ModuleName], err = cdc.MarshalJSON(evmGenState); err != nil {
+				return fmt.Errorf("failed to marshal evm genesis state: %w", err)
+			}
+
+			appStateJSON, err := cdc.MarshalJSON(appState)
+			if err != nil {
+				return fmt.Errorf("failed to marshal application genesis state: %w", err)
+			}
+
+			if err := checkEthStateInvariants(genDoc.ChainID, appStateJSON); err != nil {
+				return err
+			}
+
+			ctx.Logger.Info(
+				"migrated Ethereum state",
+				"accounts", len(authAccounts), "contracts", len(evmAccounts),
+				"evm-chain-id", evmGenState.Params.ChainID,
+			)
+
+			genDoc.AppState = appStateJSON
+			return genutil.ExportGenesisFile(genDoc, genFile)
+		},
+	}
+
+	cmd.Flags().String(cli.HomeFlag, defaultNodeHome, "node's home directory")
+	cmd.Flags().Bool(flagMigrateChainID, false, "Set the EVM chain ID param to the chain ID of the geth genesis config")
+	return cmd
+}
+
+// checkEthStateInvariants initializes an in-memory application with the given genesis app
+// state and verifies the EVM balance, nonce and supply invariants.
+func checkEthStateInvariants(chainID string, appStateJSON json.RawMessage) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to initialize the genesis state: %v", r)
+		}
+	}()
+
+	ethermintApp := app.NewEthermintApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, map[int64]bool{}, 0)
+	ethermintApp.InitChain(abci.RequestInitChain{ChainId: chainID, AppStateBytes: appStateJSON})
+
+	ctx := ethermintApp.BaseApp.NewContext(false, abci.Header{ChainID: chainID})
+
+	for _, invariant := range []sdk.Invariant{
+		ethermintApp.EvmKeeper.BalanceInvariant(),
+		ethermintApp.EvmKeeper.NonceInvariant(),
+		ethermintApp.EvmKeeper.SupplyInvariant(),
+	} {
+		if msg, broken := invariant(ctx); broken {
+			return fmt.Errorf("invariant broken by the migrated state: %s", msg)
+		}
+	}
+
+	return nil
+}
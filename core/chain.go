@@ -46,6 +46,12 @@ func (cc *ChainContext) SetHeader(number uint64, header *ethtypes.Header) {
 	cc.headersByNumber[number] = header
 }
 
+// DeleteHeader removes the header of the given block number, e.g. once it's older than the
+// 256 most recent blocks accessible with the BLOCKHASH opcode.
+func (cc *ChainContext) DeleteHeader(number uint64) {
+	delete(cc.headersByNumber, number)
+}
+
 // GetHeader implements Ethereum's core.ChainContext interface.
 //
 // TODO: The Cosmos SDK supports retreiving such information in contexts and
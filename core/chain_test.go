@@ -51,6 +51,17 @@ func TestChainContextGetHeader(t *testing.T) {
 	require.Nil(t, cc.GetHeader(ethcmn.Hash{}, 0))
 }
 
+func TestChainContextDeleteHeader(t *testing.T) {
+	cc := NewChainContext()
+	header := &ethtypes.Header{
+		Number: big.NewInt(64),
+	}
+
+	cc.SetHeader(uint64(header.Number.Int64()), header)
+	cc.DeleteHeader(uint64(header.Number.Int64()))
+	require.Nil(t, cc.GetHeader(ethcmn.Hash{}, uint64(header.Number.Int64())))
+}
+
 func TestChainContextAuthor(t *testing.T) {
 	cc := NewChainContext()
 
@@ -9,6 +9,8 @@ import (
 	ethsecp256k1 "github.com/ethereum/go-ethereum/crypto/secp256k1"
 
 	tmcrypto "github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/multisig"
+	tmsecp256k1 "github.com/tendermint/tendermint/crypto/secp256k1"
 )
 
 func TestPrivKeyPrivKey(t *testing.T) {
@@ -59,3 +61,34 @@ func TestPrivKeyPubKey(t *testing.T) {
 	res := pubKey.VerifyBytes(msg, sig)
 	require.True(t, res)
 }
+
+func TestMultisigPubKey(t *testing.T) {
+	privKey1, err := GenerateKey()
+	require.NoError(t, err)
+	privKey2, err := GenerateKey()
+	require.NoError(t, err)
+
+	pubKey := multisig.NewPubKeyMultisigThreshold(2, []tmcrypto.PubKey{privKey1.PubKey(), privKey2.PubKey()})
+
+	// the Tendermint multisig codec can't encode the ethsecp256k1 keys
+	require.Panics(t, func() { _ = pubKey.Address() })
+	require.Len(t, PubKeyAddress(pubKey), 20)
+
+	// the keys supported by the Tendermint multisig codec have the same bytes and address
+	tmPubKey := multisig.NewPubKeyMultisigThreshold(1, []tmcrypto.PubKey{tmsecp256k1.GenPrivKey().PubKey()})
+	require.Equal(t, tmPubKey.Bytes(), PubKeyBytes(tmPubKey))
+	require.Equal(t, tmPubKey.Address(), PubKeyAddress(tmPubKey))
+
+	msg := []byte("hello world")
+	sig1, err := privKey1.Sign(msg)
+	require.NoError(t, err)
+	sig2, err := privKey2.Sign(msg)
+	require.NoError(t, err)
+
+	multisignature := multisig.NewMultisig(2)
+	require.NoError(t, multisignature.AddSignatureFromPubKey(sig1, privKey1.PubKey(), pubKey.(multisig.PubKeyMultisigThreshold).PubKeys))
+	require.False(t, pubKey.VerifyBytes(msg, multisignature.Marshal()))
+
+	require.NoError(t, multisignature.AddSignatureFromPubKey(sig2, privKey2.PubKey(), pubKey.(multisig.PubKeyMultisigThreshold).PubKeys))
+	require.True(t, pubKey.VerifyBytes(msg, multisignature.Marshal()))
+}
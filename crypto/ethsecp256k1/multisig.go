@@ -0,0 +1,48 @@
+package ethsecp256k1
+
+import (
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/multisig"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/crypto/sr25519"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// multisigCdc is the amino codec used to encode the multisig threshold public keys composed of
+// ethsecp256k1 keys. The Tendermint multisig codec only registers the Tendermint key types, so it
+// can't encode them. It registers the same types and amino routes as the Tendermint codec, so the
+// keys that it can encode get the same bytes and address.
+var multisigCdc = codec.New()
+
+func init() {
+	multisigCdc.RegisterInterface((*tmcrypto.PubKey)(nil), nil)
+	multisigCdc.RegisterConcrete(multisig.PubKeyMultisigThreshold{}, multisig.PubKeyMultisigThresholdAminoRoute, nil)
+	multisigCdc.RegisterConcrete(ed25519.PubKeyEd25519{}, ed25519.PubKeyAminoName, nil)
+	multisigCdc.RegisterConcrete(sr25519.PubKeySr25519{}, sr25519.PubKeyAminoName, nil)
+	multisigCdc.RegisterConcrete(secp256k1.PubKeySecp256k1{}, secp256k1.PubKeyAminoName, nil)
+	multisigCdc.RegisterConcrete(PubKey{}, PubKeyName, nil)
+}
+
+// PubKeyBytes returns the amino encoded bytes of the given public key. The multisig threshold
+// public keys are encoded with the ethermint multisig codec, as their Bytes method panics if
+// they contain ethsecp256k1 keys.
+func PubKeyBytes(pubKey tmcrypto.PubKey) []byte {
+	if pk, ok := pubKey.(multisig.PubKeyMultisigThreshold); ok {
+		return multisigCdc.MustMarshalBinaryBare(pk)
+	}
+
+	return pubKey.Bytes()
+}
+
+// PubKeyAddress returns the address of the given public key. The address of the multisig
+// threshold public keys is derived from their PubKeyBytes, as their Address method panics if
+// they contain ethsecp256k1 keys.
+func PubKeyAddress(pubKey tmcrypto.PubKey) tmcrypto.Address {
+	if pk, ok := pubKey.(multisig.PubKeyMultisigThreshold); ok {
+		return tmcrypto.AddressHash(PubKeyBytes(pk))
+	}
+
+	return pubKey.Address()
+}
@@ -0,0 +1,105 @@
+package signer
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/cosmos/ethermint/crypto/ethsecp256k1"
+	"github.com/cosmos/ethermint/crypto/hd"
+)
+
+var _ ExternalSigner = &MockDevice{}
+
+// EIP155Payload defines the RLP encoded fields of an unsigned EIP-155 transaction, as
+// decoded by the MockDevice.
+type EIP155Payload struct {
+	Nonce    uint64
+	GasPrice *big.Int
+	Gas      uint64
+	To       *ethcmn.Address `rlp:"nil"`
+	Value    *big.Int
+	Data     []byte
+	ChainID  *big.Int
+	R, S     uint
+}
+
+// MockDevice is an ExternalSigner that derives its keys from a mnemonic in memory. It
+// behaves like a hardware wallet running the Ethereum app, as it decodes the payload
+// before signing it, so that the device integrations can be developed and tested
+// without the physical device.
+type MockDevice struct {
+	mnemonic string
+	closed   bool
+
+	// Reject rejects the signature requests, as if the user denied them on the device.
+	Reject bool
+	// Signed are the decoded payloads signed by the device.
+	Signed []EIP155Payload
+}
+
+// NewMockDevice creates a new MockDevice that derives its keys from the given mnemonic.
+func NewMockDevice(mnemonic string) *MockDevice {
+	return &MockDevice{
+		mnemonic: mnemonic,
+	}
+}
+
+// PubKey implements ExternalSigner.
+func (md *MockDevice) PubKey(hdPath string) (ethsecp256k1.PubKey, error) {
+	privKey, err := md.derive(hdPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return privKey.PubKey().(ethsecp256k1.PubKey), nil
+}
+
+// SignEIP155 implements ExternalSigner. Unlike a plain secp256k1 signer, the payload must
+// be a valid EIP-155 transaction with a non-zero chain ID.
+func (md *MockDevice) SignEIP155(hdPath string, payload []byte) ([]byte, error) {
+	privKey, err := md.derive(hdPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var tx EIP155Payload
+	if err := rlp.DecodeBytes(payload, &tx); err != nil {
+		return nil, fmt.Errorf("invalid EIP-155 payload: %w", err)
+	}
+
+	if tx.ChainID == nil || tx.ChainID.Sign() == 0 || tx.R != 0 || tx.S != 0 {
+		return nil, errors.New("invalid EIP-155 payload: chain ID must be set and R, S must be 0")
+	}
+
+	if md.Reject {
+		return nil, ErrSignRejected
+	}
+
+	md.Signed = append(md.Signed, tx)
+	return ethcrypto.Sign(ethcrypto.Keccak256(payload), privKey.ToECDSA())
+}
+
+// Close implements ExternalSigner.
+func (md *MockDevice) Close() error {
+	md.closed = true
+	return nil
+}
+
+// derive derives the private key of the given HD path.
+func (md *MockDevice) derive(hdPath string) (ethsecp256k1.PrivKey, error) {
+	if md.closed {
+		return nil, errors.New("device is closed")
+	}
+
+	bz, err := hd.DeriveSecp256k1(md.mnemonic, "", hdPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return ethsecp256k1.PrivKey(bz), nil
+}
@@ -0,0 +1,98 @@
+package signer
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/cosmos/ethermint/crypto/ethsecp256k1"
+)
+
+// ErrSignRejected is returned by the external signers when the signature request is
+// rejected on the device.
+var ErrSignRejected = errors.New("signature request rejected on the device")
+
+// ExternalSigner defines a device that holds eth_secp256k1 private keys outside of the
+// keybase, such as a hardware wallet running the Ethereum app. The keys are referenced
+// by their BIP44 HD path (eg: m/44'/60'/0'/0/0) and never leave the device.
+type ExternalSigner interface {
+	// PubKey returns the public key derived on the given HD path.
+	PubKey(hdPath string) (ethsecp256k1.PubKey, error)
+	// SignEIP155 signs the RLP encoded EIP-155 payload of an Ethereum transaction (i.e
+	// [nonce, gasPrice, gas, to, value, data, chainID, 0, 0]) with the key derived on
+	// the given HD path. It returns the 65 byte [R || S || V] signature, with V in {0, 1}.
+	SignEIP155(hdPath string, payload []byte) ([]byte, error)
+	// Close releases the device.
+	Close() error
+}
+
+// Constructor opens a connection to an ExternalSigner device.
+type Constructor func() (ExternalSigner, error)
+
+var (
+	mu      sync.RWMutex
+	signers = make(map[string]Constructor)
+)
+
+// Register makes an ExternalSigner available by the provided name. It panics if the
+// name is already registered or if the constructor is nil.
+func Register(name string, constructor Constructor) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if constructor == nil {
+		panic("external signer constructor is nil")
+	}
+
+	if _, found := signers[name]; found {
+		panic(fmt.Sprintf("external signer %s already registered", name))
+	}
+
+	signers[name] = constructor
+}
+
+// Open opens the ExternalSigner registered with the provided name.
+func Open(name string) (ExternalSigner, error) {
+	mu.RLock()
+	constructor, found := signers[name]
+	mu.RUnlock()
+
+	if !found {
+		return nil, fmt.Errorf("external signer %s not registered, available signers: %v", name, Names())
+	}
+
+	return constructor()
+}
+
+// Names returns the sorted names of the registered external signers.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]string, 0, len(signers))
+	for name := range signers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// Address returns the Ethereum address of the key derived on the given HD path.
+func Address(signer ExternalSigner, hdPath string) (ethcmn.Address, error) {
+	pubKey, err := signer.PubKey(hdPath)
+	if err != nil {
+		return ethcmn.Address{}, err
+	}
+
+	pubKeyECDSA, err := ethcrypto.DecompressPubkey(pubKey)
+	if err != nil {
+		return ethcmn.Address{}, fmt.Errorf("invalid public key from the device: %w", err)
+	}
+
+	return ethcrypto.PubkeyToAddress(*pubKeyECDSA), nil
+}
@@ -0,0 +1,77 @@
+package signer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	ethermint "github.com/cosmos/ethermint/types"
+)
+
+// well-known test mnemonic, whose first Ethereum account is 0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266
+const testMnemonic = "test test test test test test test test test test test junk"
+
+func TestRegister(t *testing.T) {
+	device := NewMockDevice("")
+	Register("test", func() (ExternalSigner, error) { return device, nil })
+
+	require.Contains(t, Names(), "test")
+	require.Panics(t, func() { Register("test", func() (ExternalSigner, error) { return device, nil }) })
+	require.Panics(t, func() { Register("nil", nil) })
+
+	opened, err := Open("test")
+	require.NoError(t, err)
+	require.Equal(t, device, opened)
+
+	_, err = Open("unknown")
+	require.Error(t, err)
+}
+
+func TestMockDevice(t *testing.T) {
+	device := NewMockDevice(testMnemonic)
+
+	// the device derives the keys like the Ethereum wallets
+	addr, err := Address(device, ethermint.BIP44HDPath)
+	require.NoError(t, err)
+	require.Equal(t, ethcmn.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"), addr)
+
+	to := ethcmn.BytesToAddress([]byte("to"))
+	chainID := big.NewInt(9000)
+	payload, err := rlp.EncodeToBytes([]interface{}{
+		uint64(1), big.NewInt(10), uint64(21000), &to, big.NewInt(100), []byte{}, chainID, uint(0), uint(0),
+	})
+	require.NoError(t, err)
+
+	sig, err := device.SignEIP155(ethermint.BIP44HDPath, payload)
+	require.NoError(t, err)
+	require.Len(t, device.Signed, 1)
+	require.Equal(t, chainID, device.Signed[0].ChainID)
+
+	pubKey, err := ethcrypto.SigToPub(ethcrypto.Keccak256(payload), sig)
+	require.NoError(t, err)
+	require.Equal(t, addr, ethcrypto.PubkeyToAddress(*pubKey))
+
+	// the payload must be an EIP-155 transaction
+	_, err = device.SignEIP155(ethermint.BIP44HDPath, ethcrypto.Keccak256(payload))
+	require.Error(t, err)
+
+	unprotected, err := rlp.EncodeToBytes([]interface{}{
+		uint64(1), big.NewInt(10), uint64(21000), &to, big.NewInt(100), []byte{}, big.NewInt(0), uint(0), uint(0),
+	})
+	require.NoError(t, err)
+	_, err = device.SignEIP155(ethermint.BIP44HDPath, unprotected)
+	require.Error(t, err)
+
+	device.Reject = true
+	_, err = device.SignEIP155(ethermint.BIP44HDPath, payload)
+	require.Equal(t, ErrSignRejected, err)
+
+	require.NoError(t, device.Close())
+	_, err = device.PubKey(ethermint.BIP44HDPath)
+	require.Error(t, err)
+}
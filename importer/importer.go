@@ -0,0 +1,598 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"time"
+
+	sdkcodec "github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdkstore "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+
+	"github.com/cosmos/ethermint/core"
+	cryptocodec "github.com/cosmos/ethermint/crypto/ethsecp256k1"
+	"github.com/cosmos/ethermint/types"
+	"github.com/cosmos/ethermint/x/evm"
+	evmtypes "github.com/cosmos/ethermint/x/evm/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	ethcore "github.com/ethereum/go-ethereum/core"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethvm "github.com/ethereum/go-ethereum/core/vm"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	ethparams "github.com/ethereum/go-ethereum/params"
+	ethrlp "github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// blockHashWindow is the number of recent block headers accessible with the BLOCKHASH opcode.
+const blockHashWindow = 256
+
+var (
+	rewardBig8  = big.NewInt(8)
+	rewardBig32 = big.NewInt(32)
+)
+
+// Config defines the imported chain and the verification options of an Importer.
+type Config struct {
+	// Genesis is the Ethereum genesis of the imported chain, which defines its chain
+	// configuration and the allocated accounts.
+	Genesis *ethcore.Genesis
+	// CheckInterval is the number of blocks between two checkpoints, where the receipts
+	// root of the block is verified (from Byzantium) and the import metrics are logged.
+	// The checkpoints are disabled if it's 0.
+	CheckInterval uint64
+	// Balances are the expected account balances, verified once the block of the given
+	// number is imported.
+	Balances Balances
+}
+
+// Balances maps a block number to the expected account balances after that block.
+type Balances map[uint64]map[ethcmn.Address]*big.Int
+
+// ParseBalances parses the expected balances from their JSON format, i.e a map of block
+// numbers to the hex addresses and the decimal balances (in wei) of the accounts:
+//
+//	{"46147": {"0xA1E4380A3B1f749673E270229993eE55F35663b4": "2000000000000000000"}}
+func ParseBalances(bz []byte) (Balances, error) {
+	var raw map[uint64]map[string]string
+	if err := json.Unmarshal(bz, &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal balances: %w", err)
+	}
+
+	balances := make(Balances, len(raw))
+	for number, accounts := range raw {
+		balances[number] = make(map[ethcmn.Address]*big.Int, len(accounts))
+
+		for addr, balanceStr := range accounts {
+			address, err := types.ParseHexAddress(addr)
+			if err != nil {
+				return nil, err
+			}
+
+			balance, ok := new(big.Int).SetString(balanceStr, 10)
+			if !ok {
+				return nil, fmt.Errorf("invalid balance %s for address %s at block %d", balanceStr, addr, number)
+			}
+
+			balances[number][address] = balance
+		}
+	}
+
+	return balances, nil
+}
+
+// ChainGenesis returns the genesis of the Ethereum network with the given name (mainnet,
+// ropsten, rinkeby or goerli).
+func ChainGenesis(name string) (*ethcore.Genesis, error) {
+	switch name {
+	case "mainnet":
+		return ethcore.DefaultGenesisBlock(), nil
+	case "ropsten":
+		return ethcore.DefaultRopstenGenesisBlock(), nil
+	case "rinkeby":
+		return ethcore.DefaultRinkebyGenesisBlock(), nil
+	case "goerli":
+		return ethcore.DefaultGoerliGenesisBlock(), nil
+	default:
+		return nil, fmt.Errorf("unknown chain %s, expected one of mainnet, ropsten, rinkeby or goerli", name)
+	}
+}
+
+// Metrics defines the timing metrics of an import.
+type Metrics struct {
+	Blocks  uint64        `json:"blocks"`
+	Txs     uint64        `json:"txs"`
+	GasUsed uint64        `json:"gas_used"`
+	Elapsed time.Duration `json:"elapsed"`
+}
+
+// BlocksPerSecond returns the number of blocks imported per second.
+func (m Metrics) BlocksPerSecond() float64 {
+	if m.Elapsed <= 0 {
+		return 0
+	}
+
+	return float64(m.Blocks) / m.Elapsed.Seconds()
+}
+
+// MGasPerSecond returns the millions of gas units executed per second.
+func (m Metrics) MGasPerSecond() float64 {
+	if m.Elapsed <= 0 {
+		return 0
+	}
+
+	return float64(m.GasUsed) / 1e6 / m.Elapsed.Seconds()
+}
+
+// String implements the fmt.Stringer interface
+func (m Metrics) String() string {
+	return fmt.Sprintf(
+		"blocks: %d, txs: %d, gas used: %d, elapsed: %v, blocks/s: %.2f, mgas/s: %.2f",
+		m.Blocks, m.Txs, m.GasUsed, m.Elapsed, m.BlocksPerSecond(), m.MGasPerSecond(),
+	)
+}
+
+// Importer replays the blocks of an Ethereum chain export against the auth and EVM module
+// stores. Every block is committed to the database, so that an interrupted import resumes
+// from the last imported block.
+type Importer struct {
+	config       Config
+	chainConfig  *ethparams.ChainConfig
+	genesisHash  ethcmn.Hash
+	cms          sdk.CommitMultiStore
+	evmKeeper    *evm.Keeper
+	chainContext *core.ChainContext
+	logger       tmlog.Logger
+}
+
+// NewImporter creates a new Importer on the given database. The genesis accounts are
+// created if the database is empty.
+func NewImporter(db dbm.DB, config Config, logger tmlog.Logger) (*Importer, error) {
+	if config.Genesis == nil || config.Genesis.Config == nil {
+		return nil, errors.New("the genesis chain config is required")
+	}
+
+	cdc := newCodec()
+	cms := store.NewCommitMultiStore(db)
+
+	authStoreKey := sdk.NewKVStoreKey(auth.StoreKey)
+	evmStoreKey := sdk.NewKVStoreKey(evmtypes.StoreKey)
+	paramsStoreKey := sdk.NewKVStoreKey(params.StoreKey)
+	paramsTransientStoreKey := sdk.NewTransientStoreKey(params.TStoreKey)
+
+	// mount stores
+	keys := []*sdk.KVStoreKey{authStoreKey, evmStoreKey, paramsStoreKey}
+	for _, key := range keys {
+		cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, nil)
+	}
+
+	cms.MountStoreWithDB(paramsTransientStoreKey, sdk.StoreTypeTransient, nil)
+
+	paramsKeeper := params.NewKeeper(cdc, paramsStoreKey, paramsTransientStoreKey)
+
+	// Set specific subspaces
+	authSubspace := paramsKeeper.Subspace(auth.DefaultParamspace)
+	evmSubspace := paramsKeeper.Subspace(evmtypes.DefaultParamspace).WithKeyTable(evmtypes.ParamKeyTable())
+	ak := auth.NewAccountKeeper(cdc, authStoreKey, authSubspace, types.ProtoAccount)
+	// NOTE: the staking keeper is only used to set the EVM coinbase on the keeper state
+	// transitions, which are not used by the importer
+	evmKeeper := evm.NewKeeper(cdc, evmStoreKey, authStoreKey, evmSubspace, ak, nil, nil)
+
+	// only the state of the last imported block is kept
+	cms.SetPruning(sdkstore.PruneEverything)
+
+	// load latest version (root)
+	if err := cms.LoadLatestVersion(); err != nil {
+		return nil, err
+	}
+
+	im := &Importer{
+		config:       config,
+		chainConfig:  config.Genesis.Config,
+		genesisHash:  config.Genesis.ToBlock(nil).Hash(),
+		cms:          cms,
+		evmKeeper:    evmKeeper,
+		chainContext: core.NewChainContext(),
+		logger:       logger,
+	}
+
+	if cms.LastCommitID().Version == 0 {
+		if err := im.initGenesis(); err != nil {
+			return nil, fmt.Errorf("failed to import the genesis accounts: %w", err)
+		}
+	}
+
+	return im, nil
+}
+
+// LastBlock returns the number of the last imported block, which is 0 if only the genesis
+// accounts have been imported.
+func (im *Importer) LastBlock() uint64 {
+	// the genesis state is committed on the first version
+	return uint64(im.cms.LastCommitID().Version - 1)
+}
+
+// initGenesis sets the default EVM parameters and creates the genesis accounts.
+func (im *Importer) initGenesis() error {
+	ms := im.cms.CacheMultiStore()
+	ctx := sdk.NewContext(ms, abci.Header{}, false, im.logger)
+
+	// Set the default Ethermint parameters to the parameter keeper store
+	im.evmKeeper.SetParams(ctx, evmtypes.DefaultParams())
+
+	// sort the addresses and insertion of key/value pairs matters
+	genAddrs := make([]string, 0, len(im.config.Genesis.Alloc))
+	for addr := range im.config.Genesis.Alloc {
+		genAddrs = append(genAddrs, addr.String())
+	}
+
+	sort.Strings(genAddrs)
+
+	for _, addrStr := range genAddrs {
+		addr := ethcmn.HexToAddress(addrStr)
+		acc := im.config.Genesis.Alloc[addr]
+
+		im.evmKeeper.AddBalance(ctx, addr, acc.Balance)
+		im.evmKeeper.SetCode(ctx, addr, acc.Code)
+		im.evmKeeper.SetNonce(ctx, addr, acc.Nonce)
+
+		for key, value := range acc.Storage {
+			im.evmKeeper.SetState(ctx, addr, key, value)
+		}
+	}
+
+	// commit the stateDB with 'false' to delete empty objects
+	//
+	// NOTE: Commit does not yet return the intra merkle root (version)
+	if _, err := im.evmKeeper.Commit(ctx, false); err != nil {
+		return err
+	}
+
+	if err := im.checkBalances(ctx, 0); err != nil {
+		return err
+	}
+
+	// persist multi-store cache state
+	ms.Write()
+
+	// persist multi-store root state
+	im.cms.Commit()
+	return nil
+}
+
+// Import decodes and applies the RLP encoded blocks of the reader (i.e a geth export
+// file). The blocks that have already been imported are skipped and the import stops
+// after the current block when the context is done. It returns the metrics of the
+// blocks imported by the call.
+func (im *Importer) Import(goCtx context.Context, r io.Reader) (Metrics, error) {
+	var (
+		metrics   Metrics
+		lastBlock = im.LastBlock()
+		startTime = time.Now()
+		stream    = ethrlp.NewStream(r, 0)
+	)
+
+	im.logger.Info("importing blocks", "last-block", lastBlock)
+
+	for {
+		select {
+		case <-goCtx.Done():
+			return metrics, goCtx.Err()
+		default:
+		}
+
+		block := new(ethtypes.Block)
+		if err := stream.Decode(block); err != nil {
+			if err == io.EOF {
+				return metrics, nil
+			}
+
+			return metrics, fmt.Errorf("failed to decode block %d: %w", lastBlock+1, err)
+		}
+
+		number := block.NumberU64()
+
+		switch {
+		case number == 0:
+			if block.Hash() != im.genesisHash {
+				return metrics, fmt.Errorf("genesis block hash mismatch, expected %s, got %s", im.genesisHash.Hex(), block.Hash().Hex())
+			}
+
+			continue
+		case number <= lastBlock:
+			// the block has already been imported, only its header is kept for the BLOCKHASH opcode
+			im.setHeader(block.Header())
+			continue
+		case number != lastBlock+1:
+			return metrics, fmt.Errorf("non contiguous block, expected block %d, got %d", lastBlock+1, number)
+		}
+
+		if err := im.checkParentHash(block); err != nil {
+			return metrics, err
+		}
+
+		checkpoint := im.config.CheckInterval > 0 && number%im.config.CheckInterval == 0
+		if err := im.importBlock(block, checkpoint); err != nil {
+			return metrics, fmt.Errorf("failed to import block %d: %w", number, err)
+		}
+
+		lastBlock = number
+
+		metrics.Blocks++
+		metrics.Txs += uint64(len(block.Transactions()))
+		metrics.GasUsed += block.GasUsed()
+		metrics.Elapsed = time.Since(startTime)
+
+		if checkpoint {
+			im.logger.Info(
+				"checkpoint", "block", number, "blocks", metrics.Blocks, "txs", metrics.Txs, "elapsed", metrics.Elapsed,
+				"blocks/s", fmt.Sprintf("%.2f", metrics.BlocksPerSecond()), "mgas/s", fmt.Sprintf("%.2f", metrics.MGasPerSecond()),
+			)
+		}
+	}
+}
+
+// checkParentHash verifies that the block is the child of the previous one, whose header is
+// known unless the import resumed from the database on this block.
+func (im *Importer) checkParentHash(block *ethtypes.Block) error {
+	parentHash := im.genesisHash
+	if number := block.NumberU64(); number > 1 {
+		parent := im.chainContext.GetHeader(ethcmn.Hash{}, number-1)
+		if parent == nil {
+			return nil
+		}
+
+		parentHash = parent.Hash()
+	}
+
+	if block.ParentHash() != parentHash {
+		return fmt.Errorf("parent hash mismatch for block %d, expected %s, got %s", block.NumberU64(), parentHash.Hex(), block.ParentHash().Hex())
+	}
+
+	return nil
+}
+
+// setHeader sets the header on the chain context and deletes the headers that are no longer
+// accessible with the BLOCKHASH opcode.
+func (im *Importer) setHeader(header *ethtypes.Header) {
+	number := header.Number.Uint64()
+	im.chainContext.SetHeader(number, header)
+
+	if number > blockHashWindow {
+		im.chainContext.DeleteHeader(number - blockHashWindow - 1)
+	}
+}
+
+// importBlock applies the transactions and the mining rewards of the block, verifies that
+// the gas used matches the header and commits the state. The receipts root and the
+// expected balances are verified on checkpoints.
+func (im *Importer) importBlock(block *ethtypes.Block, checkpoint bool) error {
+	header := block.Header()
+	number := block.NumberU64()
+
+	im.setHeader(header)
+	im.chainContext.Coinbase = header.Coinbase
+
+	// Create a cached-wrapped multi-store based on the commit multi-store and
+	// create a new context based off of that.
+	ms := im.cms.CacheMultiStore()
+	ctx := sdk.NewContext(ms, abci.Header{}, false, im.logger)
+	ctx = ctx.WithBlockHeight(int64(number))
+
+	// the block rewards are applied to the StateDB even if the block doesn't have any tx
+	csdb := im.evmKeeper.CommitStateDB.WithContext(ctx)
+
+	if im.chainConfig.DAOForkSupport && im.chainConfig.DAOForkBlock != nil && im.chainConfig.DAOForkBlock.Cmp(block.Number()) == 0 {
+		applyDAOHardFork(csdb)
+	}
+
+	var (
+		usedGas  = new(uint64)
+		gp       = new(ethcore.GasPool).AddGas(block.GasLimit())
+		receipts = make(ethtypes.Receipts, 0, len(block.Transactions()))
+	)
+
+	for i, tx := range block.Transactions() {
+		csdb.Prepare(tx.Hash(), i)
+		csdb.SetBlockHash(block.Hash())
+
+		receipt, err := applyTransaction(im.chainConfig, im.chainContext, nil, gp, csdb, header, tx, usedGas, ethvm.Config{})
+		if err != nil {
+			return fmt.Errorf("failed to apply tx %s: %w", tx.Hash().Hex(), err)
+		}
+
+		receipts = append(receipts, receipt)
+	}
+
+	if *usedGas != header.GasUsed {
+		return fmt.Errorf("gas used mismatch, expected %d, got %d", header.GasUsed, *usedGas)
+	}
+
+	// apply mining rewards
+	accumulateRewards(im.chainConfig, csdb, header, block.Uncles())
+
+	// commit stateDB
+	if _, err := csdb.Commit(im.chainConfig.IsEIP158(block.Number())); err != nil {
+		return fmt.Errorf("failed to commit StateDB: %w", err)
+	}
+
+	// the receipts only contain the intermediate state roots before Byzantium, which aren't
+	// computed by the StateDB
+	if checkpoint && im.chainConfig.IsByzantium(block.Number()) {
+		if root := ethtypes.DeriveSha(receipts, trie.NewStackTrie(nil)); root != header.ReceiptHash {
+			return fmt.Errorf("receipts root mismatch, expected %s, got %s", header.ReceiptHash.Hex(), root.Hex())
+		}
+	}
+
+	if err := im.checkBalances(ctx, number); err != nil {
+		return err
+	}
+
+	// simulate BaseApp EndBlocker commitment
+	ms.Write()
+	im.cms.Commit()
+
+	return nil
+}
+
+// checkBalances verifies the expected account balances after the given block.
+func (im *Importer) checkBalances(ctx sdk.Context, number uint64) error {
+	for addr, expBalance := range im.config.Balances[number] {
+		if balance := im.evmKeeper.GetBalance(ctx, addr); balance.Cmp(expBalance) != 0 {
+			return fmt.Errorf("balance mismatch for %s, expected %s, got %s", addr.Hex(), expBalance, balance)
+		}
+	}
+
+	return nil
+}
+
+func newCodec() *sdkcodec.Codec {
+	cdc := sdkcodec.New()
+
+	evmtypes.RegisterCodec(cdc)
+	types.RegisterCodec(cdc)
+	auth.RegisterCodec(cdc)
+	bank.RegisterCodec(cdc)
+	sdk.RegisterCodec(cdc)
+	cryptocodec.RegisterCodec(cdc)
+	sdkcodec.RegisterCrypto(cdc)
+
+	return cdc
+}
+
+// accumulateRewards credits the coinbase of the given block with the mining
+// reward. The total reward consists of the static block reward and rewards for
+// included uncles. The coinbase of each uncle block is also rewarded.
+func accumulateRewards(
+	config *ethparams.ChainConfig, csdb *evmtypes.CommitStateDB,
+	header *ethtypes.Header, uncles []*ethtypes.Header,
+) {
+
+	// select the correct block reward based on chain progression
+	blockReward := ethash.FrontierBlockReward
+	if config.IsByzantium(header.Number) {
+		blockReward = ethash.ByzantiumBlockReward
+	}
+	if config.IsConstantinople(header.Number) {
+		blockReward = ethash.ConstantinopleBlockReward
+	}
+
+	// accumulate the rewards for the miner and any included uncles
+	reward := new(big.Int).Set(blockReward)
+	r := new(big.Int)
+
+	for _, uncle := range uncles {
+		r.Add(uncle.Number, rewardBig8)
+		r.Sub(r, header.Number)
+		r.Mul(r, blockReward)
+		r.Div(r, rewardBig8)
+		csdb.AddBalance(uncle.Coinbase, r)
+		r.Div(blockReward, rewardBig32)
+		reward.Add(reward, r)
+	}
+
+	csdb.AddBalance(header.Coinbase, reward)
+}
+
+// ApplyDAOHardFork modifies the state database according to the DAO hard-fork
+// rules, transferring all balances of a set of DAO accounts to a single refund
+// contract.
+// Code is pulled from go-ethereum 1.9 because the StateDB interface does not include the
+// SetBalance function implementation
+// Ref: https://github.com/ethereum/go-ethereum/blob/52f2461774bcb8cdd310f86b4bc501df5b783852/consensus/misc/dao.go#L74
+func applyDAOHardFork(csdb *evmtypes.CommitStateDB) {
+	// Retrieve the contract to refund balances into
+	if !csdb.Exist(ethparams.DAORefundContract) {
+		csdb.CreateAccount(ethparams.DAORefundContract)
+	}
+
+	// Move every DAO account and extra-balance account funds into the refund contract
+	for _, addr := range ethparams.DAODrainList() {
+		csdb.AddBalance(ethparams.DAORefundContract, csdb.GetBalance(addr))
+		csdb.SetBalance(addr, new(big.Int))
+	}
+}
+
+// ApplyTransaction attempts to apply a transaction to the given state database
+// and uses the input parameters for its environment. It returns the receipt
+// for the transaction and an error if the transaction failed, indicating the
+// block was invalid.
+// Function is also pulled from go-ethereum 1.9 because of the incompatible usage
+// Ref: https://github.com/ethereum/go-ethereum/blob/52f2461774bcb8cdd310f86b4bc501df5b783852/core/state_processor.go#L88
+func applyTransaction(
+	config *ethparams.ChainConfig, bc ethcore.ChainContext, author *ethcmn.Address,
+	gp *ethcore.GasPool, csdb *evmtypes.CommitStateDB, header *ethtypes.Header,
+	tx *ethtypes.Transaction, usedGas *uint64, cfg ethvm.Config,
+) (*ethtypes.Receipt, error) {
+	msg, err := tx.AsMessage(ethtypes.MakeSigner(config, header.Number))
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a new context to be used in the EVM environment
+	blockCtx := ethcore.NewEVMBlockContext(header, bc, author)
+	txCtx := ethcore.NewEVMTxContext(msg)
+
+	// Create a new environment which holds all relevant information
+	// about the transaction and calling mechanisms.
+	vmenv := ethvm.NewEVM(blockCtx, txCtx, csdb, config, cfg)
+
+	// Apply the transaction to the current state (included in the env). The EVM execution
+	// errors (eg: out of gas) are part of the result, so the error invalidates the block.
+	execResult, err := ethcore.ApplyMessage(vmenv, msg, gp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update the state with pending changes. The receipts only contain the intermediate
+	// root before Byzantium.
+	var root []byte
+	if config.IsByzantium(header.Number) {
+		err = csdb.Finalise(true)
+	} else {
+		var intRoot ethcmn.Hash
+		intRoot, err = csdb.IntermediateRoot(config.IsEIP158(header.Number))
+		root = intRoot.Bytes()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	*usedGas += execResult.UsedGas
+
+	// Create a new receipt for the transaction, storing the intermediate root and gas used by the tx
+	// based on the eip phase, we're passing whether the root touch-delete accounts.
+	receipt := ethtypes.NewReceipt(root, execResult.Failed(), *usedGas)
+	receipt.TxHash = tx.Hash()
+	receipt.GasUsed = execResult.UsedGas
+
+	// if the transaction created a contract, store the creation address in the receipt.
+	if msg.To() == nil {
+		receipt.ContractAddress = ethcrypto.CreateAddress(vmenv.TxContext.Origin, tx.Nonce())
+	}
+
+	// Set the receipt logs and create a bloom for filtering
+	receipt.Logs, err = csdb.GetLogs(tx.Hash())
+	receipt.Bloom = ethtypes.CreateBloom(ethtypes.Receipts{receipt})
+	receipt.BlockHash = csdb.BlockHash()
+	receipt.BlockNumber = header.Number
+	receipt.TransactionIndex = uint(csdb.TxIndex())
+
+	return receipt, err
+}
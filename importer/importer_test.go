@@ -1,44 +1,30 @@
 package importer
 
 import (
+	"bytes"
+	"context"
 	"flag"
 	"fmt"
-	"io"
 	"math/big"
 	"os"
 	"os/signal"
 	"runtime/pprof"
-	"sort"
 	"syscall"
 	"testing"
-	"time"
 
 	"github.com/stretchr/testify/require"
 
-	sdkcodec "github.com/cosmos/cosmos-sdk/codec"
-	"github.com/cosmos/cosmos-sdk/store"
-	sdkstore "github.com/cosmos/cosmos-sdk/store/types"
-	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/cosmos/cosmos-sdk/x/auth"
-	"github.com/cosmos/cosmos-sdk/x/bank"
-	"github.com/cosmos/cosmos-sdk/x/params"
-
-	"github.com/cosmos/ethermint/core"
-	cryptocodec "github.com/cosmos/ethermint/crypto/ethsecp256k1"
-	"github.com/cosmos/ethermint/types"
-	"github.com/cosmos/ethermint/x/evm"
-	evmtypes "github.com/cosmos/ethermint/x/evm/types"
-
 	ethcmn "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	ethcore "github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
-	ethvm "github.com/ethereum/go-ethereum/core/vm"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
 	ethparams "github.com/ethereum/go-ethereum/params"
 	ethrlp "github.com/ethereum/go-ethereum/rlp"
 
-	abci "github.com/tendermint/tendermint/abci/types"
 	tmlog "github.com/tendermint/tendermint/libs/log"
 	dbm "github.com/tendermint/tm-db"
 )
@@ -51,9 +37,6 @@ var (
 	genInvestor = ethcmn.HexToAddress("0x756F45E3FA69347A9A973A725E3C98bC4db0b5a0")
 
 	logger = tmlog.NewNopLogger()
-
-	rewardBig8  = big.NewInt(8)
-	rewardBig32 = big.NewInt(32)
 )
 
 func init() {
@@ -64,20 +47,6 @@ func init() {
 	flag.Parse()
 }
 
-func newTestCodec() *sdkcodec.Codec {
-	cdc := sdkcodec.New()
-
-	evmtypes.RegisterCodec(cdc)
-	types.RegisterCodec(cdc)
-	auth.RegisterCodec(cdc)
-	bank.RegisterCodec(cdc)
-	sdk.RegisterCodec(cdc)
-	cryptocodec.RegisterCodec(cdc)
-	sdkcodec.RegisterCrypto(cdc)
-
-	return cdc
-}
-
 func cleanup() {
 	fmt.Println("cleaning up test execution...")
 	os.RemoveAll(flagDataDir)
@@ -98,64 +67,11 @@ func trapSignals() {
 	}()
 }
 
-// nolint: interfacer
-func createAndTestGenesis(t *testing.T, cms sdk.CommitMultiStore, ak auth.AccountKeeper, evmKeeper *evm.Keeper) {
-	genBlock := ethcore.DefaultGenesisBlock()
-	ms := cms.CacheMultiStore()
-	ctx := sdk.NewContext(ms, abci.Header{}, false, logger)
-
-	// Set the default Ethermint parameters to the parameter keeper store
-	evmKeeper.SetParams(ctx, evmtypes.DefaultParams())
-
-	// sort the addresses and insertion of key/value pairs matters
-	genAddrs := make([]string, len(genBlock.Alloc))
-	i := 0
-	for addr := range genBlock.Alloc {
-		genAddrs[i] = addr.String()
-		i++
-	}
-
-	sort.Strings(genAddrs)
-
-	for _, addrStr := range genAddrs {
-		addr := ethcmn.HexToAddress(addrStr)
-		acc := genBlock.Alloc[addr]
-
-		evmKeeper.AddBalance(ctx, addr, acc.Balance)
-		evmKeeper.SetCode(ctx, addr, acc.Code)
-		evmKeeper.SetNonce(ctx, addr, acc.Nonce)
-
-		for key, value := range acc.Storage {
-			evmKeeper.SetState(ctx, addr, key, value)
-		}
+func TestImportBlocks(t *testing.T) {
+	if _, err := os.Stat(flagBlockchain); os.IsNotExist(err) {
+		t.Skipf("ethereum block export file %s not found", flagBlockchain)
 	}
 
-	// get balance of one of the genesis account having 400 ETH
-	b := evmKeeper.GetBalance(ctx, genInvestor)
-	require.Equal(t, "200000000000000000000", b.String())
-
-	// commit the stateDB with 'false' to delete empty objects
-	//
-	// NOTE: Commit does not yet return the intra merkle root (version)
-	_, err := evmKeeper.Commit(ctx, false)
-	require.NoError(t, err)
-
-	// persist multi-store cache state
-	ms.Write()
-
-	// persist multi-store root state
-	cms.Commit()
-
-	// verify account mapper state
-	genAcc := ak.GetAccount(ctx, sdk.AccAddress(genInvestor.Bytes()))
-	require.NotNil(t, genAcc)
-
-	evmDenom := evmKeeper.GetParams(ctx).EvmDenom
-	balance := sdk.NewCoin(evmDenom, genAcc.GetCoins().AmountOf(evmDenom))
-	require.Equal(t, sdk.NewIntFromBigInt(b), balance.Amount)
-}
-
-func TestImportBlocks(t *testing.T) {
 	if flagDataDir == "" {
 		flagDataDir = os.TempDir()
 	}
@@ -172,40 +88,16 @@ func TestImportBlocks(t *testing.T) {
 	defer cleanup()
 	trapSignals()
 
-	cdc := newTestCodec()
-
-	cms := store.NewCommitMultiStore(db)
-
-	authStoreKey := sdk.NewKVStoreKey(auth.StoreKey)
-	evmStoreKey := sdk.NewKVStoreKey(evmtypes.StoreKey)
-	paramsStoreKey := sdk.NewKVStoreKey(params.StoreKey)
-	paramsTransientStoreKey := sdk.NewTransientStoreKey(params.TStoreKey)
-
-	// mount stores
-	keys := []*sdk.KVStoreKey{authStoreKey, evmStoreKey, paramsStoreKey}
-	for _, key := range keys {
-		cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, nil)
-	}
-
-	cms.MountStoreWithDB(paramsTransientStoreKey, sdk.StoreTypeTransient, nil)
-
-	paramsKeeper := params.NewKeeper(cdc, paramsStoreKey, paramsTransientStoreKey)
-
-	// Set specific subspaces
-	authSubspace := paramsKeeper.Subspace(auth.DefaultParamspace)
-	evmSubspace := paramsKeeper.Subspace(evmtypes.DefaultParamspace).WithKeyTable(evmtypes.ParamKeyTable())
-	ak := auth.NewAccountKeeper(cdc, authStoreKey, authSubspace, types.ProtoAccount)
-	evmKeeper := evm.NewKeeper(cdc, evmStoreKey, evmSubspace, ak)
+	// ethereum mainnet config
+	genesis, err := ChainGenesis("mainnet")
+	require.NoError(t, err)
 
-	cms.SetPruning(sdkstore.PruneNothing)
+	// get balance of one of the genesis account having 200 ETH
+	balances := Balances{0: {genInvestor: new(big.Int).Mul(big.NewInt(200), big.NewInt(ethparams.Ether))}}
 
-	// load latest version (root)
-	err := cms.LoadLatestVersion()
+	im, err := NewImporter(db, Config{Genesis: genesis, CheckInterval: 1000, Balances: balances}, logger)
 	require.NoError(t, err)
 
-	// set and test genesis block
-	createAndTestGenesis(t, cms, ak, evmKeeper)
-
 	// open blockchain export file
 	blockchainInput, err := os.Open(flagBlockchain)
 	require.Nil(t, err)
@@ -215,186 +107,211 @@ func TestImportBlocks(t *testing.T) {
 		require.NoError(t, err)
 	}()
 
-	// ethereum mainnet config
-	chainContext := core.NewChainContext()
-	vmConfig := ethvm.Config{}
-	chainConfig := ethparams.MainnetChainConfig
-
-	// create RLP stream for exported blocks
-	stream := ethrlp.NewStream(blockchainInput, 0)
-	startTime := time.Now()
-
-	var block ethtypes.Block
-	for {
-		err = stream.Decode(&block)
-		if err == io.EOF {
-			break
-		}
-
-		require.NoError(t, err, "failed to decode block")
-
-		var (
-			usedGas = new(uint64)
-			gp      = new(ethcore.GasPool).AddGas(block.GasLimit())
-		)
+	metrics, err := im.Import(context.Background(), blockchainInput)
+	require.NoError(t, err, "failed to import blocks")
 
-		header := block.Header()
-		chainContext.Coinbase = header.Coinbase
+	fmt.Printf("processed blocks: %s\n", metrics)
+}
 
-		chainContext.SetHeader(block.NumberU64(), header)
+// generateChain generates a chain of n blocks with value transfers and a contract
+// deployment that emits a log. It returns the genesis, the blocks (including the genesis
+// block) and the database of the generated state.
+func generateChain(t *testing.T, n int) (*ethcore.Genesis, []*ethtypes.Block, ethdb.Database) {
+	key, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
 
-		// Create a cached-wrapped multi-store based on the commit multi-store and
-		// create a new context based off of that.
-		ms := cms.CacheMultiStore()
-		ctx := sdk.NewContext(ms, abci.Header{}, false, logger)
-		ctx = ctx.WithBlockHeight(int64(block.NumberU64()))
+	var (
+		sender    = ethcrypto.PubkeyToAddress(key.PublicKey)
+		recipient = ethcmn.HexToAddress("0x1000000000000000000000000000000000000001")
+		miner     = ethcmn.HexToAddress("0x2000000000000000000000000000000000000002")
+		config    = ethparams.AllEthashProtocolChanges
+		signer    = ethtypes.NewEIP155Signer(config.ChainID)
+		// init code: LOG0(0, 0) STOP
+		logCode = ethcmn.FromHex("0x60006000a000")
+	)
+
+	genesis := &ethcore.Genesis{
+		Config: config,
+		Alloc: ethcore.GenesisAlloc{
+			sender: {Balance: new(big.Int).Mul(big.NewInt(100), big.NewInt(ethparams.Ether))},
+		},
+	}
 
-		if chainConfig.DAOForkSupport && chainConfig.DAOForkBlock != nil && chainConfig.DAOForkBlock.Cmp(block.Number()) == 0 {
-			applyDAOHardFork(evmKeeper)
-		}
+	db := rawdb.NewMemoryDatabase()
+	genBlock := genesis.MustCommit(db)
 
-		for i, tx := range block.Transactions() {
-			evmKeeper.Prepare(ctx, tx.Hash(), i)
-			evmKeeper.CommitStateDB.SetBlockHash(block.Hash())
+	blocks, _ := ethcore.GenerateChain(config, genBlock, ethash.NewFaker(), db, n, func(i int, gen *ethcore.BlockGen) {
+		gen.SetCoinbase(miner)
 
-			receipt, gas, err := applyTransaction(
-				chainConfig, chainContext, nil, gp, evmKeeper, header, tx, usedGas, vmConfig,
-			)
-			require.NoError(t, err, "failed to apply tx at block %d; tx: %X; gas %d; receipt:%v", block.NumberU64(), tx.Hash(), gas, receipt)
-			require.NotNil(t, receipt)
+		var tx *ethtypes.Transaction
+		if i%3 == 2 {
+			tx = ethtypes.NewContractCreation(gen.TxNonce(sender), big.NewInt(0), 100000, big.NewInt(1), logCode)
+		} else {
+			tx = ethtypes.NewTransaction(gen.TxNonce(sender), recipient, big.NewInt(int64(i+1)), ethparams.TxGas, big.NewInt(1), nil)
 		}
 
-		// apply mining rewards
-		accumulateRewards(chainConfig, evmKeeper, header, block.Uncles())
+		signedTx, err := ethtypes.SignTx(tx, signer, key)
+		require.NoError(t, err)
 
-		// commit stateDB
-		_, err := evmKeeper.CommitStateDB.Commit(chainConfig.IsEIP158(block.Number()))
-		require.NoError(t, err, "failed to commit StateDB")
+		gen.AddTx(signedTx)
+	})
 
-		// simulate BaseApp EndBlocker commitment
-		ms.Write()
-		cms.Commit()
+	return genesis, append([]*ethtypes.Block{genBlock}, blocks...), db
+}
 
-		// block debugging output
-		if block.NumberU64() > 0 && block.NumberU64()%1000 == 0 {
-			fmt.Printf("processed block: %d (time so far: %v)\n", block.NumberU64(), time.Since(startTime))
-		}
+// exportChain RLP encodes the blocks, like the geth export command.
+func exportChain(t *testing.T, blocks []*ethtypes.Block) *bytes.Buffer {
+	buf := new(bytes.Buffer)
+	for _, block := range blocks {
+		require.NoError(t, ethrlp.Encode(buf, block))
 	}
+
+	return buf
 }
 
-// accumulateRewards credits the coinbase of the given block with the mining
-// reward. The total reward consists of the static block reward and rewards for
-// included uncles. The coinbase of each uncle block is also rewarded.
-func accumulateRewards(
-	config *ethparams.ChainConfig, evmKeeper *evm.Keeper,
-	header *ethtypes.Header, uncles []*ethtypes.Header,
-) {
-
-	// select the correct block reward based on chain progression
-	blockReward := ethash.FrontierBlockReward
-	if config.IsByzantium(header.Number) {
-		blockReward = ethash.ByzantiumBlockReward
-	}
+// expectedBalances returns the balances of the given accounts on the generated state after the block.
+func expectedBalances(t *testing.T, db ethdb.Database, block *ethtypes.Block, addrs ...ethcmn.Address) map[ethcmn.Address]*big.Int {
+	statedb, err := state.New(block.Root(), state.NewDatabase(db), nil)
+	require.NoError(t, err)
 
-	// accumulate the rewards for the miner and any included uncles
-	reward := new(big.Int).Set(blockReward)
-	r := new(big.Int)
-
-	for _, uncle := range uncles {
-		r.Add(uncle.Number, rewardBig8)
-		r.Sub(r, header.Number)
-		r.Mul(r, blockReward)
-		r.Div(r, rewardBig8)
-		evmKeeper.CommitStateDB.AddBalance(uncle.Coinbase, r)
-		r.Div(blockReward, rewardBig32)
-		reward.Add(reward, r)
+	balances := make(map[ethcmn.Address]*big.Int, len(addrs))
+	for _, addr := range addrs {
+		balances[addr] = statedb.GetBalance(addr)
 	}
 
-	evmKeeper.CommitStateDB.AddBalance(header.Coinbase, reward)
+	return balances
 }
 
-// ApplyDAOHardFork modifies the state database according to the DAO hard-fork
-// rules, transferring all balances of a set of DAO accounts to a single refund
-// contract.
-// Code is pulled from go-ethereum 1.9 because the StateDB interface does not include the
-// SetBalance function implementation
-// Ref: https://github.com/ethereum/go-ethereum/blob/52f2461774bcb8cdd310f86b4bc501df5b783852/consensus/misc/dao.go#L74
-func applyDAOHardFork(evmKeeper *evm.Keeper) {
-	// Retrieve the contract to refund balances into
-	if !evmKeeper.CommitStateDB.Exist(ethparams.DAORefundContract) {
-		evmKeeper.CommitStateDB.CreateAccount(ethparams.DAORefundContract)
-	}
+func TestImporterResume(t *testing.T) {
+	genesis, blocks, gendb := generateChain(t, 10)
 
-	// Move every DAO account and extra-balance account funds into the refund contract
-	for _, addr := range ethparams.DAODrainList() {
-		evmKeeper.CommitStateDB.AddBalance(ethparams.DAORefundContract, evmKeeper.CommitStateDB.GetBalance(addr))
-		evmKeeper.CommitStateDB.SetBalance(addr, new(big.Int))
+	var addrs []ethcmn.Address
+	for _, tx := range blocks[1].Transactions() {
+		from, err := ethtypes.Sender(ethtypes.NewEIP155Signer(genesis.Config.ChainID), tx)
+		require.NoError(t, err)
+		addrs = append(addrs, from, *tx.To())
 	}
-}
-
-// ApplyTransaction attempts to apply a transaction to the given state database
-// and uses the input parameters for its environment. It returns the receipt
-// for the transaction, gas used and an error if the transaction failed,
-// indicating the block was invalid.
-// Function is also pulled from go-ethereum 1.9 because of the incompatible usage
-// Ref: https://github.com/ethereum/go-ethereum/blob/52f2461774bcb8cdd310f86b4bc501df5b783852/core/state_processor.go#L88
-func applyTransaction(
-	config *ethparams.ChainConfig, bc ethcore.ChainContext, author *ethcmn.Address,
-	gp *ethcore.GasPool, evmKeeper *evm.Keeper, header *ethtypes.Header,
-	tx *ethtypes.Transaction, usedGas *uint64, cfg ethvm.Config,
-) (*ethtypes.Receipt, uint64, error) {
-	msg, err := tx.AsMessage(ethtypes.MakeSigner(config, header.Number))
-	if err != nil {
-		return nil, 0, err
+	addrs = append(addrs, blocks[1].Coinbase())
+
+	config := Config{
+		Genesis:       genesis,
+		CheckInterval: 1,
+		Balances: Balances{
+			5:  expectedBalances(t, gendb, blocks[5], addrs...),
+			10: expectedBalances(t, gendb, blocks[10], addrs...),
+		},
 	}
 
-	// Create a new context to be used in the EVM environment
-	blockCtx := ethcore.NewEVMBlockContext(header, bc, author)
-	txCtx := ethcore.NewEVMTxContext(msg)
+	db := dbm.NewMemDB()
 
-	// Create a new environment which holds all relevant information
-	// about the transaction and calling mechanisms.
-	vmenv := ethvm.NewEVM(blockCtx, txCtx, evmKeeper.CommitStateDB, config, cfg)
+	im, err := NewImporter(db, config, logger)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), im.LastBlock())
+
+	// import the first 5 blocks
+	metrics, err := im.Import(context.Background(), exportChain(t, blocks[:6]))
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), metrics.Blocks)
+	require.Equal(t, uint64(5), metrics.Txs)
+	require.Equal(t, uint64(5), im.LastBlock())
+
+	// resume the import from the database
+	im, err = NewImporter(db, config, logger)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), im.LastBlock())
 
-	// Apply the transaction to the current state (included in the env)
-	execResult, err := ethcore.ApplyMessage(vmenv, msg, gp)
-	if err != nil {
-		// NOTE: ignore vm execution error (eg: tx out of gas at block 51169) as we care only about state transition errors
-		return &ethtypes.Receipt{}, 0, nil
+	metrics, err = im.Import(context.Background(), exportChain(t, blocks))
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), metrics.Blocks)
+	require.Equal(t, uint64(10), im.LastBlock())
+
+	var gasUsed uint64
+	for _, block := range blocks[6:] {
+		gasUsed += block.GasUsed()
 	}
+	require.Equal(t, gasUsed, metrics.GasUsed)
+}
 
-	// Update the state with pending changes
-	var intRoot ethcmn.Hash
-	if config.IsByzantium(header.Number) {
-		err = evmKeeper.CommitStateDB.Finalise(true)
-	} else {
-		intRoot, err = evmKeeper.CommitStateDB.IntermediateRoot(config.IsEIP158(header.Number))
+func TestImporterErrors(t *testing.T) {
+	genesis, blocks, _ := generateChain(t, 3)
+	recipient := ethcmn.HexToAddress("0x1000000000000000000000000000000000000001")
+
+	testCases := []struct {
+		name     string
+		config   Config
+		blocks   []*ethtypes.Block
+		malleate func() context.Context
+	}{
+		{
+			"non contiguous blocks",
+			Config{Genesis: genesis},
+			[]*ethtypes.Block{blocks[0], blocks[2]},
+			context.Background,
+		},
+		{
+			"genesis mismatch",
+			Config{Genesis: ethcore.DefaultGenesisBlock()},
+			blocks,
+			context.Background,
+		},
+		{
+			"balance mismatch",
+			Config{Genesis: genesis, Balances: Balances{2: {recipient: big.NewInt(1)}}},
+			blocks,
+			context.Background,
+		},
+		{
+			"interrupted",
+			Config{Genesis: genesis},
+			blocks,
+			func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+		},
 	}
 
-	if err != nil {
-		return nil, execResult.UsedGas, err
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			im, err := NewImporter(dbm.NewMemDB(), tc.config, logger)
+			require.NoError(t, err)
+
+			_, err = im.Import(tc.malleate(), exportChain(t, tc.blocks))
+			require.Error(t, err)
+		})
 	}
 
-	root := intRoot.Bytes()
-	*usedGas += execResult.UsedGas
+	_, err := NewImporter(dbm.NewMemDB(), Config{}, logger)
+	require.Error(t, err)
 
-	// Create a new receipt for the transaction, storing the intermediate root and gas used by the tx
-	// based on the eip phase, we're passing whether the root touch-delete accounts.
-	receipt := ethtypes.NewReceipt(root, execResult.Failed(), *usedGas)
-	receipt.TxHash = tx.Hash()
-	receipt.GasUsed = execResult.UsedGas
+	// genesis balance mismatch
+	_, err = NewImporter(dbm.NewMemDB(), Config{Genesis: genesis, Balances: Balances{0: {recipient: big.NewInt(1)}}}, logger)
+	require.Error(t, err)
+}
 
-	// if the transaction created a contract, store the creation address in the receipt.
-	if msg.To() == nil {
-		receipt.ContractAddress = ethcrypto.CreateAddress(vmenv.TxContext.Origin, tx.Nonce())
-	}
+func TestParseBalances(t *testing.T) {
+	balances, err := ParseBalances([]byte(`{"46147": {"0xA1E4380A3B1f749673E270229993eE55F35663b4": "2000000000000000000"}}`))
+	require.NoError(t, err)
+	require.Equal(t, "2000000000000000000", balances[46147][ethcmn.HexToAddress("0xA1E4380A3B1f749673E270229993eE55F35663b4")].String())
+
+	_, err = ParseBalances([]byte(`{"1": {"0xA1E4380A3B1f749673E270229993eE55F35663b4": "2e18"}}`))
+	require.Error(t, err)
 
-	// Set the receipt logs and create a bloom for filtering
-	receipt.Logs, err = evmKeeper.CommitStateDB.GetLogs(tx.Hash())
-	receipt.Bloom = ethtypes.CreateBloom(ethtypes.Receipts{receipt})
-	receipt.BlockHash = evmKeeper.CommitStateDB.BlockHash()
-	receipt.BlockNumber = header.Number
-	receipt.TransactionIndex = uint(evmKeeper.CommitStateDB.TxIndex())
+	_, err = ParseBalances([]byte(`{"1": {"invalid": "1"}}`))
+	require.Error(t, err)
+
+	_, err = ParseBalances([]byte(`[]`))
+	require.Error(t, err)
+}
+
+func TestChainGenesis(t *testing.T) {
+	for _, name := range []string{"mainnet", "ropsten", "rinkeby", "goerli"} {
+		genesis, err := ChainGenesis(name)
+		require.NoError(t, err, name)
+		require.NotNil(t, genesis.Config, name)
+	}
 
-	return receipt, execResult.UsedGas, err
+	_, err := ChainGenesis("other")
+	require.Error(t, err)
 }
@@ -0,0 +1,94 @@
+package rpc
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/types/rest"
+
+	ethermint "github.com/cosmos/ethermint/types"
+)
+
+// hexAddressVars are the path variables that take an account address on the SDK REST routes,
+// keyed by the route path template. The EVM routes parse the hex addresses themselves and
+// aren't listed.
+var hexAddressVars = map[string][]string{
+	"/auth/accounts/{address}":                                                  {"address"},
+	"/bank/balances/{address}":                                                  {"address"},
+	"/bank/accounts/{address}/transfers":                                        {"address"},
+	"/distribution/delegators/{delegatorAddr}/rewards":                          {"delegatorAddr"},
+	"/distribution/delegators/{delegatorAddr}/rewards/{validatorAddr}":          {"delegatorAddr"},
+	"/distribution/delegators/{delegatorAddr}/withdraw_address":                 {"delegatorAddr"},
+	"/staking/delegators/{delegatorAddr}/delegations":                           {"delegatorAddr"},
+	"/staking/delegators/{delegatorAddr}/delegations/{validatorAddr}":           {"delegatorAddr"},
+	"/staking/delegators/{delegatorAddr}/redelegations":                         {"delegatorAddr"},
+	"/staking/delegators/{delegatorAddr}/txs":                                   {"delegatorAddr"},
+	"/staking/delegators/{delegatorAddr}/unbonding_delegations":                 {"delegatorAddr"},
+	"/staking/delegators/{delegatorAddr}/unbonding_delegations/{validatorAddr}": {"delegatorAddr"},
+	"/staking/delegators/{delegatorAddr}/validators":                            {"delegatorAddr"},
+	"/staking/delegators/{delegatorAddr}/validators/{validatorAddr}":            {"delegatorAddr"},
+	"/gov/proposals/{proposal-id}/deposits/{depositor}":                         {"depositor"},
+	"/gov/proposals/{proposal-id}/votes/{voter}":                                {"voter"},
+}
+
+// hexAddressQueryParams are the query parameters that take an account address on the SDK
+// REST routes, keyed by the route path template.
+var hexAddressQueryParams = map[string][]string{
+	"/staking/redelegations": {"delegator"},
+	"/gov/proposals":         {"depositor", "voter"},
+	"/txs":                   {"message.sender", "transfer.sender", "transfer.recipient"},
+}
+
+// hexAddressMiddleware converts the 0x prefixed Ethereum hex (EIP-55) addresses on the account
+// address path variables and query parameters of the SDK REST routes (see hexAddressVars and
+// hexAddressQueryParams) to the Bech32 account address format, so that the routes accept both
+// formats. The other path variables and query parameters are left as they are.
+func hexAddressMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := mux.CurrentRoute(r)
+		if route == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		vars := mux.Vars(r)
+		for _, key := range hexAddressVars[template] {
+			addr, err := ethermint.ConvertHexAddress(vars[key])
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			vars[key] = addr
+		}
+
+		query := r.URL.Query()
+		converted := false
+		for _, key := range hexAddressQueryParams[template] {
+			values := query[key]
+			for i, value := range values {
+				addr, err := ethermint.ConvertHexAddress(value)
+				if err != nil {
+					rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+					return
+				}
+
+				converted = converted || addr != value
+				values[i] = addr
+			}
+		}
+
+		if converted {
+			r.URL.RawQuery = query.Encode()
+		}
+
+		next.ServeHTTP(w, mux.SetURLVars(r, vars))
+	})
+}
@@ -0,0 +1,141 @@
+package rpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+func TestHexAddressMiddleware(t *testing.T) {
+	hexAddr := ethcmn.HexToAddress("0x756f45e3fa69347a9a973a725e3c98bc4db0b5a0").Hex()
+	bech32Addr := sdk.AccAddress(ethcmn.HexToAddress(hexAddr).Bytes()).String()
+	valAddr := sdk.ValAddress(ethcmn.HexToAddress(hexAddr).Bytes()).String()
+	txHash := "0x3f2a64a5f7a4c3f04e0b0e4f8b8f4d1a0f6c1e2d3b4a5968778695a4b3c2d1e0"
+
+	testCases := []struct {
+		name      string
+		path      string
+		expStatus int
+		expVars   map[string]string
+		expQuery  url.Values
+	}{
+		{
+			"account address var",
+			"/auth/accounts/" + hexAddr,
+			http.StatusOK,
+			map[string]string{"address": bech32Addr},
+			url.Values{},
+		},
+		{
+			"Bech32 account address var",
+			"/bank/balances/" + bech32Addr,
+			http.StatusOK,
+			map[string]string{"address": bech32Addr},
+			url.Values{},
+		},
+		{
+			"delegator var converted and validator var unchanged",
+			"/staking/delegators/" + hexAddr + "/delegations/" + valAddr,
+			http.StatusOK,
+			map[string]string{"delegatorAddr": bech32Addr, "validatorAddr": valAddr},
+			url.Values{},
+		},
+		{
+			"voter var converted and proposal id unchanged",
+			"/gov/proposals/1/votes/" + hexAddr,
+			http.StatusOK,
+			map[string]string{"proposal-id": "1", "voter": bech32Addr},
+			url.Values{},
+		},
+		{
+			"txs query params",
+			"/txs?message.sender=" + hexAddr + "&message.action=send&page=1",
+			http.StatusOK,
+			map[string]string{},
+			url.Values{"message.sender": {bech32Addr}, "message.action": {"send"}, "page": {"1"}},
+		},
+		{
+			"gov proposals query params",
+			"/gov/proposals?depositor=" + hexAddr + "&voter=" + bech32Addr,
+			http.StatusOK,
+			map[string]string{},
+			url.Values{"depositor": {bech32Addr}, "voter": {bech32Addr}},
+		},
+		{
+			"tx hash var unchanged",
+			"/txs/" + txHash,
+			http.StatusOK,
+			map[string]string{"hash": txHash},
+			url.Values{},
+		},
+		{
+			"unlisted route address var unchanged",
+			"/evm/codes/" + hexAddr,
+			http.StatusOK,
+			map[string]string{"address": hexAddr},
+			url.Values{},
+		},
+		{
+			"unlisted query param unchanged",
+			"/txs?tx.hash=" + txHash + "&data=0xa9059cbb",
+			http.StatusOK,
+			map[string]string{},
+			url.Values{"tx.hash": {txHash}, "data": {"0xa9059cbb"}},
+		},
+		{
+			"invalid checksum address var",
+			"/auth/accounts/0x756f45E3FA69347A9A973A725E3C98bC4db0b5a0",
+			http.StatusBadRequest,
+			nil,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				gotVars  map[string]string
+				gotQuery url.Values
+			)
+
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				gotVars = mux.Vars(r)
+				gotQuery = r.URL.Query()
+			}
+
+			router := mux.NewRouter()
+			router.Use(hexAddressMiddleware)
+			for _, path := range []string{
+				"/auth/accounts/{address}",
+				"/bank/balances/{address}",
+				"/staking/delegators/{delegatorAddr}/delegations/{validatorAddr}",
+				"/gov/proposals/{proposal-id}/votes/{voter}",
+				"/gov/proposals",
+				"/txs",
+				"/txs/{hash}",
+				"/evm/codes/{address}",
+			} {
+				router.HandleFunc(path, handler).Methods("GET")
+			}
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest("GET", tc.path, nil))
+
+			require.Equal(t, tc.expStatus, rec.Code, rec.Body.String())
+			if tc.expStatus != http.StatusOK {
+				return
+			}
+
+			require.Equal(t, tc.expVars, gotVars)
+			require.Equal(t, tc.expQuery, gotQuery)
+		})
+	}
+}
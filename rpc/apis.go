@@ -7,6 +7,7 @@ import (
 
 	"github.com/cosmos/ethermint/crypto/ethsecp256k1"
 	"github.com/cosmos/ethermint/rpc/backend"
+	"github.com/cosmos/ethermint/rpc/namespaces/debug"
 	"github.com/cosmos/ethermint/rpc/namespaces/eth"
 	"github.com/cosmos/ethermint/rpc/namespaces/eth/filters"
 	"github.com/cosmos/ethermint/rpc/namespaces/net"
@@ -21,15 +22,19 @@ const (
 	EthNamespace      = "eth"
 	PersonalNamespace = "personal"
 	NetNamespace      = "net"
+	DebugNamespace    = "debug"
 	flagRPCAPI        = "rpc-api"
+	flagLight         = "light"
 
 	apiVersion = "1.0"
 )
 
-// GetAPIs returns the list of all APIs from the Ethereum namespaces
-func GetAPIs(clientCtx context.CLIContext, selectedApis []string, keys ...ethsecp256k1.PrivKey) []rpc.API {
+// GetAPIs returns the list of all APIs from the Ethereum namespaces. In light mode, the client
+// context must verify the responses of the node (see the --light flag), and the filter APIs
+// aren't available.
+func GetAPIs(clientCtx context.CLIContext, lightMode bool, selectedApis []string, keys ...ethsecp256k1.PrivKey) []rpc.API {
 	nonceLock := new(rpctypes.AddrLocker)
-	backend := backend.New(clientCtx)
+	backend := backend.New(clientCtx, lightMode)
 	ethAPI := eth.NewAPI(clientCtx, backend, nonceLock, keys...)
 
 	var apis []rpc.API
@@ -42,14 +47,18 @@ func GetAPIs(clientCtx context.CLIContext, selectedApis []string, keys ...ethsec
 			Public:    true,
 		},
 	)
-	apis = append(apis,
-		rpc.API{
-			Namespace: EthNamespace,
-			Version:   apiVersion,
-			Service:   filters.NewAPI(clientCtx, backend),
-			Public:    true,
-		},
-	)
+
+	// the filters are built from the logs, blooms and events of the node, which can't be proven
+	if !lightMode {
+		apis = append(apis,
+			rpc.API{
+				Namespace: EthNamespace,
+				Version:   apiVersion,
+				Service:   filters.NewAPI(clientCtx, backend),
+				Public:    true,
+			},
+		)
+	}
 
 	for _, api := range selectedApis {
 		switch api {
@@ -80,6 +89,15 @@ func GetAPIs(clientCtx context.CLIContext, selectedApis []string, keys ...ethsec
 					Public:    true,
 				},
 			)
+		case DebugNamespace:
+			apis = append(apis,
+				rpc.API{
+					Namespace: DebugNamespace,
+					Version:   apiVersion,
+					Service:   debug.NewAPI(clientCtx, backend),
+					Public:    false,
+				},
+			)
 		}
 	}
 
@@ -1,11 +1,14 @@
 package backend
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
 
 	rpctypes "github.com/cosmos/ethermint/rpc/types"
 	evmtypes "github.com/cosmos/ethermint/x/evm/types"
@@ -25,6 +28,9 @@ type Backend interface {
 	LatestBlockNumber() (int64, error)
 	HeaderByNumber(blockNum rpctypes.BlockNumber) (*ethtypes.Header, error)
 	HeaderByHash(blockHash common.Hash) (*ethtypes.Header, error)
+	GetBlockNumber(blockNrOrHash rpctypes.BlockNumberOrHash) (rpctypes.BlockNumber, error)
+	StateHeight(blockNum rpctypes.BlockNumber) (int64, error)
+	LightMode() bool
 	GetBlockByNumber(blockNum rpctypes.BlockNumber, fullTx bool) (map[string]interface{}, error)
 	GetBlockByHash(hash common.Hash, fullTx bool) (map[string]interface{}, error)
 
@@ -47,18 +53,27 @@ type EthermintBackend struct {
 	clientCtx clientcontext.CLIContext
 	logger    log.Logger
 	gasLimit  int64
+	lightMode bool
 }
 
-// New creates a new EthermintBackend instance
-func New(clientCtx clientcontext.CLIContext) *EthermintBackend {
+// New creates a new EthermintBackend instance. In light mode, the client context must verify the
+// blocks and the store query proofs (see LightMode).
+func New(clientCtx clientcontext.CLIContext, lightMode bool) *EthermintBackend {
 	return &EthermintBackend{
 		ctx:       context.Background(),
 		clientCtx: clientCtx,
 		logger:    log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "json-rpc"),
 		gasLimit:  int64(^uint32(0)),
+		lightMode: lightMode,
 	}
 }
 
+// LightMode returns true if the backend runs in light client mode, where the blocks are verified
+// against the light client headers and the state is read with verified store queries.
+func (b *EthermintBackend) LightMode() bool {
+	return b.lightMode
+}
+
 // BlockNumber returns the current block number.
 func (b *EthermintBackend) BlockNumber() (hexutil.Uint64, error) {
 	blockNumber, err := b.LatestBlockNumber()
@@ -107,6 +122,10 @@ func (b *EthermintBackend) GetBlockByHash(hash common.Hash, fullTx bool) (map[st
 		return nil, err
 	}
 
+	if err := checkBlockHash(resBlock.Block.Header, hash); err != nil {
+		return nil, err
+	}
+
 	return rpctypes.EthBlockFromTendermint(b.clientCtx, resBlock.Block)
 }
 
@@ -128,7 +147,7 @@ func (b *EthermintBackend) HeaderByNumber(blockNum rpctypes.BlockNumber) (*ethty
 		return nil, err
 	}
 
-	res, _, err := b.clientCtx.Query(fmt.Sprintf("custom/%s/%s/%d", evmtypes.ModuleName, evmtypes.QueryBloom, resBlock.Block.Height))
+	res, err := rpctypes.QueryBlockHistory(b.clientCtx, fmt.Sprintf("custom/%s/%s/%d", evmtypes.ModuleName, evmtypes.QueryBloom, resBlock.Block.Height), resBlock.Block.Height)
 	if err != nil {
 		return nil, err
 	}
@@ -158,7 +177,11 @@ func (b *EthermintBackend) HeaderByHash(blockHash common.Hash) (*ethtypes.Header
 		return nil, err
 	}
 
-	res, _, err = b.clientCtx.Query(fmt.Sprintf("custom/%s/%s/%d", evmtypes.ModuleName, evmtypes.QueryBloom, resBlock.Block.Height))
+	if err := checkBlockHash(resBlock.Block.Header, blockHash); err != nil {
+		return nil, err
+	}
+
+	res, err = rpctypes.QueryBlockHistory(b.clientCtx, fmt.Sprintf("custom/%s/%s/%d", evmtypes.ModuleName, evmtypes.QueryBloom, resBlock.Block.Height), resBlock.Block.Height)
 	if err != nil {
 		return nil, err
 	}
@@ -172,7 +195,8 @@ func (b *EthermintBackend) HeaderByHash(blockHash common.Hash) (*ethtypes.Header
 }
 
 // GetTransactionLogs returns the logs given a transaction hash.
-// It returns an error if there's an encoding error.
+// It returns an error if there's an encoding error or if the logs of the
+// transaction block have been pruned.
 // If no logs are found for the tx hash, the error is nil.
 func (b *EthermintBackend) GetTransactionLogs(txHash common.Hash) ([]*ethtypes.Log, error) {
 	res, _, err := b.clientCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", evmtypes.ModuleName, evmtypes.QueryTransactionLogs, txHash.String()), nil)
@@ -185,6 +209,35 @@ func (b *EthermintBackend) GetTransactionLogs(txHash common.Hash) ([]*ethtypes.L
 		return nil, err
 	}
 
+	if len(out.Logs) > 0 {
+		return out.Logs, nil
+	}
+
+	// the logs might be empty because they have been pruned, in which case they are queried
+	// at the height of the transaction block
+	tx, err := b.clientCtx.Client.Tx(txHash.Bytes(), false)
+	if err != nil {
+		return out.Logs, nil
+	}
+
+	clientCtx, err := rpctypes.HistoryClientContext(b.clientCtx, tx.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	if clientCtx.Height == b.clientCtx.Height {
+		return out.Logs, nil
+	}
+
+	res, _, err = clientCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", evmtypes.ModuleName, evmtypes.QueryTransactionLogs, txHash.String()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.clientCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+		return nil, err
+	}
+
 	return out.Logs, nil
 }
 
@@ -211,7 +264,32 @@ func (b *EthermintBackend) PendingTransactions() ([]*rpctypes.Transaction, error
 		}
 		transactions = append(transactions, rpcTx)
 	}
-	return transactions, nil
+	return dropReplacedTxs(transactions), nil
+}
+
+// dropReplacedTxs removes the pending transactions that have been replaced by a later
+// transaction from the same sender and with the same nonce. The replaced transactions
+// stay on the mempool until they are rechecked, but they are dropped for the clients.
+func dropReplacedTxs(txs []*rpctypes.Transaction) []*rpctypes.Transaction {
+	type senderNonce struct {
+		from  common.Address
+		nonce uint64
+	}
+
+	// the mempool is ordered, so the replacement is the last tx with the same sender and nonce
+	latest := make(map[senderNonce]int, len(txs))
+	for i, tx := range txs {
+		latest[senderNonce{tx.From, uint64(tx.Nonce)}] = i
+	}
+
+	pending := make([]*rpctypes.Transaction, 0, len(latest))
+	for i, tx := range txs {
+		if latest[senderNonce{tx.From, uint64(tx.Nonce)}] == i {
+			pending = append(pending, tx)
+		}
+	}
+
+	return pending
 }
 
 // GetLogs returns all the logs from all the ethereum transactions in a block.
@@ -226,6 +304,13 @@ func (b *EthermintBackend) GetLogs(blockHash common.Hash) ([][]*ethtypes.Log, er
 		return nil, err
 	}
 
+	// return an error if the block logs have been pruned, unless the node keeps the state at
+	// the block height
+	clientCtx, err := rpctypes.HistoryClientContext(b.clientCtx, out.Number)
+	if err != nil {
+		return nil, err
+	}
+
 	block, err := b.clientCtx.Client.Block(&out.Number)
 	if err != nil {
 		return nil, err
@@ -234,7 +319,7 @@ func (b *EthermintBackend) GetLogs(blockHash common.Hash) ([][]*ethtypes.Log, er
 	var blockLogs = [][]*ethtypes.Log{}
 	for _, tx := range block.Block.Txs {
 		// NOTE: we query the state in case the tx result logs are not persisted after an upgrade.
-		res, _, err := b.clientCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", evmtypes.ModuleName, evmtypes.QueryTransactionLogs, common.BytesToHash(tx.Hash()).String()), nil)
+		res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", evmtypes.ModuleName, evmtypes.QueryTransactionLogs, common.BytesToHash(tx.Hash()).String()), nil)
 		if err != nil {
 			continue
 		}
@@ -266,3 +351,100 @@ func (b *EthermintBackend) LatestBlockNumber() (int64, error) {
 
 	return info.LastHeight, nil
 }
+
+// GetBlockNumber returns the number of the block identified by number or hash. The block
+// hashes are resolved to their height from the EVM store.
+func (b *EthermintBackend) GetBlockNumber(blockNrOrHash rpctypes.BlockNumberOrHash) (rpctypes.BlockNumber, error) {
+	if blockNum, ok := blockNrOrHash.Number(); ok {
+		return blockNum, nil
+	}
+
+	blockHash, ok := blockNrOrHash.Hash()
+	if !ok {
+		return rpctypes.BlockNumber(0), errors.New("either the block number or the block hash must be specified")
+	}
+
+	res, _, err := b.clientCtx.Query(fmt.Sprintf("custom/%s/%s/%s", evmtypes.ModuleName, evmtypes.QueryHashToHeight, blockHash.Hex()))
+	if err != nil {
+		return rpctypes.BlockNumber(0), fmt.Errorf("block %s not found", blockHash)
+	}
+
+	var out evmtypes.QueryResBlockNumber
+	if err := b.clientCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+		return rpctypes.BlockNumber(0), err
+	}
+
+	if b.lightMode {
+		// the height of the hash isn't proven, so check it against the verified header
+		resCommit, err := b.clientCtx.Client.Commit(&out.Number)
+		if err != nil {
+			return rpctypes.BlockNumber(0), err
+		}
+
+		if err := checkBlockHash(*resCommit.Header, blockHash); err != nil {
+			return rpctypes.BlockNumber(0), err
+		}
+	}
+
+	return rpctypes.BlockNumber(out.Number), nil
+}
+
+// StateHeight returns the height to query the application state at the end of the given
+// block, which is 0 (i.e the latest state) for the latest and pending blocks. It returns an
+// error if the block is ahead of the latest block or if its state has been pruned.
+//
+// In light mode, the proofs of the state at height H are verified against the app hash of the
+// header H+1, so the latest verifiable state is the one of the parent of the latest block. The
+// latest and pending blocks are resolved to its height, so that the proofs of the state reads
+// of a request are verified against the same header, and the state of the latest block can't be
+// queried until the next block is committed.
+func (b *EthermintBackend) StateHeight(blockNum rpctypes.BlockNumber) (int64, error) {
+	if !b.lightMode && (blockNum == rpctypes.LatestBlockNumber || blockNum == rpctypes.PendingBlockNumber) {
+		return 0, nil
+	}
+
+	latest, err := b.LatestBlockNumber()
+	if err != nil {
+		return 0, err
+	}
+
+	height := blockNum.Int64()
+	if blockNum == rpctypes.LatestBlockNumber || blockNum == rpctypes.PendingBlockNumber {
+		height = latest - 1
+	}
+
+	if height > latest {
+		return 0, fmt.Errorf("block %d is ahead of the latest block %d", height, latest)
+	}
+
+	if b.lightMode && height >= latest {
+		return 0, fmt.Errorf("the state of block %d can't be verified until block %d is committed", height, height+1)
+	}
+
+	if height < 1 {
+		return 0, fmt.Errorf("the state of block %d is not available", height)
+	}
+
+	// the pruned heights are queried as an empty state, so the EVM chain config, which is
+	// set on genesis, is only missing from the pruned states
+	bz, _, err := b.clientCtx.WithHeight(height).QueryStore(evmtypes.KeyPrefixChainConfig, evmtypes.StoreKey)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(bz) == 0 {
+		return 0, fmt.Errorf("the state of block %d has been pruned, the node only keeps the state of the recent blocks", height)
+	}
+
+	return height, nil
+}
+
+// checkBlockHash returns an error if the hash of the given header doesn't match the block hash
+// that was resolved to its height.
+func checkBlockHash(header tmtypes.Header, blockHash common.Hash) error {
+	if !bytes.Equal(header.Hash(), blockHash.Bytes()) {
+		return fmt.Errorf("block %d hash %X doesn't match the requested block hash %s", header.Height, header.Hash(), blockHash)
+	}
+
+	return nil
+}
@@ -13,9 +13,10 @@ import (
 // Cosmos rest-server endpoints
 func ServeCmd(cdc *codec.Codec) *cobra.Command {
 	cmd := lcd.ServeCommand(cdc, RegisterRoutes)
-	cmd.Flags().String(flagRPCAPI, "", fmt.Sprintf("Comma separated list of RPC API modules to enable: %s, %s, %s, %s", Web3Namespace, EthNamespace, PersonalNamespace, NetNamespace))
+	cmd.Flags().String(flagRPCAPI, "", fmt.Sprintf("Comma separated list of RPC API modules to enable: %s, %s, %s, %s, %s", Web3Namespace, EthNamespace, PersonalNamespace, NetNamespace, DebugNamespace))
 	cmd.Flags().String(flagUnlockKey, "", "Select a key to unlock on the RPC server")
 	cmd.Flags().String(flagWebsocket, "8546", "websocket port to listen to")
 	cmd.Flags().StringP(flags.FlagBroadcastMode, "b", flags.BroadcastSync, "Transaction broadcasting mode (sync|async|block)")
+	cmd.Flags().Bool(flagLight, false, "Run in light client mode: verify the blocks and the proofs of the state queries against the headers certified by the Tendermint light client, and fail if they don't verify")
 	return cmd
 }
@@ -62,7 +62,18 @@ func RegisterRoutes(rs *lcd.RestServer) {
 	rpcapi = strings.ReplaceAll(rpcapi, " ", "")
 	rpcapiArr := strings.Split(rpcapi, ",")
 
-	apis := GetAPIs(rs.CliCtx, rpcapiArr, privkeys...)
+	// in light mode, every route verifies the responses of the node against the light client headers
+	lightMode := viper.GetBool(flagLight)
+	if lightMode {
+		clientCtx, err := lightClientContext(rs.CliCtx)
+		if err != nil {
+			panic(err)
+		}
+
+		rs.CliCtx = clientCtx
+	}
+
+	apis := GetAPIs(rs.CliCtx, lightMode, rpcapiArr, privkeys...)
 
 	// Register all the APIs exposed by the namespace services
 	// TODO: handle allowlist and private APIs
@@ -75,11 +86,17 @@ func RegisterRoutes(rs *lcd.RestServer) {
 	// Web3 RPC API route
 	rs.Mux.HandleFunc("/", server.ServeHTTP).Methods("POST", "OPTIONS")
 
-	// Register all other Cosmos routes
+	// Register all other Cosmos routes, which accept both Bech32 and hex addresses
+	rs.Mux.Use(hexAddressMiddleware)
 	client.RegisterRoutes(rs.CliCtx, rs.Mux)
 	evmrest.RegisterRoutes(rs.CliCtx, rs.Mux)
 	app.ModuleBasics.RegisterRESTRoutes(rs.CliCtx, rs.Mux)
 
+	// the websocket subscriptions stream the events of the node, which can't be proven
+	if lightMode {
+		return
+	}
+
 	// start websockets server
 	websocketAddr := viper.GetString(flagWebsocket)
 	ws := websockets.NewServer(rs.CliCtx, websocketAddr)
@@ -0,0 +1,101 @@
+package rpc
+
+import (
+	"fmt"
+	"strings"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmlite "github.com/tendermint/tendermint/lite"
+	tmliteproxy "github.com/tendermint/tendermint/lite/proxy"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+
+	evmtypes "github.com/cosmos/ethermint/x/evm/types"
+)
+
+// lightClient is a Tendermint RPC client that verifies the blocks, commits and transactions
+// returned by the node against the headers certified by the light client, and that rejects the
+// ABCI queries whose results can't be proven.
+type lightClient struct {
+	tmliteproxy.Wrapper
+}
+
+var _ rpcclient.Client = lightClient{}
+
+// ABCIQuery implements the rpcclient.Client interface. The query is proven like on
+// ABCIQueryWithOptions.
+func (c lightClient) ABCIQuery(path string, data tmbytes.HexBytes) (*ctypes.ResultABCIQuery, error) {
+	return c.ABCIQueryWithOptions(path, data, rpcclient.DefaultABCIQueryOptions)
+}
+
+// ABCIQueryWithOptions implements the rpcclient.Client interface. Only the store key queries are
+// allowed, as the client context verifies their merkle proofs against the app hash of the light
+// client headers. The results of the custom querier paths (e.g the EVM receipts, logs, blooms and
+// block gas used) and of the simulations can't be proven, so those queries fail, except for the
+// block hash to height lookups whose callers check the hash of the verified block header.
+func (c lightClient) ABCIQueryWithOptions(
+	path string, data tmbytes.HexBytes, opts rpcclient.ABCIQueryOptions,
+) (*ctypes.ResultABCIQuery, error) {
+	switch {
+	case isStoreKeyQuery(path):
+		opts.Prove = true
+	case strings.HasPrefix(path, lightHashToHeightPath):
+	default:
+		return nil, fmt.Errorf("the response of the query %s can't be proven against the light client headers", path)
+	}
+
+	return c.Wrapper.Client.ABCIQueryWithOptions(path, data, opts)
+}
+
+// lightHashToHeightPath is the path of the EVM block hash to height lookups, which are allowed in
+// light mode as the hash of the block is then checked against its verified header.
+var lightHashToHeightPath = fmt.Sprintf("custom/%s/%s/", evmtypes.ModuleName, evmtypes.QueryHashToHeight)
+
+// isStoreKeyQuery returns true if the path is a store key query (i.e /store/<store name>/key), whose
+// merkle proof is verified by the client context when it doesn't trust the node.
+func isStoreKeyQuery(path string) bool {
+	paths := strings.SplitN(path, "/", 4)
+	return len(paths) == 4 && paths[0] == "" && paths[1] == "store" && paths[2] != "" && paths[3] == "key"
+}
+
+// BlockchainInfo implements the rpcclient.Client interface. The block metas aren't verified, as
+// only the latest height is used, and the blocks are verified once they are fetched.
+func (c lightClient) BlockchainInfo(minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
+	return c.Wrapper.Client.BlockchainInfo(minHeight, maxHeight)
+}
+
+// Tx implements the rpcclient.Client interface. The inclusion proof of the transaction is always
+// verified against the data hash of its block header.
+func (c lightClient) Tx(hash []byte, _ bool) (*ctypes.ResultTx, error) {
+	return c.Wrapper.Tx(hash, true)
+}
+
+// lightClientContext returns a client context that doesn't trust the node: the store query proofs
+// are verified against the app hash of the light client headers, and the blocks, commits and
+// transactions are verified by the light client.
+func lightClientContext(clientCtx context.CLIContext) (context.CLIContext, error) {
+	clientCtx = clientCtx.WithTrustNode(false)
+
+	node, err := clientCtx.GetNode()
+	if err != nil {
+		return clientCtx, err
+	}
+
+	if clientCtx.Verifier == nil {
+		verifier, err := context.CreateVerifier(clientCtx, context.DefaultVerifierCacheSize)
+		if err != nil {
+			return clientCtx, fmt.Errorf("failed to create the light client verifier: %w", err)
+		}
+
+		clientCtx = clientCtx.WithVerifier(verifier)
+	}
+
+	verifier, ok := clientCtx.Verifier.(*tmlite.DynamicVerifier)
+	if !ok {
+		return clientCtx, fmt.Errorf("invalid light client verifier type %T", clientCtx.Verifier)
+	}
+
+	return clientCtx.WithClient(lightClient{tmliteproxy.SecureClient(node, verifier)}), nil
+}
@@ -0,0 +1,63 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmliteproxy "github.com/tendermint/tendermint/lite/proxy"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+// queryClient records the options of the ABCI queries sent to the node.
+type queryClient struct {
+	rpcclient.Client
+	opts *rpcclient.ABCIQueryOptions
+}
+
+func (c queryClient) ABCIQueryWithOptions(
+	_ string, _ tmbytes.HexBytes, opts rpcclient.ABCIQueryOptions,
+) (*ctypes.ResultABCIQuery, error) {
+	*c.opts = opts
+	return &ctypes.ResultABCIQuery{}, nil
+}
+
+func TestLightClientABCIQuery(t *testing.T) {
+	testCases := []struct {
+		path    string
+		expPass bool
+	}{
+		{"/store/acc/key", true},
+		{"/store/evm/key", true},
+		{"custom/evm/hashToHeight/0x01", true},
+		{"store/evm/key", false},
+		{"/store/evm/subspace", false},
+		{"/store//key", false},
+		{"custom/evm/receipts/1", false},
+		{"custom/evm/logs/0x01", false},
+		{"custom/evm/bloom/1", false},
+		{"custom/evm/blockGasUsed/1", false},
+		{"custom/evm/hashToHeightX/0x01", false},
+		{"app/simulate", false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.path, func(t *testing.T) {
+			opts := new(rpcclient.ABCIQueryOptions)
+			client := lightClient{tmliteproxy.Wrapper{Client: queryClient{opts: opts}}}
+
+			_, err := client.ABCIQuery(tc.path, nil)
+			if !tc.expPass {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			// the store queries are always proven
+			require.Equal(t, tc.path[0] == '/', opts.Prove)
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package debug
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+
+	"github.com/cosmos/ethermint/rpc/backend"
+	rpctypes "github.com/cosmos/ethermint/rpc/types"
+	evmtypes "github.com/cosmos/ethermint/x/evm/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethstate "github.com/ethereum/go-ethereum/core/state"
+)
+
+// PrivateDebugAPI is the debug_ prefixed set of APIs in the Web3 JSON-RPC spec.
+type PrivateDebugAPI struct {
+	clientCtx context.CLIContext
+	backend   backend.Backend
+	logger    log.Logger
+}
+
+// NewAPI creates an instance of the Debug API.
+func NewAPI(clientCtx context.CLIContext, backend backend.Backend) *PrivateDebugAPI {
+	return &PrivateDebugAPI{
+		clientCtx: clientCtx,
+		backend:   backend,
+		logger:    log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "json-rpc", "namespace", "debug"),
+	}
+}
+
+// StorageRangeResult is the result of a debug_storageRangeAt API call.
+type StorageRangeResult struct {
+	Storage StorageMap   `json:"storage"`
+	NextKey *common.Hash `json:"nextKey"` // nil if Storage includes the last key of the storage
+}
+
+// StorageMap defines the storage entries of a StorageRangeResult, keyed by their store key.
+type StorageMap map[common.Hash]StorageEntry
+
+// StorageEntry defines a storage entry of a StorageRangeResult. The key is the preimage of
+// the store key, which is always nil as the preimages are not persisted.
+type StorageEntry struct {
+	Key   *common.Hash `json:"key"`
+	Value common.Hash  `json:"value"`
+}
+
+// AccountDiff is an account changed on a block, returned by the debug_accountDiff API call.
+type AccountDiff struct {
+	Address common.Address              `json:"address"`
+	Storage map[common.Hash]common.Hash `json:"storage"`
+}
+
+// DumpResult is the result of a debug_dumpBlock API call. It's the geth state dump format,
+// along with the hex address of the next page.
+type DumpResult struct {
+	Root     string                                  `json:"root"`
+	Accounts map[common.Address]ethstate.DumpAccount `json:"accounts"`
+	Next     hexutil.Bytes                           `json:"next,omitempty"` // nil if no more accounts
+}
+
+// StorageRangeAt returns the storage of a contract before the transaction at the given index
+// of the block is executed, starting at the keyStart store key and containing at most
+// maxResult entries. The store keys are the Keccak-256 hash of the address and the slot.
+// As the state between the transactions of a block is not persisted, only the state
+// before the first transaction (i.e index 0), which is the state of the parent block, is
+// available.
+func (api *PrivateDebugAPI) StorageRangeAt(
+	blockHash common.Hash, txIndex int, contractAddress common.Address, keyStart hexutil.Bytes, maxResult int,
+) (StorageRangeResult, error) {
+	api.logger.Debug("debug_storageRangeAt", "hash", blockHash, "index", txIndex, "address", contractAddress)
+
+	if txIndex != 0 {
+		return StorageRangeResult{}, fmt.Errorf(
+			"the state before the transaction %d of the block is not available, only the index 0 is supported", txIndex,
+		)
+	}
+
+	if maxResult < 1 {
+		return StorageRangeResult{}, errors.New("the maximum number of results must be positive")
+	}
+
+	header, err := api.backend.HeaderByHash(blockHash)
+	if err != nil {
+		return StorageRangeResult{}, err
+	}
+
+	// the state before the block is the state committed at the parent block height
+	height := header.Number.Int64() - 1
+	if height < 1 {
+		return StorageRangeResult{}, fmt.Errorf("the state before the block %d is not available", header.Number)
+	}
+
+	params := evmtypes.NewQueryStorageRangeParams(
+		contractAddress.Hex(), common.BytesToHash(keyStart).Hex(), uint64(maxResult),
+	)
+
+	bz, err := api.clientCtx.Codec.MarshalJSON(params)
+	if err != nil {
+		return StorageRangeResult{}, err
+	}
+
+	res, _, err := api.clientCtx.WithHeight(height).QueryWithData(
+		fmt.Sprintf("custom/%s/%s", evmtypes.ModuleName, evmtypes.QueryStorageRange), bz)
+	if err != nil {
+		return StorageRangeResult{}, err
+	}
+
+	var out evmtypes.QueryResStorageRange
+	if err := api.clientCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+		return StorageRangeResult{}, err
+	}
+
+	result := StorageRangeResult{Storage: make(StorageMap, len(out.Storage))}
+	for _, state := range out.Storage {
+		result.Storage[common.HexToHash(state.Key)] = StorageEntry{Value: common.HexToHash(state.Value)}
+	}
+
+	if out.NextKey != "" {
+		nextKey := common.HexToHash(out.NextKey)
+		result.NextKey = &nextKey
+	}
+
+	return result, nil
+}
+
+// AccountDiff returns the accounts changed by the EVM transactions of a block, with the
+// storage slots written, keyed by their store key, and their value at the end of the block.
+func (api *PrivateDebugAPI) AccountDiff(blockNum rpctypes.BlockNumber) ([]AccountDiff, error) {
+	api.logger.Debug("debug_accountDiff", "number", blockNum)
+
+	height := blockNum.Int64()
+	if blockNum == rpctypes.LatestBlockNumber || blockNum == rpctypes.PendingBlockNumber {
+		var err error
+		if height, err = api.backend.LatestBlockNumber(); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := rpctypes.QueryBlockHistory(
+		api.clientCtx, fmt.Sprintf("custom/%s/%s/%d", evmtypes.ModuleName, evmtypes.QueryStateDiff, height), height)
+	if err != nil {
+		return nil, err
+	}
+
+	var out evmtypes.QueryResStateDiff
+	if err := api.clientCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+		return nil, err
+	}
+
+	diffs := make([]AccountDiff, len(out.Accounts))
+	for i, account := range out.Accounts {
+		diffs[i] = AccountDiff{
+			Address: common.HexToAddress(account.Address),
+			Storage: make(map[common.Hash]common.Hash, len(account.Storage)),
+		}
+
+		for _, state := range account.Storage {
+			diffs[i].Storage[common.HexToHash(state.Key)] = common.HexToHash(state.Value)
+		}
+	}
+
+	return diffs, nil
+}
+
+// DumpBlock returns the dump of the EthAccounts at the end of a block, in the geth dump
+// format, with their code and storage. As the EVM state isn't stored on a trie, the root
+// is the zero hash and the storage entries are keyed by their store key. The dump is
+// paginated: it starts at the optional start address and contains at most maxResults
+// accounts (100 by default), and its next field is the address of the next page.
+func (api *PrivateDebugAPI) DumpBlock(
+	blockNum rpctypes.BlockNumber, start *hexutil.Bytes, maxResults *int,
+) (DumpResult, error) {
+	api.logger.Debug("debug_dumpBlock", "number", blockNum)
+
+	height := blockNum.Int64()
+	if blockNum == rpctypes.LatestBlockNumber || blockNum == rpctypes.PendingBlockNumber {
+		var err error
+		if height, err = api.backend.LatestBlockNumber(); err != nil {
+			return DumpResult{}, err
+		}
+	}
+
+	if height < 1 {
+		return DumpResult{}, fmt.Errorf("the state of the block %d is not available", height)
+	}
+
+	params := evmtypes.QueryDumpParams{}
+	if start != nil && len(*start) > 0 {
+		params.StartKey = common.BytesToAddress(*start).Hex()
+	}
+
+	if maxResults != nil {
+		if *maxResults < 1 {
+			return DumpResult{}, errors.New("the maximum number of results must be positive")
+		}
+
+		params.Limit = uint64(*maxResults)
+	}
+
+	bz, err := api.clientCtx.Codec.MarshalJSON(params)
+	if err != nil {
+		return DumpResult{}, err
+	}
+
+	res, _, err := api.clientCtx.WithHeight(height).QueryWithData(
+		fmt.Sprintf("custom/%s/%s", evmtypes.ModuleName, evmtypes.QueryDump), bz)
+	if err != nil {
+		return DumpResult{}, err
+	}
+
+	var out evmtypes.QueryResDump
+	if err := api.clientCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+		return DumpResult{}, err
+	}
+
+	dump := DumpResult{
+		Root:     fmt.Sprintf("%x", common.Hash{}),
+		Accounts: make(map[common.Address]ethstate.DumpAccount, len(out.Accounts)),
+	}
+
+	for _, account := range out.Accounts {
+		addr, dumpAccount := account.EthDumpAccount()
+		dump.Accounts[addr] = dumpAccount
+	}
+
+	if out.NextKey != "" {
+		dump.Next = common.HexToAddress(out.NextKey).Bytes()
+	}
+
+	return dump, nil
+}
@@ -21,15 +21,14 @@ import (
 	evmtypes "github.com/cosmos/ethermint/x/evm/types"
 
 	abci "github.com/tendermint/tendermint/abci/types"
-	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/libs/log"
-	"github.com/tendermint/tendermint/rpc/client"
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
 
 	clientcontext "github.com/cosmos/cosmos-sdk/client/context"
@@ -43,14 +42,13 @@ import (
 
 // PublicEthereumAPI is the eth_ prefixed set of APIs in the Web3 JSON-RPC spec.
 type PublicEthereumAPI struct {
-	ctx          context.Context
-	clientCtx    clientcontext.CLIContext
-	chainIDEpoch *big.Int
-	logger       log.Logger
-	backend      backend.Backend
-	keys         []ethsecp256k1.PrivKey // unlocked keys
-	nonceLock    *rpctypes.AddrLocker
-	keyringLock  sync.Mutex
+	ctx         context.Context
+	clientCtx   clientcontext.CLIContext
+	logger      log.Logger
+	backend     backend.Backend
+	keys        []ethsecp256k1.PrivKey // unlocked keys
+	nonceLock   *rpctypes.AddrLocker
+	keyringLock sync.Mutex
 }
 
 // NewAPI creates an instance of the public ETH Web3 API.
@@ -59,19 +57,13 @@ func NewAPI(
 	keys ...ethsecp256k1.PrivKey,
 ) *PublicEthereumAPI {
 
-	epoch, err := ethermint.ParseChainID(clientCtx.ChainID)
-	if err != nil {
-		panic(err)
-	}
-
 	api := &PublicEthereumAPI{
-		ctx:          context.Background(),
-		clientCtx:    clientCtx,
-		chainIDEpoch: epoch,
-		logger:       log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "json-rpc", "namespace", "eth"),
-		backend:      backend,
-		keys:         keys,
-		nonceLock:    nonceLock,
+		ctx:       context.Background(),
+		clientCtx: clientCtx,
+		logger:    log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "json-rpc", "namespace", "eth"),
+		backend:   backend,
+		keys:      keys,
+		nonceLock: nonceLock,
 	}
 
 	if err := api.GetKeyringInfo(); err != nil {
@@ -130,7 +122,12 @@ func (api *PublicEthereumAPI) ProtocolVersion() hexutil.Uint {
 // ChainId returns the chain's identifier in hex format
 func (api *PublicEthereumAPI) ChainId() (hexutil.Uint, error) { // nolint
 	api.logger.Debug("eth_chainId")
-	return hexutil.Uint(uint(api.chainIDEpoch.Uint64())), nil
+	chainID, err := rpctypes.GetChainID(api.clientCtx)
+	if err != nil {
+		return 0, err
+	}
+
+	return hexutil.Uint(uint(chainID.Uint64())), nil
 }
 
 // Syncing returns whether or not the current node is syncing with other peers. Returns false if not, or a struct
@@ -156,7 +153,8 @@ func (api *PublicEthereumAPI) Syncing() (interface{}, error) {
 	}, nil
 }
 
-// Coinbase is the address that staking rewards will be send to (alias for Etherbase).
+// Coinbase returns the operator address of the node's validator, which is used as the
+// EVM coinbase on the blocks proposed by the node (alias for Etherbase).
 func (api *PublicEthereumAPI) Coinbase() (common.Address, error) {
 	api.logger.Debug("eth_coinbase")
 
@@ -170,7 +168,7 @@ func (api *PublicEthereumAPI) Coinbase() (common.Address, error) {
 		return common.Address{}, err
 	}
 
-	return common.BytesToAddress(status.ValidatorInfo.Address.Bytes()), nil
+	return rpctypes.GetValidatorCoinbase(api.clientCtx, status.ValidatorInfo.Address, 0)
 }
 
 // Mining returns whether or not this node is currently mining. Always false.
@@ -219,23 +217,16 @@ func (api *PublicEthereumAPI) BlockNumber() (hexutil.Uint64, error) {
 	return api.backend.BlockNumber()
 }
 
-// GetBalance returns the provided account's balance up to the provided block number.
-func (api *PublicEthereumAPI) GetBalance(address common.Address, blockNum rpctypes.BlockNumber) (*hexutil.Big, error) {
-	api.logger.Debug("eth_getBalance", "address", address, "block number", blockNum)
-
-	clientCtx := api.clientCtx
-	if !(blockNum == rpctypes.PendingBlockNumber || blockNum == rpctypes.LatestBlockNumber) {
-		clientCtx = api.clientCtx.WithHeight(blockNum.Int64())
-	}
+// GetBalance returns the provided account's balance up to the provided block number or hash.
+func (api *PublicEthereumAPI) GetBalance(address common.Address, blockNrOrHash rpctypes.BlockNumberOrHash) (*hexutil.Big, error) {
+	api.logger.Debug("eth_getBalance", "address", address, "block", blockNrOrHash)
 
-	res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/%s/balance/%s", evmtypes.ModuleName, address.Hex()), nil)
+	clientCtx, blockNum, err := api.stateClientContext(blockNrOrHash)
 	if err != nil {
 		return nil, err
 	}
 
-	var out evmtypes.QueryResBalance
-	api.clientCtx.Codec.MustUnmarshalJSON(res, &out)
-	val, err := utils.UnmarshalBigInt(out.Balance)
+	val, err := api.balance(clientCtx, address)
 	if err != nil {
 		return nil, err
 	}
@@ -266,10 +257,24 @@ func (api *PublicEthereumAPI) GetBalance(address common.Address, blockNum rpctyp
 	return (*hexutil.Big)(val), nil
 }
 
-// GetStorageAt returns the contract storage at the given address, block number, and key.
-func (api *PublicEthereumAPI) GetStorageAt(address common.Address, key string, blockNum rpctypes.BlockNumber) (hexutil.Bytes, error) {
-	api.logger.Debug("eth_getStorageAt", "address", address, "key", key, "block number", blockNum)
-	clientCtx := api.clientCtx.WithHeight(blockNum.Int64())
+// GetStorageAt returns the contract storage at the given address, block number or hash, and key.
+func (api *PublicEthereumAPI) GetStorageAt(address common.Address, key string, blockNrOrHash rpctypes.BlockNumberOrHash) (hexutil.Bytes, error) {
+	api.logger.Debug("eth_getStorageAt", "address", address, "key", key, "block", blockNrOrHash)
+
+	clientCtx, _, err := api.stateClientContext(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if api.backend.LightMode() {
+		value, err := rpctypes.QueryStoreState(clientCtx, address, common.HexToHash(key))
+		if err != nil {
+			return nil, err
+		}
+
+		return value.Bytes(), nil
+	}
+
 	res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/%s/storage/%s/%s", evmtypes.ModuleName, address.Hex(), key), nil)
 	if err != nil {
 		return nil, err
@@ -280,19 +285,17 @@ func (api *PublicEthereumAPI) GetStorageAt(address common.Address, key string, b
 	return out.Value, nil
 }
 
-// GetTransactionCount returns the number of transactions at the given address up to the given block number.
-func (api *PublicEthereumAPI) GetTransactionCount(address common.Address, blockNum rpctypes.BlockNumber) (*hexutil.Uint64, error) {
-	api.logger.Debug("eth_getTransactionCount", "address", address, "block number", blockNum)
+// GetTransactionCount returns the number of transactions at the given address up to the given block number
+// or hash.
+func (api *PublicEthereumAPI) GetTransactionCount(address common.Address, blockNrOrHash rpctypes.BlockNumberOrHash) (*hexutil.Uint64, error) {
+	api.logger.Debug("eth_getTransactionCount", "address", address, "block", blockNrOrHash)
 
-	clientCtx := api.clientCtx
-	pending := blockNum == rpctypes.PendingBlockNumber
-
-	// pass the given block height to the context if the height is not pending or latest
-	if !pending && blockNum != rpctypes.LatestBlockNumber {
-		clientCtx = api.clientCtx.WithHeight(blockNum.Int64())
+	clientCtx, blockNum, err := api.stateClientContext(blockNrOrHash)
+	if err != nil {
+		return nil, err
 	}
 
-	nonce, err := api.accountNonce(clientCtx, address, pending)
+	nonce, err := api.accountNonce(clientCtx, address, blockNum == rpctypes.PendingBlockNumber)
 	if err != nil {
 		return nil, err
 	}
@@ -315,7 +318,7 @@ func (api *PublicEthereumAPI) GetBlockTransactionCountByHash(hash common.Hash) *
 	}
 
 	resBlock, err := api.clientCtx.Client.Block(&out.Number)
-	if err != nil {
+	if err != nil || !bytes.Equal(resBlock.Block.Hash(), hash.Bytes()) {
 		return nil
 	}
 
@@ -383,10 +386,24 @@ func (api *PublicEthereumAPI) GetUncleCountByBlockNumber(_ rpctypes.BlockNumber)
 	return 0
 }
 
-// GetCode returns the contract code at the given address and block number.
-func (api *PublicEthereumAPI) GetCode(address common.Address, blockNumber rpctypes.BlockNumber) (hexutil.Bytes, error) {
-	api.logger.Debug("eth_getCode", "address", address, "block number", blockNumber)
-	clientCtx := api.clientCtx.WithHeight(blockNumber.Int64())
+// GetCode returns the contract code at the given address and block number or hash.
+func (api *PublicEthereumAPI) GetCode(address common.Address, blockNrOrHash rpctypes.BlockNumberOrHash) (hexutil.Bytes, error) {
+	api.logger.Debug("eth_getCode", "address", address, "block", blockNrOrHash)
+
+	clientCtx, _, err := api.stateClientContext(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if api.backend.LightMode() {
+		account, err := rpctypes.QueryStoreAccount(clientCtx, address)
+		if err != nil || account == nil {
+			return nil, err
+		}
+
+		return rpctypes.QueryStoreCode(clientCtx, account.CodeHash)
+	}
+
 	res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", evmtypes.ModuleName, evmtypes.QueryCode, address.Hex()), nil)
 	if err != nil {
 		return nil, err
@@ -452,8 +469,13 @@ func (api *PublicEthereumAPI) SendTransaction(args rpctypes.SendTxArgs) (common.
 		return common.Hash{}, err
 	}
 
+	chainID, err := rpctypes.GetChainID(api.clientCtx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
 	// Sign transaction
-	if err := tx.Sign(api.chainIDEpoch, key.ToECDSA()); err != nil {
+	if err := tx.Sign(chainID, key.ToECDSA()); err != nil {
 		api.logger.Debug("failed to sign tx", "error", err)
 		return common.Hash{}, err
 	}
@@ -472,7 +494,9 @@ func (api *PublicEthereumAPI) SendTransaction(args rpctypes.SendTxArgs) (common.
 		return common.Hash{}, err
 	}
 
-	if res.Code != abci.CodeTypeOK {
+	// the transactions with a future nonce are queued on the node, out of the mempool, until
+	// the nonce gap is filled
+	if res.Code != abci.CodeTypeOK && !txQueued(res.Codespace, res.Code) {
 		return common.Hash{}, fmt.Errorf(res.RawLog)
 	}
 	// Return transaction hash
@@ -504,7 +528,9 @@ func (api *PublicEthereumAPI) SendRawTransaction(data hexutil.Bytes) (common.Has
 		return common.Hash{}, err
 	}
 
-	if res.Code != abci.CodeTypeOK {
+	// the transactions with a future nonce are queued on the node, out of the mempool, until
+	// the nonce gap is filled
+	if res.Code != abci.CodeTypeOK && !txQueued(res.Codespace, res.Code) {
 		return common.Hash{}, fmt.Errorf(res.RawLog)
 	}
 	// Return transaction hash
@@ -512,9 +538,12 @@ func (api *PublicEthereumAPI) SendRawTransaction(data hexutil.Bytes) (common.Has
 }
 
 // Call performs a raw contract call.
-func (api *PublicEthereumAPI) Call(args rpctypes.CallArgs, blockNr rpctypes.BlockNumber, _ *map[common.Address]rpctypes.Account) (hexutil.Bytes, error) {
-	api.logger.Debug("eth_call", "args", args, "block number", blockNr)
-	simRes, err := api.doCall(args, blockNr, big.NewInt(ethermint.DefaultRPCGasLimit))
+//
+// NOTE: the SDK simulates the call on the latest state, so the block only determines whether the
+// pending transactions are applied before the call.
+func (api *PublicEthereumAPI) Call(args rpctypes.CallArgs, blockNrOrHash rpctypes.BlockNumberOrHash, _ *map[common.Address]rpctypes.Account) (hexutil.Bytes, error) {
+	api.logger.Debug("eth_call", "args", args, "block", blockNrOrHash)
+	simRes, err := api.doCall(args, blockNrOrHash, big.NewInt(ethermint.DefaultRPCGasLimit))
 	if err != nil {
 		return []byte{}, err
 	}
@@ -530,13 +559,12 @@ func (api *PublicEthereumAPI) Call(args rpctypes.CallArgs, blockNr rpctypes.Bloc
 // DoCall performs a simulated call operation through the evmtypes. It returns the
 // estimated gas used on the operation or an error if fails.
 func (api *PublicEthereumAPI) doCall(
-	args rpctypes.CallArgs, blockNum rpctypes.BlockNumber, globalGasCap *big.Int,
+	args rpctypes.CallArgs, blockNrOrHash rpctypes.BlockNumberOrHash, globalGasCap *big.Int,
 ) (*sdk.SimulationResponse, error) {
 
-	clientCtx := api.clientCtx
-	// pass the given block height to the context if the height is not pending or latest
-	if !(blockNum == rpctypes.PendingBlockNumber || blockNum == rpctypes.LatestBlockNumber) {
-		clientCtx = api.clientCtx.WithHeight(blockNum.Int64())
+	clientCtx, blockNum, err := api.stateClientContext(blockNrOrHash)
+	if err != nil {
+		return nil, err
 	}
 
 	// Set sender address or use a default if none specified
@@ -637,7 +665,9 @@ func (api *PublicEthereumAPI) doCall(
 // param from the SDK.
 func (api *PublicEthereumAPI) EstimateGas(args rpctypes.CallArgs) (hexutil.Uint64, error) {
 	api.logger.Debug("eth_estimateGas", "args", args)
-	simResponse, err := api.doCall(args, 0, big.NewInt(ethermint.DefaultRPCGasLimit))
+	simResponse, err := api.doCall(
+		args, rpctypes.BlockNumberOrHashWithNumber(rpctypes.LatestBlockNumber), big.NewInt(ethermint.DefaultRPCGasLimit),
+	)
 	if err != nil {
 		return 0, err
 	}
@@ -696,6 +726,7 @@ func (api *PublicEthereumAPI) GetBlockByNumber(blockNum rpctypes.BlockNumber, fu
 		},
 		0,
 		latestBlock.Block.Hash(),
+		common.Address{},
 		0,
 		gasUsed,
 		pendingTxs,
@@ -724,7 +755,7 @@ func (api *PublicEthereumAPI) GetTransactionByHash(hash common.Hash) (*rpctypes.
 			}
 		}
 
-		// Return nil for transaction when not found
+		// Return nil for transaction when not found or dropped (i.e replaced)
 		return nil, nil
 	}
 
@@ -761,6 +792,11 @@ func (api *PublicEthereumAPI) GetTransactionByBlockHashAndIndex(hash common.Hash
 		return nil, err
 	}
 
+	// the height of the hash isn't proven, so check it against the block header
+	if !bytes.Equal(resBlock.Block.Hash(), hash.Bytes()) {
+		return nil, fmt.Errorf("block %d hash %X doesn't match the requested block hash %s", out.Number, resBlock.Block.Hash(), hash)
+	}
+
 	return api.getTransactionByBlockAndIndex(resBlock.Block, idx)
 }
 
@@ -829,7 +865,7 @@ func (api *PublicEthereumAPI) GetTransactionReceipt(hash common.Hash) (map[strin
 	api.logger.Debug("eth_getTransactionReceipt", "hash", hash)
 	tx, err := api.clientCtx.Client.Tx(hash.Bytes(), false)
 	if err != nil {
-		// Return nil for transaction when not found
+		// Return nil for transaction when not found or dropped (i.e replaced)
 		return nil, nil
 	}
 
@@ -852,9 +888,13 @@ func (api *PublicEthereumAPI) GetTransactionReceipt(hash common.Hash) (map[strin
 		return nil, err
 	}
 
-	cumulativeGasUsed := uint64(tx.TxResult.GasUsed)
-	if tx.Index != 0 {
-		cumulativeGasUsed += rpctypes.GetBlockCumulativeGas(api.clientCtx.Codec, block.Block, int(tx.Index))
+	cumulativeGasUsed, found := api.cumulativeGasFromReceipts(tx.Height, hash)
+	if !found {
+		// fallback for the blocks that don't have receipts stored
+		cumulativeGasUsed = uint64(tx.TxResult.GasUsed)
+		if tx.Index != 0 {
+			cumulativeGasUsed += rpctypes.GetBlockCumulativeGas(api.clientCtx.Codec, block.Block, int(tx.Index))
+		}
 	}
 
 	// Set status codes based on tx result
@@ -903,6 +943,25 @@ func (api *PublicEthereumAPI) GetTransactionReceipt(hash common.Hash) (map[strin
 	return receipt, nil
 }
 
+// cumulativeGasFromReceipts returns the cumulative gas used on a block up to (and including)
+// the transaction with the given hash, using the receipts stored for that block.
+func (api *PublicEthereumAPI) cumulativeGasFromReceipts(height int64, hash common.Hash) (uint64, bool) {
+	blockReceipts, err := rpctypes.GetBlockReceipts(api.clientCtx, height)
+	if err != nil {
+		return 0, false
+	}
+
+	var cumulativeGasUsed uint64
+	for _, receipt := range blockReceipts.Receipts {
+		cumulativeGasUsed += receipt.GasUsed
+		if receipt.TxHash() == hash {
+			return cumulativeGasUsed, true
+		}
+	}
+
+	return 0, false
+}
+
 // PendingTransactions returns the transactions that are in the transaction pool
 // and have a from address that is one of the accounts this node manages.
 func (api *PublicEthereumAPI) PendingTransactions() ([]*rpctypes.Transaction, error) {
@@ -920,77 +979,106 @@ func (api *PublicEthereumAPI) GetUncleByBlockNumberAndIndex(number hexutil.Uint,
 	return nil
 }
 
-// GetProof returns an account object with proof and any storage proofs
-func (api *PublicEthereumAPI) GetProof(address common.Address, storageKeys []string, block rpctypes.BlockNumber) (*rpctypes.AccountResult, error) {
-	api.logger.Debug("eth_getProof", "address", address, "keys", storageKeys, "number", block)
-
-	clientCtx := api.clientCtx.WithHeight(int64(block))
-	path := fmt.Sprintf("custom/%s/%s/%s", evmtypes.ModuleName, evmtypes.QueryAccount, address.Hex())
+// GetProof returns an account object with proof and any storage proofs. The proofs are IAVL and
+// multistore merkle proofs against the app hash of the next block (see rpctypes.AccountResult.VerifyProof).
+func (api *PublicEthereumAPI) GetProof(address common.Address, storageKeys []string, blockNrOrHash rpctypes.BlockNumberOrHash) (*rpctypes.AccountResult, error) {
+	api.logger.Debug("eth_getProof", "address", address, "keys", storageKeys, "block", blockNrOrHash)
 
-	// query eth account at block height
-	resBz, _, err := clientCtx.Query(path)
+	clientCtx, _, err := api.stateClientContext(blockNrOrHash)
 	if err != nil {
 		return nil, err
 	}
 
-	var account evmtypes.QueryResAccount
-	clientCtx.Codec.MustUnmarshalJSON(resBz, &account)
+	// pin the latest state to an explicit height so that every proof is against the same app hash
+	if clientCtx.Height == 0 {
+		height, err := api.backend.LatestBlockNumber()
+		if err != nil {
+			return nil, err
+		}
+
+		clientCtx = clientCtx.WithHeight(height)
+	}
+
+	account, err := api.account(clientCtx, address)
+	if err != nil {
+		return nil, err
+	}
 
 	storageProofs := make([]rpctypes.StorageResult, len(storageKeys))
-	opts := client.ABCIQueryOptions{Height: int64(block), Prove: true}
 	for i, k := range storageKeys {
-		// Get value for key
-		vPath := fmt.Sprintf("custom/%s/%s/%s/%s", evmtypes.ModuleName, evmtypes.QueryStorage, address, k)
-		vRes, err := api.clientCtx.Client.ABCIQueryWithOptions(vPath, nil, opts)
+		res, err := clientCtx.QueryABCI(abci.RequestQuery{
+			Path:   fmt.Sprintf("/store/%s/key", evmtypes.StoreKey),
+			Data:   rpctypes.StorageProofKey(address, common.HexToHash(k)),
+			Height: clientCtx.Height,
+			Prove:  true,
+		})
 		if err != nil {
 			return nil, err
 		}
 
-		var value evmtypes.QueryResStorage
-		clientCtx.Codec.MustUnmarshalJSON(vRes.Response.GetValue(), &value)
-
-		// check for proof
-		proof := vRes.Response.GetProof()
-		proofStr := new(merkle.Proof).String()
-		if proof != nil {
-			proofStr = proof.String()
+		proof, err := rpctypes.EncodeProof(res.GetProof())
+		if err != nil {
+			return nil, err
 		}
 
 		storageProofs[i] = rpctypes.StorageResult{
 			Key:   k,
-			Value: (*hexutil.Big)(common.BytesToHash(value.Value).Big()),
-			Proof: []string{proofStr},
+			Value: (*hexutil.Big)(new(big.Int).SetBytes(res.GetValue())),
+			Proof: proof,
 		}
 	}
 
-	req := abci.RequestQuery{
-		Path:   fmt.Sprintf("store/%s/key", auth.StoreKey),
-		Data:   auth.AddressStoreKey(sdk.AccAddress(address.Bytes())),
-		Height: int64(block),
+	res, err := clientCtx.QueryABCI(abci.RequestQuery{
+		Path:   fmt.Sprintf("/store/%s/key", auth.StoreKey),
+		Data:   rpctypes.AccountProofKey(address),
+		Height: clientCtx.Height,
 		Prove:  true,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	res, err := clientCtx.QueryABCI(req)
+	accountProof, err := rpctypes.EncodeProof(res.GetProof())
 	if err != nil {
 		return nil, err
 	}
 
-	// check for proof
-	accountProof := res.GetProof()
-	accProofStr := new(merkle.Proof).String()
-	if accountProof != nil {
-		accProofStr = accountProof.String()
+	// the storage hash is the root of the evm store, committed on the multistore
+	storageHash, err := rpctypes.StoreRootFromProof(res.GetProof(), evmtypes.StoreKey)
+	if err != nil {
+		return nil, err
 	}
 
-	return &rpctypes.AccountResult{
+	accountRes := &rpctypes.AccountResult{
 		Address:      address,
-		AccountProof: []string{accProofStr},
+		AccountProof: accountProof,
+		AccountValue: res.GetValue(),
 		Balance:      (*hexutil.Big)(utils.MustUnmarshalBigInt(account.Balance)),
 		CodeHash:     common.BytesToHash(account.CodeHash),
 		Nonce:        hexutil.Uint64(account.Nonce),
-		StorageHash:  common.Hash{}, // Ethermint doesn't have a storage hash
+		StorageHash:  common.BytesToHash(storageHash),
 		StorageProof: storageProofs,
-	}, nil
+	}
+
+	if api.backend.LightMode() {
+		// the account fields are queried from the EVM querier, so they are checked against the
+		// proven account value
+		commit, err := clientCtx.Verify(clientCtx.Height + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		evmDenom, err := rpctypes.QueryStoreEVMDenom(clientCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := accountRes.VerifyProof(commit.AppHash, evmDenom); err != nil {
+			return nil, err
+		}
+	}
+
+	return accountRes, nil
 }
 
 // generateFromArgs populates tx message with args (used in RPC API)
@@ -1098,26 +1186,130 @@ func (api *PublicEthereumAPI) pendingMsgs() ([]sdk.Msg, error) {
 	return msgs, nil
 }
 
+// stateClientContext returns the client context to query the state at the end of the block
+// identified by number or hash, along with the block number. The latest state is queried for
+// the latest and pending blocks. It returns an error if the block hash is unknown or if the
+// state of the block has been pruned.
+func (api *PublicEthereumAPI) stateClientContext(
+	blockNrOrHash rpctypes.BlockNumberOrHash,
+) (clientcontext.CLIContext, rpctypes.BlockNumber, error) {
+	blockNum, err := api.backend.GetBlockNumber(blockNrOrHash)
+	if err != nil {
+		return api.clientCtx, rpctypes.BlockNumber(0), err
+	}
+
+	height, err := api.backend.StateHeight(blockNum)
+	if err != nil {
+		return api.clientCtx, rpctypes.BlockNumber(0), err
+	}
+
+	return api.clientCtx.WithHeight(height), blockNum, nil
+}
+
+// balance returns the EVM denomination balance of the given address at the height of the client
+// context. In light mode, the balance is read from the proven account.
+func (api *PublicEthereumAPI) balance(clientCtx clientcontext.CLIContext, address common.Address) (*big.Int, error) {
+	if !api.backend.LightMode() {
+		res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/%s/balance/%s", evmtypes.ModuleName, address.Hex()), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var out evmtypes.QueryResBalance
+		api.clientCtx.Codec.MustUnmarshalJSON(res, &out)
+		return utils.UnmarshalBigInt(out.Balance)
+	}
+
+	account, err := rpctypes.QueryStoreAccount(clientCtx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	if account == nil {
+		return big.NewInt(0), nil
+	}
+
+	evmDenom, err := rpctypes.QueryStoreEVMDenom(clientCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	return account.Balance(evmDenom).BigInt(), nil
+}
+
+// account returns the EVM fields of the account of the given address at the height of the client
+// context. In light mode, they are read from the proven account.
+func (api *PublicEthereumAPI) account(clientCtx clientcontext.CLIContext, address common.Address) (evmtypes.QueryResAccount, error) {
+	if !api.backend.LightMode() {
+		res, _, err := clientCtx.Query(fmt.Sprintf("custom/%s/%s/%s", evmtypes.ModuleName, evmtypes.QueryAccount, address.Hex()))
+		if err != nil {
+			return evmtypes.QueryResAccount{}, err
+		}
+
+		var out evmtypes.QueryResAccount
+		clientCtx.Codec.MustUnmarshalJSON(res, &out)
+		return out, nil
+	}
+
+	out := evmtypes.QueryResAccount{
+		Balance:  "0",
+		CodeHash: ethcrypto.Keccak256(nil),
+	}
+
+	account, err := rpctypes.QueryStoreAccount(clientCtx, address)
+	if err != nil || account == nil {
+		return out, err
+	}
+
+	evmDenom, err := rpctypes.QueryStoreEVMDenom(clientCtx)
+	if err != nil {
+		return out, err
+	}
+
+	out.Balance = utils.MustMarshalBigInt(account.Balance(evmDenom).BigInt())
+	out.Nonce = account.GetSequence()
+	if len(account.CodeHash) > 0 {
+		out.CodeHash = account.CodeHash
+	}
+
+	return out, nil
+}
+
 // accountNonce returns looks up the transaction nonce count for a given address. If the pending boolean
 // is set to true, it will add to the counter all the uncommitted EVM transactions sent from the address.
 // NOTE: The function returns no error if the account doesn't exist.
 func (api *PublicEthereumAPI) accountNonce(
 	clientCtx clientcontext.CLIContext, address common.Address, pending bool,
 ) (uint64, error) {
-	// Get nonce (sequence) from sender account
-	from := sdk.AccAddress(address.Bytes())
+	var nonce uint64
 
-	// use a the given client context in case its wrapped with a custom height
-	accRet := authtypes.NewAccountRetriever(clientCtx)
+	if api.backend.LightMode() {
+		account, err := rpctypes.QueryStoreAccount(clientCtx, address)
+		if err != nil {
+			return 0, err
+		}
 
-	if err := accRet.EnsureExists(from); err != nil {
-		// account doesn't exist yet, return 0
-		return 0, nil
-	}
+		if account != nil {
+			nonce = account.GetSequence()
+		}
+	} else {
+		// Get nonce (sequence) from sender account
+		from := sdk.AccAddress(address.Bytes())
 
-	_, nonce, err := accRet.GetAccountNumberSequence(from)
-	if err != nil {
-		return 0, err
+		// use a the given client context in case its wrapped with a custom height
+		accRet := authtypes.NewAccountRetriever(clientCtx)
+
+		if err := accRet.EnsureExists(from); err != nil {
+			// account doesn't exist yet, return 0
+			return 0, nil
+		}
+
+		_, seq, err := accRet.GetAccountNumberSequence(from)
+		if err != nil {
+			return 0, err
+		}
+
+		nonce = seq
 	}
 
 	if !pending {
@@ -1145,3 +1337,9 @@ func (api *PublicEthereumAPI) accountNonce(
 
 	return nonce, nil
 }
+
+// txQueued returns true if the broadcasted transaction has been queued on the node until the
+// gap between its nonce and the sender's pending nonce is filled.
+func txQueued(codespace string, code uint32) bool {
+	return codespace == ethermint.ErrTxQueued.Codespace() && code == ethermint.ErrTxQueued.ABCICode()
+}
@@ -4,28 +4,28 @@ import (
 	"fmt"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
-	ethermint "github.com/cosmos/ethermint/types"
+
+	rpctypes "github.com/cosmos/ethermint/rpc/types"
 )
 
 // PublicNetAPI is the eth_ prefixed set of APIs in the Web3 JSON-RPC spec.
 type PublicNetAPI struct {
-	networkVersion uint64
+	clientCtx context.CLIContext
 }
 
 // NewAPI creates an instance of the public Net Web3 API.
 func NewAPI(clientCtx context.CLIContext) *PublicNetAPI {
-	// parse the chainID from a integer string
-	chainIDEpoch, err := ethermint.ParseChainID(clientCtx.ChainID)
-	if err != nil {
-		panic(err)
-	}
-
 	return &PublicNetAPI{
-		networkVersion: chainIDEpoch.Uint64(),
+		clientCtx: clientCtx,
 	}
 }
 
-// Version returns the current ethereum protocol version.
-func (api *PublicNetAPI) Version() string {
-	return fmt.Sprintf("%d", api.networkVersion)
+// Version returns the current ethereum protocol version, i.e the EIP-155 chain ID.
+func (api *PublicNetAPI) Version() (string, error) {
+	chainID, err := rpctypes.GetChainID(api.clientCtx)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%d", chainID), nil
 }
@@ -1,11 +1,14 @@
 package types
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
@@ -79,3 +82,98 @@ func (bn BlockNumber) TmHeight() *int64 {
 	height := bn.Int64()
 	return &height
 }
+
+// BlockNumberOrHash identifies a block either by number or by hash, as defined by EIP-1898.
+// As Tendermint blocks are final, every block hash is canonical and RequireCanonical has no
+// effect.
+type BlockNumberOrHash struct {
+	BlockNumber      *BlockNumber `json:"blockNumber,omitempty"`
+	BlockHash        *common.Hash `json:"blockHash,omitempty"`
+	RequireCanonical bool         `json:"requireCanonical,omitempty"`
+}
+
+// UnmarshalJSON parses the given JSON fragment into a BlockNumberOrHash. It supports:
+// - an EIP-1898 object with either a blockNumber or a blockHash field
+// - "latest", "earliest" or "pending" as string arguments
+// - the block number
+// - the block hash
+func (bnh *BlockNumberOrHash) UnmarshalJSON(data []byte) error {
+	type erased BlockNumberOrHash
+	e := erased{}
+	if err := json.Unmarshal(data, &e); err == nil {
+		if e.BlockNumber != nil && e.BlockHash != nil {
+			return errors.New("cannot specify both blockHash and blockNumber, choose one or the other")
+		}
+
+		if e.BlockNumber == nil && e.BlockHash == nil {
+			return errors.New("either blockHash or blockNumber must be specified")
+		}
+
+		*bnh = BlockNumberOrHash(e)
+		return nil
+	}
+
+	var input string
+	if err := json.Unmarshal(data, &input); err != nil {
+		return err
+	}
+
+	if len(input) == 66 {
+		hash := common.Hash{}
+		if err := hash.UnmarshalText([]byte(input)); err != nil {
+			return err
+		}
+
+		*bnh = BlockNumberOrHashWithHash(hash, false)
+		return nil
+	}
+
+	var bn BlockNumber
+	if err := bn.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	*bnh = BlockNumberOrHashWithNumber(bn)
+	return nil
+}
+
+// Number returns the block number and true if the block is identified by number.
+func (bnh BlockNumberOrHash) Number() (BlockNumber, bool) {
+	if bnh.BlockNumber != nil {
+		return *bnh.BlockNumber, true
+	}
+
+	return BlockNumber(0), false
+}
+
+// Hash returns the block hash and true if the block is identified by hash.
+func (bnh BlockNumberOrHash) Hash() (common.Hash, bool) {
+	if bnh.BlockHash != nil {
+		return *bnh.BlockHash, true
+	}
+
+	return common.Hash{}, false
+}
+
+// String implements the fmt.Stringer interface
+func (bnh BlockNumberOrHash) String() string {
+	if bnh.BlockHash != nil {
+		return bnh.BlockHash.String()
+	}
+
+	if bnh.BlockNumber != nil {
+		return fmt.Sprint(bnh.BlockNumber.Int64())
+	}
+
+	return "nil"
+}
+
+// BlockNumberOrHashWithNumber returns a BlockNumberOrHash that identifies a block by number.
+func BlockNumberOrHashWithNumber(blockNum BlockNumber) BlockNumberOrHash {
+	return BlockNumberOrHash{BlockNumber: &blockNum}
+}
+
+// BlockNumberOrHashWithHash returns a BlockNumberOrHash that identifies a block by hash.
+func BlockNumberOrHashWithHash(hash common.Hash, canonical bool) BlockNumberOrHash {
+	return BlockNumberOrHash{BlockHash: &hash, RequireCanonical: canonical}
+}
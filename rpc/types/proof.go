@@ -0,0 +1,246 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/tendermint/tendermint/crypto/merkle"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	"github.com/cosmos/ethermint/crypto/ethsecp256k1"
+	ethermint "github.com/cosmos/ethermint/types"
+	evmtypes "github.com/cosmos/ethermint/x/evm/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// The eth_getProof results of Ethermint don't contain Merkle Patricia trie nodes, as the state is
+// stored on the IAVL trees of the application multistore:
+//
+//  - the account proof is the chain of Tendermint merkle proof operations (leaf first) of the
+//    account key (see AccountProofKey) on the auth store, and of the auth store root on the
+//    multistore. The proven value is the amino encoded account, returned on the accountValue
+//    field. An account that doesn't exist is proven by an IAVL absence operation.
+//  - the storage hash is the root of the EVM store, which is proven by the multistore operation
+//    of the account proof.
+//  - each storage proof is the chain of operations of the storage key (see StorageProofKey) on
+//    the EVM store, and of the EVM store root on the multistore. The proven value is the 32 bytes
+//    slot value. As zero values are deleted from the store, they are proven by absence.
+//
+// Each operation is a protobuf encoded merkle.ProofOp, in hex with the 0x prefix. The proofs of a
+// state at height H are verified against the app hash (i.e the stateRoot) of the block H+1.
+
+// proofCdc is the codec used to decode the accounts proven by the account proofs
+var proofCdc = codec.New()
+
+func init() {
+	authtypes.RegisterCodec(proofCdc)
+	ethermint.RegisterCodec(proofCdc)
+	ethsecp256k1.RegisterCodec(proofCdc)
+	codec.RegisterCrypto(proofCdc)
+	proofCdc.Seal()
+}
+
+// AccountProofKey returns the key of the given address account on the auth store.
+func AccountProofKey(address common.Address) []byte {
+	return authtypes.AddressStoreKey(sdk.AccAddress(address.Bytes()))
+}
+
+// StorageProofKey returns the key of the given address storage slot on the EVM store.
+func StorageProofKey(address common.Address, slot common.Hash) []byte {
+	return append(evmtypes.AddressStoragePrefix(address), ethcrypto.Keccak256(address.Bytes(), slot.Bytes())...)
+}
+
+// EncodeProof returns the hex encoding of each operation of the given merkle proof.
+func EncodeProof(proof *merkle.Proof) ([]string, error) {
+	if proof == nil {
+		return nil, fmt.Errorf("proof is empty")
+	}
+
+	ops := make([]string, len(proof.Ops))
+	for i, op := range proof.Ops {
+		bz, err := op.Marshal()
+		if err != nil {
+			return nil, err
+		}
+
+		ops[i] = hexutil.Encode(bz)
+	}
+
+	return ops, nil
+}
+
+// DecodeProof decodes the hex encoded operations of a merkle proof.
+func DecodeProof(ops []string) (*merkle.Proof, error) {
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("proof is empty")
+	}
+
+	proof := &merkle.Proof{Ops: make([]merkle.ProofOp, len(ops))}
+	for i, op := range ops {
+		bz, err := hexutil.Decode(op)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proof operation %d: %w", i, err)
+		}
+
+		if err := proof.Ops[i].Unmarshal(bz); err != nil {
+			return nil, fmt.Errorf("invalid proof operation %d: %w", i, err)
+		}
+	}
+
+	return proof, nil
+}
+
+// StoreRootFromProof returns the root of the given store, as committed on the multistore
+// operation of a proof.
+func StoreRootFromProof(proof *merkle.Proof, storeName string) ([]byte, error) {
+	if proof == nil || len(proof.Ops) == 0 {
+		return nil, fmt.Errorf("proof is empty")
+	}
+
+	op, err := rootmulti.MultiStoreProofOpDecoder(proof.Ops[len(proof.Ops)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, si := range op.(rootmulti.MultiStoreProofOp).Proof.StoreInfos {
+		if si.Name == storeName {
+			return si.Core.CommitID.Hash, nil
+		}
+	}
+
+	return nil, fmt.Errorf("store %s not found in multistore proof", storeName)
+}
+
+// VerifyProof verifies the account and storage proofs of an eth_getProof result against the app
+// hash of the block following the proven state. The balance is checked against the given EVM
+// denomination.
+func (res AccountResult) VerifyProof(appHash []byte, evmDenom string) error {
+	prt := rootmulti.DefaultProofRuntime()
+
+	accountProof, err := DecodeProof(res.AccountProof)
+	if err != nil {
+		return fmt.Errorf("invalid account proof: %w", err)
+	}
+
+	keyPath := proofKeyPath(authtypes.StoreKey, AccountProofKey(res.Address))
+	if len(res.AccountValue) == 0 {
+		if err := prt.VerifyAbsence(accountProof, appHash, keyPath); err != nil {
+			return fmt.Errorf("invalid account proof: %w", err)
+		}
+
+		if err := res.verifyAccount(nil, evmDenom); err != nil {
+			return err
+		}
+	} else {
+		if err := prt.VerifyValue(accountProof, appHash, keyPath, res.AccountValue); err != nil {
+			return fmt.Errorf("invalid account proof: %w", err)
+		}
+
+		var account authexported.Account
+		if err := proofCdc.UnmarshalBinaryBare(res.AccountValue, &account); err != nil {
+			return fmt.Errorf("invalid account value: %w", err)
+		}
+
+		if err := res.verifyAccount(account, evmDenom); err != nil {
+			return err
+		}
+	}
+
+	// the storage hash is committed by the evm store info of the account proof multistore operation
+	multiStoreOp := accountProof.Ops[len(accountProof.Ops)-1]
+	multiStoreOp.Key = []byte(evmtypes.StoreKey)
+	if err := prt.VerifyValue(&merkle.Proof{Ops: []merkle.ProofOp{multiStoreOp}}, appHash, proofKeyPath(evmtypes.StoreKey), res.StorageHash.Bytes()); err != nil {
+		return fmt.Errorf("invalid storage hash: %w", err)
+	}
+
+	for _, sr := range res.StorageProof {
+		storageProof, err := DecodeProof(sr.Proof)
+		if err != nil {
+			return fmt.Errorf("invalid storage proof of key %s: %w", sr.Key, err)
+		}
+
+		keyPath := proofKeyPath(evmtypes.StoreKey, StorageProofKey(res.Address, common.HexToHash(sr.Key)))
+
+		value := (*big.Int)(sr.Value)
+		if value == nil || value.Sign() == 0 {
+			err = prt.VerifyAbsence(storageProof, appHash, keyPath)
+		} else {
+			err = prt.VerifyValue(storageProof, appHash, keyPath, common.BigToHash(value).Bytes())
+		}
+
+		if err != nil {
+			return fmt.Errorf("invalid storage proof of key %s: %w", sr.Key, err)
+		}
+
+		root, err := StoreRootFromProof(storageProof, evmtypes.StoreKey)
+		if err != nil {
+			return fmt.Errorf("invalid storage proof of key %s: %w", sr.Key, err)
+		}
+
+		if !bytes.Equal(root, res.StorageHash.Bytes()) {
+			return fmt.Errorf("storage proof of key %s doesn't match the storage hash", sr.Key)
+		}
+	}
+
+	return nil
+}
+
+// verifyAccount checks that the balance, nonce and code hash of the result match the proven
+// account. A nil account is an account that doesn't exist on the state.
+func (res AccountResult) verifyAccount(account authexported.Account, evmDenom string) error {
+	var (
+		balance  = sdk.ZeroInt()
+		nonce    uint64
+		codeHash = ethcrypto.Keccak256(nil)
+	)
+
+	if account != nil {
+		ethAccount, ok := account.(*ethermint.EthAccount)
+		if !ok {
+			return fmt.Errorf("invalid account type %T", account)
+		}
+
+		if ethAccount.EthAddress() != res.Address {
+			return fmt.Errorf("account address mismatch: %s vs %s", ethAccount.EthAddress().String(), res.Address.String())
+		}
+
+		balance = ethAccount.Balance(evmDenom)
+		nonce = ethAccount.GetSequence()
+		if len(ethAccount.CodeHash) > 0 {
+			codeHash = ethAccount.CodeHash
+		}
+	}
+
+	if res.Balance == nil || balance.BigInt().Cmp(res.Balance.ToInt()) != 0 {
+		return fmt.Errorf("account balance mismatch: %s vs %s", balance, res.Balance)
+	}
+
+	if uint64(res.Nonce) != nonce {
+		return fmt.Errorf("account nonce mismatch: %d vs %d", nonce, res.Nonce)
+	}
+
+	if !bytes.Equal(res.CodeHash.Bytes(), codeHash) {
+		return fmt.Errorf("account code hash mismatch: %x vs %s", codeHash, res.CodeHash.Hex())
+	}
+
+	return nil
+}
+
+// proofKeyPath returns the merkle key path of a key on the given store of the multistore.
+func proofKeyPath(storeName string, keys ...[]byte) string {
+	keyPath := merkle.KeyPath{}.AppendKey([]byte(storeName), merkle.KeyEncodingURL)
+	for _, key := range keys {
+		keyPath = keyPath.AppendKey(key, merkle.KeyEncodingHex)
+	}
+
+	return keyPath.String()
+}
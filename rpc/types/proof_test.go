@@ -0,0 +1,176 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	ethermint "github.com/cosmos/ethermint/types"
+	evmtypes "github.com/cosmos/ethermint/x/evm/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// proveAccount returns the eth_getProof result of the given address and slots on the latest
+// version of the multistore.
+func proveAccount(t *testing.T, cms sdk.CommitMultiStore, address common.Address, slots ...common.Hash) AccountResult {
+	queryable := cms.(sdk.Queryable)
+	height := cms.LastCommitID().Version
+
+	res := queryable.Query(abci.RequestQuery{
+		Path: "/" + authtypes.StoreKey + "/key", Data: AccountProofKey(address), Height: height, Prove: true,
+	})
+	require.True(t, res.IsOK(), res.Log)
+
+	accountProof, err := EncodeProof(res.Proof)
+	require.NoError(t, err)
+
+	storageHash, err := StoreRootFromProof(res.Proof, evmtypes.StoreKey)
+	require.NoError(t, err)
+
+	result := AccountResult{
+		Address:      address,
+		AccountProof: accountProof,
+		AccountValue: res.Value,
+		Balance:      (*hexutil.Big)(big.NewInt(0)),
+		CodeHash:     common.BytesToHash(ethcrypto.Keccak256(nil)),
+		StorageHash:  common.BytesToHash(storageHash),
+	}
+
+	if len(res.Value) > 0 {
+		var acc *ethermint.EthAccount
+		require.NoError(t, proofCdc.UnmarshalBinaryBare(res.Value, &acc))
+
+		result.Balance = (*hexutil.Big)(acc.Balance(ethermint.AttoPhoton).BigInt())
+		result.Nonce = hexutil.Uint64(acc.GetSequence())
+		result.CodeHash = common.BytesToHash(acc.CodeHash)
+	}
+
+	for _, slot := range slots {
+		res := queryable.Query(abci.RequestQuery{
+			Path: "/" + evmtypes.StoreKey + "/key", Data: StorageProofKey(address, slot), Height: height, Prove: true,
+		})
+		require.True(t, res.IsOK(), res.Log)
+
+		proof, err := EncodeProof(res.Proof)
+		require.NoError(t, err)
+
+		result.StorageProof = append(result.StorageProof, StorageResult{
+			Key:   slot.Hex(),
+			Value: (*hexutil.Big)(new(big.Int).SetBytes(res.Value)),
+			Proof: proof,
+		})
+	}
+
+	return result
+}
+
+func TestAccountResultVerifyProof(t *testing.T) {
+	accKey := sdk.NewKVStoreKey(authtypes.StoreKey)
+	evmKey := sdk.NewKVStoreKey(evmtypes.StoreKey)
+
+	cms := store.NewCommitMultiStore(dbm.NewMemDB())
+	cms.MountStoreWithDB(accKey, sdk.StoreTypeIAVL, nil)
+	cms.MountStoreWithDB(evmKey, sdk.StoreTypeIAVL, nil)
+	require.NoError(t, cms.LoadLatestVersion())
+
+	address := common.BytesToAddress([]byte{1, 2, 3})
+	missing := common.BytesToAddress([]byte{4, 5, 6})
+	slot, emptySlot := common.BigToHash(big.NewInt(1)), common.BigToHash(big.NewInt(2))
+
+	acc := ethermint.ProtoAccount().(*ethermint.EthAccount)
+	require.NoError(t, acc.SetAddress(sdk.AccAddress(address.Bytes())))
+	require.NoError(t, acc.SetSequence(3))
+	acc.SetBalance(ethermint.AttoPhoton, sdk.NewInt(100))
+	acc.CodeHash = ethcrypto.Keccak256([]byte("code"))
+
+	cms.GetKVStore(accKey).Set(AccountProofKey(address), proofCdc.MustMarshalBinaryBare(acc))
+	cms.GetKVStore(evmKey).Set(StorageProofKey(address, slot), common.BigToHash(big.NewInt(42)).Bytes())
+	appHash := cms.Commit().Hash
+
+	testCases := []struct {
+		name     string
+		address  common.Address
+		malleate func(res *AccountResult)
+		appHash  []byte
+		expPass  bool
+	}{
+		{"account and storage", address, func(*AccountResult) {}, appHash, true},
+		{"missing account", missing, func(*AccountResult) {}, appHash, true},
+		{"wrong app hash", address, func(*AccountResult) {}, ethcrypto.Keccak256(nil), false},
+		{
+			"tampered balance", address,
+			func(res *AccountResult) { res.Balance = (*hexutil.Big)(big.NewInt(101)) },
+			appHash, false,
+		},
+		{
+			"tampered nonce", address,
+			func(res *AccountResult) { res.Nonce++ },
+			appHash, false,
+		},
+		{
+			"tampered code hash", address,
+			func(res *AccountResult) { res.CodeHash = common.Hash{} },
+			appHash, false,
+		},
+		{
+			"missing account with balance", missing,
+			func(res *AccountResult) { res.Balance = (*hexutil.Big)(big.NewInt(1)) },
+			appHash, false,
+		},
+		{
+			"tampered account value", address,
+			func(res *AccountResult) { res.AccountValue = nil },
+			appHash, false,
+		},
+		{
+			"tampered storage hash", address,
+			func(res *AccountResult) { res.StorageHash = common.Hash{} },
+			appHash, false,
+		},
+		{
+			"tampered storage value", address,
+			func(res *AccountResult) { res.StorageProof[0].Value = (*hexutil.Big)(big.NewInt(43)) },
+			appHash, false,
+		},
+		{
+			"empty slot with value", address,
+			func(res *AccountResult) { res.StorageProof[1].Value = (*hexutil.Big)(big.NewInt(1)) },
+			appHash, false,
+		},
+		{
+			"swapped storage proofs", address,
+			func(res *AccountResult) {
+				res.StorageProof[0].Proof, res.StorageProof[1].Proof = res.StorageProof[1].Proof, res.StorageProof[0].Proof
+			},
+			appHash, false,
+		},
+		{
+			"empty account proof", address,
+			func(res *AccountResult) { res.AccountProof = nil },
+			appHash, false,
+		},
+	}
+
+	for _, tc := range testCases {
+		res := proveAccount(t, cms, tc.address, slot, emptySlot)
+		tc.malleate(&res)
+
+		err := res.VerifyProof(tc.appHash, ethermint.AttoPhoton)
+		if tc.expPass {
+			require.NoError(t, err, tc.name)
+		} else {
+			require.Error(t, err, tc.name)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"fmt"
+
+	clientcontext "github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
+	"github.com/cosmos/cosmos-sdk/x/params"
+
+	ethermint "github.com/cosmos/ethermint/types"
+	evmtypes "github.com/cosmos/ethermint/x/evm/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// The functions below read the EVM state with store queries instead of the EVM querier, so that the
+// client context verifies their merkle proofs against the light client headers when it doesn't
+// trust the node. The state is read at the height of the client context.
+
+// QueryStoreAccount returns the EthAccount of the given address from the auth store, or nil if the
+// account doesn't exist.
+func QueryStoreAccount(clientCtx clientcontext.CLIContext, address common.Address) (*ethermint.EthAccount, error) {
+	bz, _, err := clientCtx.QueryStore(AccountProofKey(address), auth.StoreKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bz) == 0 {
+		return nil, nil
+	}
+
+	var account authexported.Account
+	if err := clientCtx.Codec.UnmarshalBinaryBare(bz, &account); err != nil {
+		return nil, err
+	}
+
+	ethAccount, ok := account.(*ethermint.EthAccount)
+	if !ok {
+		return nil, fmt.Errorf("invalid account type %T for address %s", account, address.String())
+	}
+
+	return ethAccount, nil
+}
+
+// QueryStoreEVMDenom returns the EVM denomination from the params store.
+func QueryStoreEVMDenom(clientCtx clientcontext.CLIContext) (string, error) {
+	key := append([]byte(evmtypes.DefaultParamspace+"/"), evmtypes.ParamStoreKeyEVMDenom...)
+
+	bz, _, err := clientCtx.QueryStore(key, params.StoreKey)
+	if err != nil {
+		return "", err
+	}
+
+	var denom string
+	if err := clientCtx.Codec.UnmarshalJSON(bz, &denom); err != nil {
+		return "", fmt.Errorf("invalid evm denomination parameter: %w", err)
+	}
+
+	return denom, nil
+}
+
+// QueryStoreState returns the value of the given address storage slot from the EVM store.
+func QueryStoreState(clientCtx clientcontext.CLIContext, address common.Address, slot common.Hash) (common.Hash, error) {
+	bz, _, err := clientCtx.QueryStore(StorageProofKey(address, slot), evmtypes.StoreKey)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return common.BytesToHash(bz), nil
+}
+
+// QueryStoreCode returns the code of the given code hash from the EVM store. The code is checked
+// against its hash.
+func QueryStoreCode(clientCtx clientcontext.CLIContext, codeHash []byte) ([]byte, error) {
+	if len(codeHash) == 0 || common.BytesToHash(codeHash) == common.BytesToHash(ethcrypto.Keccak256(nil)) {
+		return nil, nil
+	}
+
+	code, _, err := clientCtx.QueryStore(append(evmtypes.KeyPrefixCode, codeHash...), evmtypes.StoreKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if common.BytesToHash(ethcrypto.Keccak256(code)) != common.BytesToHash(codeHash) {
+		return nil, fmt.Errorf("code doesn't match the code hash %x", codeHash)
+	}
+
+	return code, nil
+}
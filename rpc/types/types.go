@@ -8,10 +8,12 @@ import (
 // Copied the Account and StorageResult types since they are registered under an
 // internal pkg on geth.
 
-// AccountResult struct for account proof
+// AccountResult struct for account proof. The AccountValue field is an Ethermint extension that
+// contains the amino encoded account proven by the account proof (see AccountResult.VerifyProof).
 type AccountResult struct {
 	Address      common.Address  `json:"address"`
 	AccountProof []string        `json:"accountProof"`
+	AccountValue hexutil.Bytes   `json:"accountValue"`
 	Balance      *hexutil.Big    `json:"balance"`
 	CodeHash     common.Hash     `json:"codeHash"`
 	Nonce        hexutil.Uint64  `json:"nonce"`
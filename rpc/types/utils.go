@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"strings"
 
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	tmtypes "github.com/tendermint/tendermint/types"
@@ -70,27 +71,39 @@ func NewTransaction(tx *evmtypes.MsgEthereumTx, txHash, blockHash common.Hash, b
 
 // EthBlockFromTendermint returns a JSON-RPC compatible Ethereum blockfrom a given Tendermint block.
 func EthBlockFromTendermint(clientCtx clientcontext.CLIContext, block *tmtypes.Block) (map[string]interface{}, error) {
-	gasLimit, err := BlockMaxGasFromConsensusParams(context.Background(), clientCtx)
+	gasLimit, err := BlockMaxGasFromConsensusParams(context.Background(), clientCtx, &block.Height)
 	if err != nil {
 		return nil, err
 	}
 
-	transactions, gasUsed, err := EthTransactionsFromTendermint(clientCtx, block.Txs)
-	if err != nil {
+	// NOTE: the block is still returned if its EVM history has been pruned by the node, with an
+	// empty bloom and gas used
+	blockReceipts, err := GetBlockReceipts(clientCtx, block.Height)
+	if err != nil && !IsHistoryPruned(err) {
 		return nil, err
 	}
 
-	res, _, err := clientCtx.Query(fmt.Sprintf("custom/%s/%s/%d", evmtypes.ModuleName, evmtypes.QueryBloom, block.Height))
-	if err != nil {
-		return nil, err
+	transactions := make([]common.Hash, len(blockReceipts.Receipts))
+	for i, receipt := range blockReceipts.Receipts {
+		transactions[i] = receipt.TxHash()
+	}
+
+	// NOTE: receipts are not stored for the blocks committed before they were introduced or whose
+	// history has been pruned, so the transactions need to be decoded in that case
+	if len(transactions) == 0 && len(block.Txs) > 0 {
+		transactions, _, err = EthTransactionsFromTendermint(clientCtx, block.Txs)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	var bloomRes evmtypes.QueryBloomFilter
-	clientCtx.Codec.MustUnmarshalJSON(res, &bloomRes)
+	gasUsed := new(big.Int).SetUint64(blockReceipts.GasUsed)
+	bloom := blockReceipts.Bloom
 
-	bloom := bloomRes.Bloom
+	// NOTE: the miner is left empty if the state of the block height has been pruned
+	miner, _ := GetValidatorCoinbase(clientCtx, block.ProposerAddress, block.Height)
 
-	return FormatBlock(block.Header, block.Size(), block.Hash(), gasLimit, gasUsed, transactions, bloom), nil
+	return FormatBlock(block.Header, block.Size(), block.Hash(), miner, gasLimit, gasUsed, transactions, bloom), nil
 }
 
 // EthHeaderFromTendermint is an util function that returns an Ethereum Header
@@ -112,8 +125,9 @@ func EthHeaderFromTendermint(header tmtypes.Header) *ethtypes.Header {
 	}
 }
 
-// EthTransactionsFromTendermint returns a slice of ethereum transaction hashes and the total gas usage from a set of
-// tendermint block transactions.
+// EthTransactionsFromTendermint returns a slice of ethereum transaction hashes and the total gas limit from a set of
+// tendermint block transactions. The returned gas is only an upper bound of the gas usage, so it should only be used
+// for transactions that are not yet committed to a block (i.e pending).
 func EthTransactionsFromTendermint(clientCtx clientcontext.CLIContext, txs []tmtypes.Tx) ([]common.Hash, *big.Int, error) {
 	transactionHashes := []common.Hash{}
 	gasUsed := big.NewInt(0)
@@ -124,7 +138,6 @@ func EthTransactionsFromTendermint(clientCtx clientcontext.CLIContext, txs []tmt
 			// continue to next transaction in case it's not a MsgEthereumTx
 			continue
 		}
-		// TODO: Remove gas usage calculation if saving gasUsed per block
 		gasUsed.Add(gasUsed, big.NewInt(int64(ethTx.GetGas())))
 		transactionHashes = append(transactionHashes, common.BytesToHash(tx.Hash()))
 	}
@@ -132,9 +145,10 @@ func EthTransactionsFromTendermint(clientCtx clientcontext.CLIContext, txs []tmt
 	return transactionHashes, gasUsed, nil
 }
 
-// BlockMaxGasFromConsensusParams returns the gas limit for the latest block from the chain consensus params.
-func BlockMaxGasFromConsensusParams(_ context.Context, clientCtx clientcontext.CLIContext) (int64, error) {
-	resConsParams, err := clientCtx.Client.ConsensusParams(nil)
+// BlockMaxGasFromConsensusParams returns the gas limit for the given block height from the chain
+// consensus params. If the height is nil, the consensus params from the latest block are used.
+func BlockMaxGasFromConsensusParams(_ context.Context, clientCtx clientcontext.CLIContext, height *int64) (int64, error) {
+	resConsParams, err := clientCtx.Client.ConsensusParams(height)
 	if err != nil {
 		return 0, err
 	}
@@ -150,10 +164,101 @@ func BlockMaxGasFromConsensusParams(_ context.Context, clientCtx clientcontext.C
 	return gasLimit, nil
 }
 
+// IsHistoryPruned returns true if the error is returned by an EVM query on a block whose
+// history has been pruned from the latest state.
+func IsHistoryPruned(err error) bool {
+	return err != nil && strings.Contains(err.Error(), evmtypes.ErrHistoryPruned.Error())
+}
+
+// QueryBlockHistory queries the EVM block data (i.e bloom filter, receipts, gas used or state
+// diff) of the given height on the given path. If the block data has been pruned from the
+// latest state, it's queried on the state at the block height, which is only kept by the
+// archive nodes. Otherwise the pruned history error is returned.
+func QueryBlockHistory(clientCtx clientcontext.CLIContext, path string, height int64) ([]byte, error) {
+	res, _, err := clientCtx.Query(path)
+	if !IsHistoryPruned(err) {
+		return res, err
+	}
+
+	res, _, archiveErr := clientCtx.WithHeight(height).Query(path)
+	if archiveErr != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// HistoryClientContext returns the client context that queries the EVM block data of the
+// given height, including the transaction logs. If the block data has been pruned from the
+// latest state, the context queries the state at the block height (see QueryBlockHistory).
+func HistoryClientContext(clientCtx clientcontext.CLIContext, height int64) (clientcontext.CLIContext, error) {
+	path := fmt.Sprintf("custom/%s/%s/%d", evmtypes.ModuleName, evmtypes.QueryBlockGasUsed, height)
+	_, _, err := clientCtx.Query(path)
+	if !IsHistoryPruned(err) {
+		return clientCtx, nil
+	}
+
+	archiveCtx := clientCtx.WithHeight(height)
+	if _, _, archiveErr := archiveCtx.Query(path); archiveErr != nil {
+		return clientCtx, err
+	}
+
+	return archiveCtx, nil
+}
+
+// GetBlockReceipts returns the EVM transaction receipts, bloom filter and gas used of the
+// block at the given height.
+func GetBlockReceipts(clientCtx clientcontext.CLIContext, height int64) (evmtypes.QueryResBlockReceipts, error) {
+	res, err := QueryBlockHistory(clientCtx, fmt.Sprintf("custom/%s/%s/%d", evmtypes.ModuleName, evmtypes.QueryBlockReceipts, height), height)
+	if err != nil {
+		return evmtypes.QueryResBlockReceipts{}, err
+	}
+
+	var out evmtypes.QueryResBlockReceipts
+	if err := clientCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+		return evmtypes.QueryResBlockReceipts{}, err
+	}
+
+	return out, nil
+}
+
+// GetChainID returns the EIP-155 chain ID from the evm module parameters. If the ChainID
+// parameter is not set, it's parsed from the epoch of the client chain-id.
+func GetChainID(clientCtx clientcontext.CLIContext) (*big.Int, error) {
+	res, _, err := clientCtx.Query(fmt.Sprintf("custom/%s/%s", evmtypes.ModuleName, evmtypes.QueryParams))
+	if err != nil {
+		return nil, err
+	}
+
+	var params evmtypes.Params
+	if err := clientCtx.Codec.UnmarshalJSON(res, &params); err != nil {
+		return nil, err
+	}
+
+	return params.EIP155ChainID(clientCtx.ChainID)
+}
+
+// GetValidatorCoinbase returns the coinbase (i.e operator address) as an Ethereum address of
+// the validator with the given consensus address on the state of the given height. A height
+// of 0 queries the latest state.
+func GetValidatorCoinbase(clientCtx clientcontext.CLIContext, consAddress tmbytes.HexBytes, height int64) (common.Address, error) {
+	res, _, err := clientCtx.WithHeight(height).Query(fmt.Sprintf("custom/%s/%s/%s", evmtypes.ModuleName, evmtypes.QueryCoinbase, consAddress.String()))
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	var out evmtypes.QueryResCoinbase
+	if err := clientCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+		return common.Address{}, err
+	}
+
+	return common.HexToAddress(out.Coinbase), nil
+}
+
 // FormatBlock creates an ethereum block from a tendermint header and ethereum-formatted
-// transactions.
+// transactions. The miner is the operator address of the block proposer validator.
 func FormatBlock(
-	header tmtypes.Header, size int, curBlockHash tmbytes.HexBytes, gasLimit int64,
+	header tmtypes.Header, size int, curBlockHash tmbytes.HexBytes, miner common.Address, gasLimit int64,
 	gasUsed *big.Int, transactions interface{}, bloom ethtypes.Bloom,
 ) map[string]interface{} {
 	if len(header.DataHash) == 0 {
@@ -169,13 +274,13 @@ func FormatBlock(
 		"logsBloom":        bloom,
 		"transactionsRoot": hexutil.Bytes(header.DataHash),
 		"stateRoot":        hexutil.Bytes(header.AppHash),
-		"miner":            common.Address{},
+		"miner":            miner,
 		"mixHash":          common.Hash{},
 		"difficulty":       0,
 		"totalDifficulty":  0,
 		"extraData":        hexutil.Uint64(0),
 		"size":             hexutil.Uint64(size),
-		"gasLimit":         hexutil.Uint64(gasLimit), // Static gas limit
+		"gasLimit":         hexutil.Uint64(gasLimit),
 		"gasUsed":          (*hexutil.Big)(gasUsed),
 		"timestamp":        hexutil.Uint64(header.Time.Unix()),
 		"transactions":     transactions.([]common.Hash),
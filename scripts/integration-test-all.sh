@@ -11,6 +11,9 @@ TEST_QTD=1
 
 #PORT AND RPC_PORT 3 initial digits, to be concat with a suffix later when node is initialized
 RPC_PORT="854"
+# light client mode JSON-RPC and websocket port prefixes
+LIGHT_RPC_PORT="855"
+LIGHT_WS_PORT="856"
 IP_ADDR="0.0.0.0"
 
 KEY="mykey"
@@ -132,6 +135,20 @@ start_cli_func() {
     arrcli+=("$ETHERMINT_CLI_PID")
 }
 
+start_light_cli_func() {
+    echo "starting ethermint light client $i in background ..."
+    cp -r "$DATA_CLI_DIR$i" "$DATA_CLI_DIR"light"$i"
+    "$PWD"/build/ethermintcli rest-server --chain-id $CHAINID --trace --rpc-api="web3,eth,net" --light --trust-node=false \
+    --laddr "tcp://localhost:$LIGHT_RPC_PORT$i" --wsport "$LIGHT_WS_PORT$i" --node tcp://$IP_ADDR:$NODE_RPC_PORT"$i" \
+    --home "$DATA_CLI_DIR"light"$i" --read-timeout 30 --write-timeout 30 \
+    >"$DATA_CLI_DIR"/light"$i".log 2>&1 & disown
+
+    ETHERMINT_LIGHT_CLI_PID=$!
+    echo "started ethermintcli light client, pid=$ETHERMINT_LIGHT_CLI_PID"
+    # add PID to array
+    arrcli+=("$ETHERMINT_LIGHT_CLI_PID")
+}
+
 # Run node with static blockchain database
 # For loop N times
 for i in $(seq 1 "$QTD"); do
@@ -139,6 +156,7 @@ for i in $(seq 1 "$QTD"); do
     start_func "$i"
     sleep 1
     start_cli_func "$i"
+    start_light_cli_func "$i"
     echo "sleeping $SLEEP_TIMEOUT seconds for startup"
     sleep "$SLEEP_TIMEOUT"
     echo "done sleeping"
@@ -154,12 +172,13 @@ if [[ -z $TEST || $TEST == "rpc" || $TEST == "pending" ]]; then
 
     for i in $(seq 1 "$TEST_QTD"); do
         HOST_RPC=http://$IP_ADDR:$RPC_PORT"$i"
+        HOST_LIGHT_RPC=http://$IP_ADDR:$LIGHT_RPC_PORT"$i"
         echo "going to test ethermint node $HOST_RPC ..."
         if [[ $MODE == "pending" ]]; then
             sleep 150
             MODE=$MODE HOST=$HOST_RPC go test -v ./tests/tests-pending/rpc_pending_test.go
         else
-            MODE=$MODE HOST=$HOST_RPC go test ./tests/... -timeout=300s -v -short
+            MODE=$MODE HOST=$HOST_RPC LIGHT_HOST=$HOST_LIGHT_RPC go test ./tests/... -timeout=300s -v -short
         fi
         
         RPC_FAIL=$?
@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	rpctypes "github.com/cosmos/ethermint/rpc/types"
+	ethermint "github.com/cosmos/ethermint/types"
+)
+
+// lightHost is the JSON-RPC server of a rest-server running in light mode (--light), set with the
+// LIGHT_HOST environment variable.
+var lightHost = os.Getenv("LIGHT_HOST")
+
+func callLight(t *testing.T, method string, params interface{}) *Response {
+	rpcRes, err := CallHostWithError(lightHost, method, params)
+	require.NoError(t, err)
+	return rpcRes
+}
+
+func lightBlockNumber(t *testing.T) hexutil.Uint64 {
+	var height hexutil.Uint64
+	require.NoError(t, json.Unmarshal(callLight(t, "eth_blockNumber", []string{}).Result, &height))
+	return height
+}
+
+func TestLight_GetBalance(t *testing.T) {
+	if lightHost == "" {
+		t.Skip("LIGHT_HOST isn't set")
+	}
+
+	// the latest state is the one of the parent of the latest block, whose proofs are verified
+	// against the app hash of the latest header
+	for _, blockNum := range []string{"latest", "pending"} {
+		var res hexutil.Big
+		require.NoError(t, res.UnmarshalJSON(callLight(t, "eth_getBalance", []string{addrA, blockNum}).Result))
+	}
+
+	// the state of the latest block can't be verified until the next block is committed
+	height := lightBlockNumber(t)
+	if _, err := CallHostWithError(lightHost, "eth_getBalance", []interface{}{addrA, height}); err == nil {
+		require.Greater(t, uint64(lightBlockNumber(t)), uint64(height))
+	}
+
+	_, err := CallHostWithError(lightHost, "eth_getBalance", []interface{}{addrA, height + 10})
+	require.Error(t, err)
+}
+
+func TestLight_GetProof(t *testing.T) {
+	if lightHost == "" {
+		t.Skip("LIGHT_HOST isn't set")
+	}
+
+	params := []interface{}{addrA, []string{fmt.Sprint(addrAStoreKey)}, "latest"}
+
+	// the latest state is proven against the app hash of the latest block, which may change
+	// between the requests
+	from := lightBlockNumber(t)
+
+	var accRes rpctypes.AccountResult
+	require.NoError(t, json.Unmarshal(callLight(t, "eth_getProof", params).Result, &accRes))
+
+	to := lightBlockNumber(t)
+
+	for height := from; height <= to; height++ {
+		var block struct {
+			StateRoot hexutil.Bytes `json:"stateRoot"`
+		}
+		require.NoError(t, json.Unmarshal(callLight(t, "eth_getBlockByNumber", []interface{}{height, false}).Result, &block))
+
+		if accRes.VerifyProof(block.StateRoot, ethermint.AttoPhoton) == nil {
+			return
+		}
+	}
+
+	t.Fatalf("the proof doesn't verify against the blocks %d to %d", from, to)
+}
@@ -3,7 +3,8 @@
 // To run these tests please first ensure you have the ethermintd running
 // and have started the RPC service with `ethermintcli rest-server`.
 //
-// You can configure the desired HOST and MODE as well
+// You can configure the desired HOST and MODE as well, and the LIGHT_HOST of a
+// `ethermintcli rest-server --light` to run the light client tests.
 package tests
 
 import (
@@ -203,6 +204,33 @@ func TestEth_GetBalance(t *testing.T) {
 	}
 }
 
+func TestEth_GetBalance_BlockNumberOrHash(t *testing.T) {
+	rpcRes := Call(t, "eth_getBlockByNumber", []interface{}{"latest", false})
+
+	block := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(rpcRes.Result, &block))
+
+	// EIP-1898 block hash and block number objects
+	params := []interface{}{
+		map[string]interface{}{"blockHash": block["hash"], "requireCanonical": true},
+		map[string]interface{}{"blockNumber": block["number"]},
+		block["hash"],
+	}
+
+	for _, param := range params {
+		rpcRes = Call(t, "eth_getBalance", []interface{}{addrA, param})
+
+		var res hexutil.Big
+		require.NoError(t, res.UnmarshalJSON(rpcRes.Result))
+	}
+
+	_, err := CallWithError("eth_getBalance", []interface{}{addrA, map[string]interface{}{"blockHash": ethcmn.Hash{}.Hex()}})
+	require.Error(t, err)
+
+	_, err = CallWithError("eth_getBalance", []interface{}{addrA, map[string]interface{}{}})
+	require.Error(t, err)
+}
+
 func TestEth_GetStorageAt(t *testing.T) {
 	expectedRes := hexutil.Bytes{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
 	rpcRes := Call(t, "eth_getStorageAt", []string{addrA, fmt.Sprint(addrAStoreKey), zeroString})
@@ -231,6 +259,23 @@ func TestEth_GetProof(t *testing.T) {
 	require.NotEmpty(t, accRes.StorageProof)
 
 	t.Logf("Got AccountResult %s", rpcRes.Result)
+
+	// the proofs of the state at a height are verified against the app hash of the next block
+	var height hexutil.Uint64
+	require.NoError(t, json.Unmarshal(Call(t, "eth_blockNumber", []string{}).Result, &height))
+	require.NotZero(t, height)
+
+	params[2] = hexutil.Uint64(height - 1)
+	rpcRes = Call(t, "eth_getProof", params)
+	require.NoError(t, json.Unmarshal(rpcRes.Result, &accRes))
+
+	var block struct {
+		StateRoot hexutil.Bytes `json:"stateRoot"`
+	}
+	rpcRes = Call(t, "eth_getBlockByNumber", []interface{}{height, false})
+	require.NoError(t, json.Unmarshal(rpcRes.Result, &block))
+
+	require.NoError(t, accRes.VerifyProof(block.StateRoot, ethermint.AttoPhoton))
 }
 
 func TestEth_GetCode(t *testing.T) {
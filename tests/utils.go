@@ -90,6 +90,15 @@ func Call(t *testing.T, method string, params interface{}) *Response {
 }
 
 func CallWithError(method string, params interface{}) (*Response, error) {
+	if HOST == "" {
+		HOST = "http://localhost:8545"
+	}
+
+	return CallHostWithError(HOST, method, params)
+}
+
+// CallHostWithError calls the given method on the JSON-RPC server of the given host
+func CallHostWithError(host, method string, params interface{}) (*Response, error) {
 	req, err := json.Marshal(CreateRequest(method, params))
 	if err != nil {
 		return nil, err
@@ -99,10 +108,7 @@ func CallWithError(method string, params interface{}) (*Response, error) {
 	time.Sleep(1 * time.Second)
 	/* #nosec */
 
-	if HOST == "" {
-		HOST = "http://localhost:8545"
-	}
-	res, err := http.Post(HOST, "application/json", bytes.NewBuffer(req)) //nolint:gosec
+	res, err := http.Post(host, "application/json", bytes.NewBuffer(req)) //nolint:gosec
 	if err != nil {
 		return nil, err
 	}
@@ -13,6 +13,11 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth/exported"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 
+	"github.com/cosmos/ethermint/crypto/ethsecp256k1"
+
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/bech32"
+
 	ethcmn "github.com/ethereum/go-ethereum/common"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 )
@@ -102,7 +107,7 @@ func (acc EthAccount) MarshalYAML() (interface{}, error) {
 	var err error
 
 	if acc.PubKey != nil {
-		alias.PubKey, err = sdk.Bech32ifyPubKey(sdk.Bech32PubKeyTypeAccPub, acc.PubKey)
+		alias.PubKey, err = bech32ifyAccPub(acc.PubKey)
 		if err != nil {
 			return nil, err
 		}
@@ -136,7 +141,7 @@ func (acc EthAccount) MarshalJSON() ([]byte, error) {
 	var err error
 
 	if acc.PubKey != nil {
-		alias.PubKey, err = sdk.Bech32ifyPubKey(sdk.Bech32PubKeyTypeAccPub, acc.PubKey)
+		alias.PubKey, err = bech32ifyAccPub(acc.PubKey)
 		if err != nil {
 			return nil, err
 		}
@@ -209,3 +214,10 @@ func (acc EthAccount) String() string {
 	out, _ := yaml.Marshal(acc)
 	return string(out)
 }
+
+// bech32ifyAccPub returns the Bech32 account public key of the given public key. It's encoded
+// with ethsecp256k1.PubKeyBytes, so that the multisig threshold public keys composed of
+// ethsecp256k1 keys are supported.
+func bech32ifyAccPub(pubKey tmcrypto.PubKey) (string, error) {
+	return bech32.ConvertAndEncode(sdk.GetConfig().GetBech32AccountPubPrefix(), ethsecp256k1.PubKeyBytes(pubKey))
+}
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// IsHexAddress returns true if the string is formatted as a 0x prefixed Ethereum hex
+// address. It doesn't validate the EIP-55 checksum.
+func IsHexAddress(address string) bool {
+	return strings.HasPrefix(address, "0x") && ethcmn.IsHexAddress(address)
+}
+
+// ParseHexAddress parses a 0x prefixed Ethereum hex address. The EIP-55 checksum is
+// validated if the address contains both upper and lower case letters.
+func ParseHexAddress(address string) (ethcmn.Address, error) {
+	if !IsHexAddress(address) {
+		return ethcmn.Address{}, sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid hex address %s", address)
+	}
+
+	addr := ethcmn.HexToAddress(address)
+
+	hex := address[2:]
+	if hex != strings.ToLower(hex) && hex != strings.ToUpper(hex) && addr.Hex() != address {
+		return ethcmn.Address{}, sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid EIP-55 checksum for address %s", address)
+	}
+
+	return addr, nil
+}
+
+// ParseAccAddress parses an account address from either its Bech32 or its 0x prefixed
+// Ethereum hex (EIP-55) format.
+func ParseAccAddress(address string) (sdk.AccAddress, error) {
+	if strings.TrimSpace(address) == "" {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "empty address")
+	}
+
+	if strings.HasPrefix(address, "0x") {
+		addr, err := ParseHexAddress(address)
+		if err != nil {
+			return nil, err
+		}
+
+		return sdk.AccAddress(addr.Bytes()), nil
+	}
+
+	addr, err := sdk.AccAddressFromBech32(address)
+	if err != nil {
+		return nil, fmt.Errorf("expected a Bech32 or 0x prefixed hex address: %w", err)
+	}
+
+	return addr, nil
+}
+
+// ConvertHexAddress converts a 0x prefixed Ethereum hex address to the Bech32 account
+// address format. Any other value is returned unchanged.
+func ConvertHexAddress(value string) (string, error) {
+	if !IsHexAddress(value) {
+		return value, nil
+	}
+
+	addr, err := ParseAccAddress(value)
+	if err != nil {
+		return "", err
+	}
+
+	return addr.String(), nil
+}
@@ -0,0 +1,61 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+func TestParseAccAddress(t *testing.T) {
+	addr := ethcmn.HexToAddress("0x7cB61D4117AE31a12E393a1Cfa3BaC666481D02E")
+	accAddr := sdk.AccAddress(addr.Bytes())
+
+	testCases := []struct {
+		name    string
+		address string
+		expErr  bool
+	}{
+		{"EIP-55 checksum", "0x7cB61D4117AE31a12E393a1Cfa3BaC666481D02E", false},
+		{"lower case", "0x7cb61d4117ae31a12e393a1cfa3bac666481d02e", false},
+		{"upper case", "0x7CB61D4117AE31A12E393A1CFA3BAC666481D02E", false},
+		{"Bech32", accAddr.String(), false},
+		{"invalid EIP-55 checksum", "0x7cB61D4117AE31a12E393a1Cfa3BaC666481D02e", true},
+		{"hex without 0x", "7cB61D4117AE31a12E393a1Cfa3BaC666481D02E", true},
+		{"invalid hex length", "0x7cB61D4117AE31a12E393a1Cfa3BaC666481D0", true},
+		{"invalid Bech32", "eth1invalid", true},
+		{"empty string", "", true},
+	}
+
+	for _, tc := range testCases {
+		parsed, err := ParseAccAddress(tc.address)
+		if tc.expErr {
+			require.Error(t, err, tc.name)
+			continue
+		}
+
+		require.NoError(t, err, tc.name)
+		require.Equal(t, accAddr, parsed, tc.name)
+	}
+}
+
+func TestConvertHexAddress(t *testing.T) {
+	addr := sdk.AccAddress(ethcmn.HexToAddress("0x7cB61D4117AE31a12E393a1Cfa3BaC666481D02E").Bytes())
+
+	converted, err := ConvertHexAddress("0x7cB61D4117AE31a12E393a1Cfa3BaC666481D02E")
+	require.NoError(t, err)
+	require.Equal(t, addr.String(), converted)
+
+	// other values are returned unchanged
+	for _, value := range []string{"mykey", addr.String(), "1000aphoton", ethcmn.Hash{}.Hex()} {
+		converted, err = ConvertHexAddress(value)
+		require.NoError(t, err)
+		require.Equal(t, value, converted)
+	}
+
+	_, err = ConvertHexAddress("0x7cB61D4117AE31a12E393a1Cfa3BaC666481D02e")
+	require.Error(t, err)
+}
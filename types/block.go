@@ -0,0 +1,19 @@
+package types
+
+import (
+	"math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BlockGasLimit returns the max gas (limit) defined on the Tendermint consensus params
+// of the given context. If the block gas limit is not set (i.e -1 or the consensus params
+// are nil), it returns math.MaxInt64 as there's no upper bound for the block gas.
+func BlockGasLimit(ctx sdk.Context) uint64 {
+	cp := ctx.ConsensusParams()
+	if cp == nil || cp.Block == nil || cp.Block.MaxGas <= 0 {
+		return math.MaxInt64
+	}
+
+	return uint64(cp.Block.MaxGas)
+}
@@ -0,0 +1,30 @@
+package types
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestBlockGasLimit(t *testing.T) {
+	testCases := []struct {
+		name     string
+		params   *abci.ConsensusParams
+		expLimit uint64
+	}{
+		{"nil consensus params", nil, math.MaxInt64},
+		{"nil block params", &abci.ConsensusParams{}, math.MaxInt64},
+		{"unlimited block gas", &abci.ConsensusParams{Block: &abci.BlockParams{MaxGas: -1}}, math.MaxInt64},
+		{"block gas limit", &abci.ConsensusParams{Block: &abci.BlockParams{MaxGas: 10000000}}, 10000000},
+	}
+
+	for _, tc := range testCases {
+		ctx := sdk.Context{}.WithConsensusParams(tc.params)
+		require.Equal(t, tc.expLimit, BlockGasLimit(ctx), tc.name)
+	}
+}
@@ -20,4 +20,8 @@ var (
 
 	// ErrVMExecution returns an error resulting from an error in EVM execution.
 	ErrVMExecution = sdkerrors.Register(RootCodespace, 4, "error while executing evm transaction")
+
+	// ErrTxQueued returns an error resulting from a transaction queued on the node until the
+	// gap between its nonce and the sender nonce is filled. It's not added to the mempool yet.
+	ErrTxQueued = sdkerrors.Register(RootCodespace, 5, "transaction queued")
 )
@@ -0,0 +1,349 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// abiValue is a decoded ABI argument, with its value formatted as a string
+type abiValue struct {
+	Name  string `json:"name" yaml:"name"`
+	Type  string `json:"type" yaml:"type"`
+	Value string `json:"value" yaml:"value"`
+}
+
+// callResult is the result of a read-only contract call, decoded with the method outputs
+type callResult struct {
+	Method  string     `json:"method" yaml:"method"`
+	GasUsed uint64     `json:"gas_used" yaml:"gas_used"`
+	Outputs []abiValue `json:"outputs" yaml:"outputs"`
+}
+
+// decodedLog is a transaction log decoded with the ABI of the contract. The topics and data of
+// the logs that don't match any ABI event are kept undecoded.
+type decodedLog struct {
+	Address string     `json:"address" yaml:"address"`
+	Index   uint       `json:"log_index" yaml:"log_index"`
+	Event   string     `json:"event,omitempty" yaml:"event,omitempty"`
+	Args    []abiValue `json:"args,omitempty" yaml:"args,omitempty"`
+	Topics  []string   `json:"topics,omitempty" yaml:"topics,omitempty"`
+	Data    string     `json:"data,omitempty" yaml:"data,omitempty"`
+}
+
+// readABI reads the JSON ABI of a contract from a file.
+func readABI(path string) (abi.ABI, error) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return abi.ABI{}, err
+	}
+
+	contractABI, err := abi.JSON(bytes.NewReader(bz))
+	if err != nil {
+		return abi.ABI{}, errors.Wrapf(err, "could not parse the ABI file %s", path)
+	}
+
+	return contractABI, nil
+}
+
+// abiMethod returns the method of the ABI with the given name or signature (eg:
+// transfer(address,uint256)), which is required for the overloaded methods.
+func abiMethod(contractABI abi.ABI, name string) (abi.Method, error) {
+	if method, ok := contractABI.Methods[name]; ok {
+		return method, nil
+	}
+
+	for _, method := range contractABI.Methods {
+		if method.Sig == name {
+			return method, nil
+		}
+	}
+
+	return abi.Method{}, fmt.Errorf("method %s not found on the ABI", name)
+}
+
+// packABIArgs parses the string arguments to the types of the ABI arguments and packs them.
+func packABIArgs(arguments abi.Arguments, args []string) ([]byte, error) {
+	if len(args) != len(arguments) {
+		return nil, fmt.Errorf("expected %d arguments, got %d", len(arguments), len(args))
+	}
+
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		value, err := parseABIValue(arguments[i].Type, arg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid argument %d (%s)", i, arguments[i].Type)
+		}
+
+		values[i] = value
+	}
+
+	return arguments.Pack(values...)
+}
+
+// parseABIValue parses a string to the Go type of an ABI type. The integers can be decimal or
+// 0x prefixed hex, the bytes are 0x prefixed hex, the addresses can be hex or Bech32 and the
+// arrays are JSON arrays of the element values.
+func parseABIValue(t abi.Type, arg string) (interface{}, error) {
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		return parseABIInt(t, arg)
+
+	case abi.BoolTy:
+		return strconv.ParseBool(arg)
+
+	case abi.StringTy:
+		return arg, nil
+
+	case abi.AddressTy:
+		addr, err := accountToHex(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		return common.HexToAddress(addr), nil
+
+	case abi.BytesTy:
+		return hexutil.Decode(arg)
+
+	case abi.FixedBytesTy:
+		bz, err := hexutil.Decode(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(bz) > t.Size {
+			return nil, fmt.Errorf("%d bytes exceed the size of %s", len(bz), t)
+		}
+
+		// the fixed size bytes are left aligned
+		value := reflect.New(t.GetType()).Elem()
+		reflect.Copy(value, reflect.ValueOf(bz))
+		return value.Interface(), nil
+
+	case abi.SliceTy, abi.ArrayTy:
+		var elems []json.RawMessage
+		if err := json.Unmarshal([]byte(arg), &elems); err != nil {
+			return nil, errors.Wrapf(err, "%s must be a JSON array", t)
+		}
+
+		if t.T == abi.ArrayTy && len(elems) != t.Size {
+			return nil, fmt.Errorf("expected %d elements for %s, got %d", t.Size, t, len(elems))
+		}
+
+		value := reflect.New(t.GetType()).Elem()
+		if t.T == abi.SliceTy {
+			value = reflect.MakeSlice(t.GetType(), len(elems), len(elems))
+		}
+
+		for i, elem := range elems {
+			// the elements can be either JSON strings or literals (eg: numbers, booleans or arrays)
+			elemArg := string(elem)
+			if err := json.Unmarshal(elem, &elemArg); err != nil {
+				elemArg = string(elem)
+			}
+
+			elemValue, err := parseABIValue(*t.Elem, elemArg)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid element %d", i)
+			}
+
+			value.Index(i).Set(reflect.ValueOf(elemValue))
+		}
+
+		return value.Interface(), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported argument type %s", t)
+	}
+}
+
+// parseABIInt parses a decimal or hex integer to the Go type of an ABI integer type, i.e the
+// sized Go integers up to 64 bits and *big.Int for the larger ones.
+func parseABIInt(t abi.Type, arg string) (interface{}, error) {
+	i, ok := new(big.Int).SetString(arg, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %s", arg)
+	}
+
+	switch {
+	case t.T == abi.UintTy && i.Sign() < 0:
+		return nil, fmt.Errorf("negative value %s for %s", arg, t)
+
+	case t.T == abi.UintTy && i.BitLen() > t.Size:
+		return nil, fmt.Errorf("value %s overflows %s", arg, t)
+
+	// the signed integers range from -2^(size-1) to 2^(size-1)-1, where -x-1 (i.e NOT x) of a
+	// negative value x has the bit length of its magnitude
+	case t.T == abi.IntTy && i.Sign() >= 0 && i.BitLen() > t.Size-1,
+		t.T == abi.IntTy && i.Sign() < 0 && new(big.Int).Not(i).BitLen() > t.Size-1:
+		return nil, fmt.Errorf("value %s overflows %s", arg, t)
+	}
+
+	goType := t.GetType()
+	switch goType.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value := reflect.New(goType).Elem()
+		value.SetUint(i.Uint64())
+		return value.Interface(), nil
+
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value := reflect.New(goType).Elem()
+		value.SetInt(i.Int64())
+		return value.Interface(), nil
+
+	default:
+		return i, nil
+	}
+}
+
+// unpackABIValues decodes the return data of a method call with its output arguments.
+func unpackABIValues(arguments abi.Arguments, data []byte) ([]abiValue, error) {
+	values, err := arguments.UnpackValues(data)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := make([]abiValue, len(values))
+	for i, value := range values {
+		decoded[i] = abiValue{Name: arguments[i].Name, Type: arguments[i].Type.String(), Value: formatABIValue(arguments[i].Type, value)}
+	}
+
+	return decoded, nil
+}
+
+// decodeLog decodes the indexed arguments of a log from its topics and the other arguments from
+// its data, with the ABI event whose ID matches the first topic. The anonymous events can't be
+// identified, so their logs are kept undecoded. The indexed strings, bytes and arrays are
+// decoded as the hash stored on the topic.
+func decodeLog(contractABI abi.ABI, log *ethtypes.Log) decodedLog {
+	decoded := decodedLog{Address: log.Address.Hex(), Index: log.Index}
+
+	args, event, err := decodeLogArgs(contractABI, log)
+	if err != nil {
+		decoded.Topics = make([]string, len(log.Topics))
+		for i, topic := range log.Topics {
+			decoded.Topics[i] = topic.Hex()
+		}
+
+		decoded.Data = hexutil.Encode(log.Data)
+		return decoded
+	}
+
+	decoded.Event = event.Sig
+	decoded.Args = args
+	return decoded
+}
+
+func decodeLogArgs(contractABI abi.ABI, log *ethtypes.Log) ([]abiValue, *abi.Event, error) {
+	if len(log.Topics) == 0 {
+		return nil, nil, errors.New("log without topics")
+	}
+
+	event, err := contractABI.EventByID(log.Topics[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if event.Anonymous {
+		return nil, nil, fmt.Errorf("anonymous event %s", event.Sig)
+	}
+
+	values, err := event.Inputs.NonIndexed().UnpackValues(log.Data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	topics := log.Topics[1:]
+	args := make([]abiValue, len(event.Inputs))
+
+	for i, input := range event.Inputs {
+		var value interface{}
+
+		if input.Indexed {
+			if len(topics) == 0 {
+				return nil, nil, fmt.Errorf("missing topic of the indexed argument %d", i)
+			}
+
+			topic := map[string]interface{}{}
+			if err := abi.ParseTopicsIntoMap(topic, abi.Arguments{input}, topics[:1]); err != nil {
+				return nil, nil, err
+			}
+
+			value = topic[input.Name]
+			topics = topics[1:]
+		} else {
+			value = values[0]
+			values = values[1:]
+		}
+
+		args[i] = abiValue{Name: input.Name, Type: input.Type.String(), Value: formatABIValue(input.Type, value)}
+	}
+
+	return args, event, nil
+}
+
+// formatABIValue formats a decoded value of an ABI type. The integers are decimal, the
+// addresses are EIP-55 hex, the bytes are 0x prefixed hex and the arrays are JSON arrays.
+func formatABIValue(t abi.Type, value interface{}) string {
+	switch v := value.(type) {
+	case *big.Int:
+		return v.String()
+	case common.Address:
+		return v.Hex()
+	case common.Hash:
+		// hash of an indexed dynamic type
+		return v.Hex()
+	}
+
+	rv := reflect.ValueOf(value)
+
+	switch t.T {
+	case abi.BytesTy:
+		return hexutil.Encode(rv.Bytes())
+
+	case abi.FixedBytesTy, abi.FunctionTy:
+		bz := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(bz), rv)
+		return hexutil.Encode(bz)
+
+	case abi.SliceTy, abi.ArrayTy:
+		elems := make([]string, rv.Len())
+		for i := range elems {
+			elems[i] = formatABIValue(*t.Elem, rv.Index(i).Interface())
+		}
+
+		bz, _ := json.Marshal(elems)
+		return string(bz)
+
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// parseHexData decodes the 0x prefixed (optional) hex data of a flag, or of the file with the
+// given path.
+func parseHexData(arg string) ([]byte, error) {
+	if bz, err := ioutil.ReadFile(arg); err == nil {
+		arg = string(bz)
+	}
+
+	arg = strings.TrimSpace(arg)
+	if !strings.HasPrefix(arg, "0x") {
+		arg = "0x" + arg
+	}
+
+	return hexutil.Decode(arg)
+}
@@ -0,0 +1,215 @@
+package cli
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+const testABI = `[
+	{"type": "constructor", "inputs": [{"name": "supply", "type": "uint256"}]},
+	{"type": "function", "name": "transfer", "inputs": [{"name": "to", "type": "address"}, {"name": "amount", "type": "uint256"}], "outputs": [{"name": "", "type": "bool"}]},
+	{"type": "function", "name": "transfer", "inputs": [{"name": "to", "type": "address"}], "outputs": []},
+	{"type": "function", "name": "setValues", "inputs": [{"name": "ids", "type": "uint8[]"}, {"name": "pair", "type": "int64[2]"}, {"name": "tag", "type": "bytes4"}, {"name": "flag", "type": "bool"}, {"name": "name", "type": "string"}, {"name": "data", "type": "bytes"}], "outputs": []}
+]`
+
+func TestABIMethod(t *testing.T) {
+	contractABI, err := abi.JSON(strings.NewReader(testABI))
+	require.NoError(t, err)
+
+	method, err := abiMethod(contractABI, "transfer")
+	require.NoError(t, err)
+	require.Equal(t, "transfer(address,uint256)", method.Sig)
+
+	// overloaded method
+	method, err = abiMethod(contractABI, "transfer(address)")
+	require.NoError(t, err)
+	require.Len(t, method.Inputs, 1)
+
+	_, err = abiMethod(contractABI, "approve")
+	require.Error(t, err)
+}
+
+func TestPackABIArgs(t *testing.T) {
+	contractABI, err := abi.JSON(strings.NewReader(testABI))
+	require.NoError(t, err)
+
+	to := common.HexToAddress("0x3B98c72760f7BBa69D62ED6f48278451251948e7")
+
+	// the bech32 and hex addresses are packed the same way
+	expected, err := contractABI.Methods["transfer"].Inputs.Pack(to, big.NewInt(100))
+	require.NoError(t, err)
+
+	for _, args := range [][]string{
+		{to.Hex(), "100"},
+		{to.Hex(), "0x64"},
+		{"cosmos18wvvwfmq77a6d8tza4h5sfuy2yj3jj88yqg82a", "100"},
+	} {
+		packed, err := packABIArgs(contractABI.Methods["transfer"].Inputs, args)
+		require.NoError(t, err, args)
+		require.Equal(t, expected, packed, args)
+	}
+
+	expected, err = contractABI.Methods["setValues"].Inputs.Pack(
+		[]uint8{1, 2}, [2]int64{-1, 1}, [4]byte{0xde, 0xad}, true, "name", []byte{0x01},
+	)
+	require.NoError(t, err)
+
+	packed, err := packABIArgs(
+		contractABI.Methods["setValues"].Inputs,
+		[]string{"[1,2]", `["-1", "0x1"]`, "0xdead", "true", "name", "0x01"},
+	)
+	require.NoError(t, err)
+	require.Equal(t, expected, packed)
+
+	testCases := []struct {
+		name string
+		args []string
+	}{
+		{"invalid number of arguments", []string{to.Hex()}},
+		{"invalid address", []string{"0x01", "100"}},
+		{"invalid integer", []string{to.Hex(), "1e18"}},
+		{"negative unsigned integer", []string{to.Hex(), "-1"}},
+		{"unsigned integer overflow", []string{to.Hex(), new(big.Int).Lsh(big.NewInt(1), 256).String()}},
+	}
+
+	for _, tc := range testCases {
+		_, err := packABIArgs(contractABI.Methods["transfer"].Inputs, tc.args)
+		require.Error(t, err, tc.name)
+	}
+
+	for _, args := range [][]string{
+		{"[256]", "[1,1]", "0x01", "true", "", "0x"},
+		{"[1]", "[1]", "0x01", "true", "", "0x"},
+		{"[1]", "[1,1]", "0x0102030405", "true", "", "0x"},
+		{"[1]", "[1,1]", "0x01", "yes", "", "0x"},
+		{"[1]", "[1,1]", "0x01", "true", "", "01"},
+		{"1", "[1,1]", "0x01", "true", "", "0x"},
+	} {
+		_, err := packABIArgs(contractABI.Methods["setValues"].Inputs, args)
+		require.Error(t, err, args)
+	}
+}
+
+func TestParseABIInt(t *testing.T) {
+	int8Type, err := abi.NewType("int8", "", nil)
+	require.NoError(t, err)
+
+	for _, arg := range []string{"127", "-128", "0", "-1"} {
+		_, err := parseABIInt(int8Type, arg)
+		require.NoError(t, err, arg)
+	}
+
+	for _, arg := range []string{"128", "-129"} {
+		_, err := parseABIInt(int8Type, arg)
+		require.Error(t, err, arg)
+	}
+
+	int256Type, err := abi.NewType("int256", "", nil)
+	require.NoError(t, err)
+
+	value, err := parseABIInt(int256Type, "-1")
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(-1), value)
+}
+
+func TestParseHexData(t *testing.T) {
+	for _, arg := range []string{"0x6000", "6000", " 0x6000\n"} {
+		bz, err := parseHexData(arg)
+		require.NoError(t, err, arg)
+		require.Equal(t, []byte{0x60, 0x00}, bz, arg)
+	}
+
+	_, err := parseHexData("0x600")
+	require.Error(t, err)
+}
+
+func TestUnpackABIValues(t *testing.T) {
+	outputs, err := abi.JSON(strings.NewReader(`[{"type": "function", "name": "info", "inputs": [], "outputs": [
+		{"name": "owner", "type": "address"}, {"name": "balance", "type": "uint256"}, {"name": "ids", "type": "uint8[]"},
+		{"name": "tag", "type": "bytes4"}, {"name": "", "type": "bool"}
+	]}]`))
+	require.NoError(t, err)
+
+	owner := common.HexToAddress("0x3B98c72760f7BBa69D62ED6f48278451251948e7")
+	method := outputs.Methods["info"]
+
+	data, err := method.Outputs.Pack(owner, big.NewInt(100), []uint8{1, 2}, [4]byte{0xde, 0xad}, true)
+	require.NoError(t, err)
+
+	values, err := unpackABIValues(method.Outputs, data)
+	require.NoError(t, err)
+	require.Equal(t, []abiValue{
+		{Name: "owner", Type: "address", Value: owner.Hex()},
+		{Name: "balance", Type: "uint256", Value: "100"},
+		{Name: "ids", Type: "uint8[]", Value: `["1","2"]`},
+		{Name: "tag", Type: "bytes4", Value: "0xdead0000"},
+		{Name: "", Type: "bool", Value: "true"},
+	}, values)
+
+	_, err = unpackABIValues(method.Outputs, data[:32])
+	require.Error(t, err)
+}
+
+func TestDecodeLog(t *testing.T) {
+	contractABI, err := abi.JSON(strings.NewReader(`[
+		{"type": "event", "name": "Transfer", "inputs": [{"name": "from", "type": "address", "indexed": true}, {"name": "to", "type": "address", "indexed": true}, {"name": "value", "type": "uint256", "indexed": false}]},
+		{"type": "event", "name": "Named", "inputs": [{"name": "name", "type": "string", "indexed": true}, {"name": "data", "type": "bytes", "indexed": false}]}
+	]`))
+	require.NoError(t, err)
+
+	contract := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	from := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	to := common.HexToAddress("0x3000000000000000000000000000000000000003")
+
+	data, err := contractABI.Events["Transfer"].Inputs.NonIndexed().Pack(big.NewInt(5))
+	require.NoError(t, err)
+
+	log := &ethtypes.Log{
+		Address: contract,
+		Topics:  []common.Hash{contractABI.Events["Transfer"].ID, from.Hash(), to.Hash()},
+		Data:    data,
+		Index:   2,
+	}
+
+	require.Equal(t, decodedLog{
+		Address: contract.Hex(),
+		Index:   2,
+		Event:   "Transfer(address,address,uint256)",
+		Args: []abiValue{
+			{Name: "from", Type: "address", Value: from.Hex()},
+			{Name: "to", Type: "address", Value: to.Hex()},
+			{Name: "value", Type: "uint256", Value: "5"},
+		},
+	}, decodeLog(contractABI, log))
+
+	// the indexed strings are decoded as their hash
+	nameHash := common.BytesToHash([]byte("name hash"))
+	data, err = contractABI.Events["Named"].Inputs.NonIndexed().Pack([]byte{0x01})
+	require.NoError(t, err)
+
+	decoded := decodeLog(contractABI, &ethtypes.Log{Address: contract, Topics: []common.Hash{contractABI.Events["Named"].ID, nameHash}, Data: data})
+	require.Equal(t, []abiValue{
+		{Name: "name", Type: "string", Value: nameHash.Hex()},
+		{Name: "data", Type: "bytes", Value: "0x01"},
+	}, decoded.Args)
+
+	// unknown events and logs with missing topics are kept undecoded
+	for _, log := range []*ethtypes.Log{
+		{Address: contract, Topics: []common.Hash{common.BytesToHash([]byte("unknown"))}, Data: []byte{0x01}},
+		{Address: contract, Topics: []common.Hash{contractABI.Events["Transfer"].ID, from.Hash()}, Data: data},
+		{Address: contract, Data: []byte{0x01}},
+	} {
+		decoded := decodeLog(contractABI, log)
+		require.Empty(t, decoded.Event)
+		require.Len(t, decoded.Topics, len(log.Topics))
+		require.Equal(t, hexutil.Encode(log.Data), decoded.Data)
+	}
+}
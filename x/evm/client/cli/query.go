@@ -2,18 +2,34 @@ package cli
 
 import (
 	"fmt"
+	"math/big"
+	"strconv"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
 
+	"github.com/ethereum/go-ethereum/common"
+
+	ethermint "github.com/cosmos/ethermint/types"
 	"github.com/cosmos/ethermint/x/evm/types"
 )
 
+const (
+	flagCaller   = "caller"
+	flagTx       = "tx"
+	flagStartKey = "start-key"
+	flagLimit    = "limit"
+	flagAll      = "all"
+)
+
 // GetQueryCmd defines evm module queries through the cli
 func GetQueryCmd(moduleName string, cdc *codec.Codec) *cobra.Command {
 	evmQueryCmd := &cobra.Command{
@@ -25,7 +41,14 @@ func GetQueryCmd(moduleName string, cdc *codec.Codec) *cobra.Command {
 	}
 	evmQueryCmd.AddCommand(flags.GetCommands(
 		GetCmdGetStorageAt(moduleName, cdc),
+		GetCmdQueryStorageRange(cdc),
 		GetCmdGetCode(moduleName, cdc),
+		GetCmdFeeAllowance(moduleName, cdc),
+		GetCmdFeeAllowances(moduleName, cdc),
+		GetCmdQueryParams(moduleName, cdc),
+		GetCmdQueryCall(cdc),
+		GetCmdQueryLogs(moduleName, cdc),
+		GetCmdQueryStateDiff(cdc),
 	)...)
 	return evmQueryCmd
 }
@@ -59,6 +82,71 @@ func GetCmdGetStorageAt(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	}
 }
 
+// GetCmdQueryStorageRange queries a range of the storage of an account, or all of it
+func GetCmdQueryStorageRange(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "storage-range [account]",
+		Short: "Gets a range of the storage entries of an account",
+		Long: fmt.Sprintf(`Get the storage entries of an account, sorted by their store key (i.e the Keccak-256
+hash of the address and the slot, as the slot preimages are not persisted). The range starts
+at the --%s store key and contains at most --%s entries. The next_key of the response is
+the start key of the next range, and it's empty once the end of the storage is reached.
+
+With --%s, the ranges are queried until the end of the storage, at the same height.
+
+Example:
+$ %s query %s storage-range 0x... --limit 500
+$ %s query %s storage-range 0x... --all --height 1000
+`, flagStartKey, flagLimit, flagAll, version.ClientName, types.ModuleName, version.ClientName, types.ModuleName),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := context.NewCLIContext().WithCodec(cdc)
+
+			account, err := accountToHex(args[0])
+			if err != nil {
+				return errors.Wrap(err, "could not parse account address")
+			}
+
+			params := types.NewQueryStorageRangeParams(account, viper.GetString(flagStartKey), viper.GetUint64(flagLimit))
+
+			out := types.QueryResStorageRange{Storage: types.Storage{}}
+			for {
+				bz, err := cdc.MarshalJSON(params)
+				if err != nil {
+					return err
+				}
+
+				res, height, err := clientCtx.QueryWithData(
+					fmt.Sprintf("custom/%s/%s", types.ModuleName, types.QueryStorageRange), bz)
+				if err != nil {
+					return fmt.Errorf("could not resolve: %s", err)
+				}
+
+				var page types.QueryResStorageRange
+				cdc.MustUnmarshalJSON(res, &page)
+
+				out.Storage = append(out.Storage, page.Storage...)
+				out.NextKey = page.NextKey
+
+				if !viper.GetBool(flagAll) || page.NextKey == "" {
+					break
+				}
+
+				// query the following ranges at the height of the first one
+				clientCtx = clientCtx.WithHeight(height)
+				params.StartKey = page.NextKey
+			}
+
+			return clientCtx.PrintOutput(out)
+		},
+	}
+
+	cmd.Flags().String(flagStartKey, "", "Store key (32 bytes hex) of the first storage entry of the range")
+	cmd.Flags().Uint64(flagLimit, types.DefaultStorageRangeLimit, fmt.Sprintf("Maximum number of storage entries of the range (at most %d)", types.MaxStorageRangeLimit))
+	cmd.Flags().Bool(flagAll, false, "Query all the storage entries from the start key, in consecutive ranges")
+	return cmd
+}
+
 // GetCmdGetCode queries the code field of a given address
 func GetCmdGetCode(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
@@ -86,3 +174,252 @@ func GetCmdGetCode(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+// GetCmdFeeAllowance queries the fee allowance of a sender for a contract
+func GetCmdFeeAllowance(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "fee-allowance [sender] [contract]",
+		Short: "Gets the fees that the paymaster pays on behalf of a sender for the transactions to a contract",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := context.NewCLIContext().WithCodec(cdc)
+
+			sender, contract, err := parseAllowancePair(args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			res, _, err := clientCtx.Query(
+				fmt.Sprintf("custom/%s/%s/%s/%s", queryRoute, types.QueryFeeAllowance, sender.Hex(), contract.Hex()))
+
+			if err != nil {
+				return fmt.Errorf("could not resolve: %s", err)
+			}
+
+			var out types.FeeAllowance
+			cdc.MustUnmarshalJSON(res, &out)
+			return clientCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdFeeAllowances queries the paymaster and all the fee allowances
+func GetCmdFeeAllowances(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "fee-allowances",
+		Short: "Gets the paymaster and all the fee allowances",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := clientCtx.Query(
+				fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryFeeAllowances))
+
+			if err != nil {
+				return fmt.Errorf("could not resolve: %s", err)
+			}
+
+			var out types.QueryResFeeAllowances
+			cdc.MustUnmarshalJSON(res, &out)
+			return clientCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdQueryParams queries the evm module parameters, including the contract deployment
+// allowlists
+func GetCmdQueryParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "params",
+		Short: "Gets the evm module parameters",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := clientCtx.Query(
+				fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryParams))
+
+			if err != nil {
+				return fmt.Errorf("could not resolve: %s", err)
+			}
+
+			var out types.Params
+			cdc.MustUnmarshalJSON(res, &out)
+			return clientCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdQueryCall performs a read-only call of a contract method and decodes its return values
+func GetCmdQueryCall(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "call [contract]",
+		Short: "Call a contract method without a transaction and decode its return values",
+		Long: fmt.Sprintf(`Call a contract method through a simulation on the node, which doesn't change the state,
+and decode the return values with the --%s JSON file of the contract. The --%s arguments are
+packed with the ABI. The overloaded methods must be identified by their signature (eg:
+balanceOf(address)). The call is performed on the latest state of the node.
+
+The integer arguments can be decimal or 0x prefixed hex, the bytes are 0x prefixed hex and
+the arrays are JSON arrays. The arguments with commas must be quoted (eg: --%s '"[1,2]",0x01').
+
+Example:
+$ %s query %s call 0x... --abi Token.abi --method balanceOf --args 0x...
+`, flagABI, flagArgs, flagArgs, version.ClientName, types.ModuleName),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := context.NewCLIContext().WithCodec(cdc)
+
+			contract, err := accountToHex(args[0])
+			if err != nil {
+				return errors.Wrap(err, "could not parse contract address")
+			}
+
+			// the zero address is the default caller, like on eth_call
+			caller := sdk.AccAddress(common.Address{}.Bytes())
+			if callerArg := viper.GetString(flagCaller); callerArg != "" {
+				callerHex, err := accountToHex(callerArg)
+				if err != nil {
+					return errors.Wrap(err, "could not parse caller address")
+				}
+
+				caller = common.HexToAddress(callerHex).Bytes()
+			}
+
+			contractABI, err := readABI(viper.GetString(flagABI))
+			if err != nil {
+				return err
+			}
+
+			method, err := abiMethod(contractABI, viper.GetString(flagMethod))
+			if err != nil {
+				return err
+			}
+
+			methodArgs, err := packABIArgs(method.Inputs, viper.GetStringSlice(flagArgs))
+			if err != nil {
+				return errors.Wrapf(err, "could not pack the arguments of %s", method.Sig)
+			}
+
+			nonce, err := accountNonce(clientCtx, caller)
+			if err != nil {
+				return err
+			}
+
+			contractAddr := common.HexToAddress(contract)
+			data := append(append([]byte{}, method.ID...), methodArgs...)
+			msg := types.NewMsgEthereumTx(
+				nonce, &contractAddr, nil, ethermint.DefaultRPCGasLimit, new(big.Int).SetUint64(ethermint.DefaultGasPrice), data,
+			)
+
+			simRes, err := simulateEthereumTx(clientCtx, caller, msg)
+			if err != nil {
+				return err
+			}
+
+			resultData, err := types.DecodeResultData(simRes.Result.Data)
+			if err != nil {
+				return err
+			}
+
+			outputs, err := unpackABIValues(method.Outputs, resultData.Ret)
+			if err != nil {
+				return errors.Wrapf(err, "could not decode the return values of %s", method.Sig)
+			}
+
+			return clientCtx.PrintOutput(callResult{Method: method.Sig, GasUsed: simRes.GasUsed, Outputs: outputs})
+		},
+	}
+
+	cmd.Flags().String(flagABI, "", "Path of the JSON ABI of the contract")
+	cmd.Flags().String(flagMethod, "", "Name or signature of the contract method")
+	cmd.Flags().StringSlice(flagArgs, nil, "Comma separated arguments of the method")
+	cmd.Flags().String(flagCaller, "", "Address of the caller (msg.sender) of the method")
+	_ = cmd.MarkFlagRequired(flagABI)
+	_ = cmd.MarkFlagRequired(flagMethod)
+	return cmd
+}
+
+// GetCmdQueryLogs queries the logs of a transaction and decodes their events
+func GetCmdQueryLogs(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Gets the logs of an Ethereum transaction, decoded into named events",
+		Long: fmt.Sprintf(`Get the logs of the --%s Ethereum transaction and decode them into named events with the
+--%s JSON file of the contract. The indexed arguments are decoded from the log topics, except
+for the indexed strings, bytes and arrays whose hash is returned. The topics and data of the
+logs that don't match any event of the ABI are returned undecoded.
+
+Example:
+$ %s query %s logs --tx 0x... --abi Token.abi
+`, flagTx, flagABI, version.ClientName, types.ModuleName),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txHash := viper.GetString(flagTx)
+			if err := types.ValidateHash(txHash); err != nil {
+				return errors.Wrap(err, "invalid transaction hash")
+			}
+
+			contractABI, err := readABI(viper.GetString(flagABI))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := clientCtx.Query(
+				fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryTransactionLogs, txHash))
+
+			if err != nil {
+				return fmt.Errorf("could not resolve: %s", err)
+			}
+
+			var out types.QueryETHLogs
+			cdc.MustUnmarshalJSON(res, &out)
+
+			logs := make([]decodedLog, len(out.Logs))
+			for i, log := range out.Logs {
+				logs[i] = decodeLog(contractABI, log)
+			}
+
+			return clientCtx.PrintOutput(logs)
+		},
+	}
+
+	cmd.Flags().String(flagTx, "", "Hash of the Ethereum transaction")
+	cmd.Flags().String(flagABI, "", "Path of the JSON ABI of the contract")
+	_ = cmd.MarkFlagRequired(flagTx)
+	_ = cmd.MarkFlagRequired(flagABI)
+	return cmd
+}
+
+// GetCmdQueryStateDiff queries the accounts and storage slots changed on a block
+func GetCmdQueryStateDiff(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "state-diff [height]",
+		Short: "Gets the accounts and storage slots changed by the EVM transactions of a block",
+		Long: `Get the accounts changed by the EVM transactions of a block, with the storage slots written
+and their value at the end of the block. The storage slots are identified by their store key
+(i.e the Keccak-256 hash of the address and the slot), and a zero value denotes a deleted slot.
+The state diffs are pruned along with the other block data outside of the retention window.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := context.NewCLIContext().WithCodec(cdc)
+
+			height, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return errors.Wrap(err, "could not parse block height")
+			}
+
+			res, _, err := clientCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/%s/%d", types.ModuleName, types.QueryStateDiff, height), nil)
+			if err != nil {
+				return fmt.Errorf("could not resolve: %s", err)
+			}
+
+			var out types.QueryResStateDiff
+			cdc.MustUnmarshalJSON(res, &out)
+			return clientCtx.PrintOutput(out)
+		},
+	}
+}
@@ -0,0 +1,349 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authclient "github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/cosmos/ethermint/crypto/signer"
+	ethermint "github.com/cosmos/ethermint/types"
+	"github.com/cosmos/ethermint/x/evm/types"
+)
+
+const (
+	flagSigner        = "signer"
+	flagHDPath        = "hd-path"
+	flagEIP155ChainID = "eip155-chain-id"
+	flagBroadcast     = "broadcast"
+	flagAmount        = "amount"
+	flagBytecode      = "bytecode"
+	flagABI           = "abi"
+	flagMethod        = "method"
+	flagArgs          = "args"
+)
+
+// GetTxCmd defines the evm module transactions through the cli
+func GetTxCmd(cdc *codec.Codec) *cobra.Command {
+	evmTxCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "evm transaction subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	evmTxCmd.AddCommand(flags.PostCommands(
+		GetCmdGrantFeeAllowance(cdc),
+		GetCmdRevokeFeeAllowance(cdc),
+		GetCmdSignEthereumTx(cdc),
+		GetCmdSend(cdc),
+		GetCmdDeploy(cdc),
+		GetCmdCall(cdc),
+	)...)
+	return evmTxCmd
+}
+
+// GetCmdGrantFeeAllowance sets the fees that the paymaster pays on behalf of a sender for
+// the transactions that call a contract
+func GetCmdGrantFeeAllowance(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "grant-fee-allowance [sender] [contract] [spend-limit]",
+		Short: "Sponsor the fees of the transactions from a sender to a contract, up to the spend limit in the evm denom",
+		Long: `Sponsor the fees of the transactions from a sender to a contract, up to the spend limit
+in the evm denom. The transaction must be signed by the paymaster account defined on the evm
+module parameters. Granting an existing allowance overrides its remaining spend limit.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(authclient.GetTxEncoder(cdc))
+			clientCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			sender, contract, err := parseAllowancePair(args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			spendLimit, ok := sdk.NewIntFromString(args[2])
+			if !ok {
+				return fmt.Errorf("invalid spend limit %s", args[2])
+			}
+
+			msg := types.NewMsgGrantFeeAllowance(clientCtx.GetFromAddress(), sender, contract, spendLimit)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return authclient.GenerateOrBroadcastMsgs(clientCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdRevokeFeeAllowance removes the fee allowance of a sender for a contract
+func GetCmdRevokeFeeAllowance(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke-fee-allowance [sender] [contract]",
+		Short: "Stop sponsoring the fees of the transactions from a sender to a contract",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(authclient.GetTxEncoder(cdc))
+			clientCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			sender, contract, err := parseAllowancePair(args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRevokeFeeAllowance(clientCtx.GetFromAddress(), sender, contract)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return authclient.GenerateOrBroadcastMsgs(clientCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdSignEthereumTx signs an Ethereum transaction with either a keyring key or an
+// external signer device
+func GetCmdSignEthereumTx(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign-ethereum-tx [file]",
+		Short: "Sign an Ethereum transaction with a keyring key or an external signer",
+		Long: fmt.Sprintf(`Sign an unsigned MsgEthereumTx, read from a JSON file, with the EIP-155 chain ID. The
+transaction is signed by the --from keyring key or, if the --%s flag is set, by the key
+derived on the --%s of the external signer device (eg: a hardware wallet). The signed
+transaction is printed, unless the --%s flag is set.
+
+The EIP-155 chain ID is queried from the node, unless it's set with the --%s flag.`,
+			flagSigner, flagHDPath, flagBroadcast, flagEIP155ChainID),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			clientCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			bz, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			var msg types.MsgEthereumTx
+			if err := cdc.UnmarshalJSON(bz, &msg); err != nil {
+				return errors.Wrap(err, "could not parse the Ethereum transaction")
+			}
+
+			chainID, err := eip155ChainID(clientCtx)
+			if err != nil {
+				return err
+			}
+
+			if name := viper.GetString(flagSigner); name != "" {
+				device, err := signer.Open(name)
+				if err != nil {
+					return err
+				}
+				defer device.Close()
+
+				err = signWithExternalSigner(device, viper.GetString(flagHDPath), &msg, chainID)
+			} else {
+				err = signWithKeyring(inBuf, clientCtx.GetFromName(), &msg, chainID)
+			}
+			if err != nil {
+				return err
+			}
+
+			if !viper.GetBool(flagBroadcast) {
+				return clientCtx.PrintOutput(msg)
+			}
+
+			txBytes, err := authclient.GetTxEncoder(cdc)(msg)
+			if err != nil {
+				return err
+			}
+
+			res, err := clientCtx.BroadcastTx(txBytes)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintOutput(res)
+		},
+	}
+
+	cmd.Flags().String(flagSigner, "", fmt.Sprintf("Name of the external signer device (available: %v)", signer.Names()))
+	cmd.Flags().String(flagHDPath, ethermint.BIP44HDPath, "HD path of the key on the external signer device")
+	cmd.Flags().Uint64(flagEIP155ChainID, 0, "EIP-155 chain ID to sign the transaction with, queried from the node if not set")
+	cmd.Flags().Bool(flagBroadcast, false, "Broadcast the signed transaction to the node")
+	return cmd
+}
+
+// GetCmdSend sends the EVM denom to an account with an Ethereum transaction
+func GetCmdSend(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "send [to] [amount]",
+		Short: "Send an amount of the evm denom to an account with an Ethereum transaction",
+		Long: `Send an amount of the evm denom to an account with an Ethereum transaction signed by the
+--from key. The amount is either an integer or a coin of the evm denom (eg: 10aphoton).
+
+The gas limit is estimated through a simulation with --gas=auto, and the gas price is the
+amount of the evm denom on --gas-prices.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(authclient.GetTxEncoder(cdc))
+			clientCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			to, err := accountToHex(args[0])
+			if err != nil {
+				return errors.Wrap(err, "could not parse recipient address")
+			}
+
+			toAddr := common.HexToAddress(to)
+			return generateOrBroadcastEthereumTx(clientCtx, txBldr, inBuf, &toAddr, args[1], nil)
+		},
+	}
+}
+
+// GetCmdDeploy deploys a contract with an Ethereum transaction
+func GetCmdDeploy(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deploy",
+		Short: "Deploy a contract with an Ethereum transaction",
+		Long: fmt.Sprintf(`Deploy a contract with an Ethereum transaction signed by the --from key. The --%s is
+the hex encoded contract creation code, or the path of a file that contains it. If the
+constructor has arguments, they are packed with the --%s JSON file of the contract.
+
+The integer arguments can be decimal or 0x prefixed hex, the bytes are 0x prefixed hex and
+the arrays are JSON arrays. The arguments with commas must be quoted (eg: --%s '"[1,2]",0x01').
+
+Example:
+$ %s tx %s deploy --bytecode Token.bin --abi Token.abi --args 1000000 --from mykey --gas auto
+`, flagBytecode, flagABI, flagArgs, version.ClientName, types.ModuleName),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(authclient.GetTxEncoder(cdc))
+			clientCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			data, err := parseHexData(viper.GetString(flagBytecode))
+			if err != nil {
+				return errors.Wrap(err, "could not parse the contract bytecode")
+			}
+
+			if len(data) == 0 {
+				return errors.New("contract bytecode cannot be empty")
+			}
+
+			if path := viper.GetString(flagABI); path != "" {
+				contractABI, err := readABI(path)
+				if err != nil {
+					return err
+				}
+
+				constructorArgs, err := packABIArgs(contractABI.Constructor.Inputs, viper.GetStringSlice(flagArgs))
+				if err != nil {
+					return errors.Wrap(err, "could not pack the constructor arguments")
+				}
+
+				data = append(data, constructorArgs...)
+			} else if len(viper.GetStringSlice(flagArgs)) != 0 {
+				return fmt.Errorf("the --%s flag is required to pack the constructor arguments", flagABI)
+			}
+
+			return generateOrBroadcastEthereumTx(clientCtx, txBldr, inBuf, nil, viper.GetString(flagAmount), data)
+		},
+	}
+
+	cmd.Flags().String(flagBytecode, "", "Hex encoded contract creation code, or the path of a file that contains it")
+	cmd.Flags().String(flagABI, "", "Path of the JSON ABI of the contract")
+	cmd.Flags().StringSlice(flagArgs, nil, "Comma separated arguments of the constructor")
+	cmd.Flags().String(flagAmount, "", "Amount of the evm denom to send to the contract")
+	_ = cmd.MarkFlagRequired(flagBytecode)
+	return cmd
+}
+
+// GetCmdCall calls a contract method with an Ethereum transaction
+func GetCmdCall(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "call [contract]",
+		Short: "Call a contract method with an Ethereum transaction",
+		Long: fmt.Sprintf(`Call a contract method with an Ethereum transaction signed by the --from key. The --%s
+arguments are packed with the --%s JSON file of the contract. The overloaded methods must be
+identified by their signature (eg: transfer(address,uint256)).
+
+The integer arguments can be decimal or 0x prefixed hex, the bytes are 0x prefixed hex and
+the arrays are JSON arrays. The arguments with commas must be quoted (eg: --%s '"[1,2]",0x01').
+
+Example:
+$ %s tx %s call 0x... --abi Token.abi --method transfer --args 0x...,100 --from mykey --gas auto
+`, flagArgs, flagABI, flagArgs, version.ClientName, types.ModuleName),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(authclient.GetTxEncoder(cdc))
+			clientCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			contract, err := accountToHex(args[0])
+			if err != nil {
+				return errors.Wrap(err, "could not parse contract address")
+			}
+
+			contractABI, err := readABI(viper.GetString(flagABI))
+			if err != nil {
+				return err
+			}
+
+			method, err := abiMethod(contractABI, viper.GetString(flagMethod))
+			if err != nil {
+				return err
+			}
+
+			methodArgs, err := packABIArgs(method.Inputs, viper.GetStringSlice(flagArgs))
+			if err != nil {
+				return errors.Wrapf(err, "could not pack the arguments of %s", method.Sig)
+			}
+
+			contractAddr := common.HexToAddress(contract)
+			data := append(append([]byte{}, method.ID...), methodArgs...)
+			return generateOrBroadcastEthereumTx(clientCtx, txBldr, inBuf, &contractAddr, viper.GetString(flagAmount), data)
+		},
+	}
+
+	cmd.Flags().String(flagABI, "", "Path of the JSON ABI of the contract")
+	cmd.Flags().String(flagMethod, "", "Name or signature of the contract method")
+	cmd.Flags().StringSlice(flagArgs, nil, "Comma separated arguments of the method")
+	cmd.Flags().String(flagAmount, "", "Amount of the evm denom to send to the contract")
+	_ = cmd.MarkFlagRequired(flagABI)
+	_ = cmd.MarkFlagRequired(flagMethod)
+	return cmd
+}
+
+// parseAllowancePair parses the Ethereum or Cosmos addresses of the sender and target
+// contract of a fee allowance.
+func parseAllowancePair(senderArg, contractArg string) (sender, contract common.Address, err error) {
+	senderHex, err := accountToHex(senderArg)
+	if err != nil {
+		return sender, contract, errors.Wrap(err, "could not parse sender address")
+	}
+
+	contractHex, err := accountToHex(contractArg)
+	if err != nil {
+		return sender, contract, errors.Wrap(err, "could not parse contract address")
+	}
+
+	return common.HexToAddress(senderHex), common.HexToAddress(contractHex), nil
+}
@@ -1,14 +1,32 @@
 package cli
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"math/big"
+	"os"
 	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/spf13/viper"
 
 	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/input"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	authclient "github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	"github.com/cosmos/ethermint/crypto/ethsecp256k1"
+	"github.com/cosmos/ethermint/crypto/hd"
+	"github.com/cosmos/ethermint/crypto/signer"
+	ethermint "github.com/cosmos/ethermint/types"
+	"github.com/cosmos/ethermint/x/evm/types"
 )
 
 func accountToHex(addr string) (string, error) {
@@ -45,3 +63,303 @@ func formatKeyToHash(key string) string {
 
 	return ethkey.Hex()
 }
+
+// queryParams queries the evm module parameters from the node.
+func queryParams(clientCtx context.CLIContext) (types.Params, error) {
+	res, _, err := clientCtx.Query(fmt.Sprintf("custom/%s/%s", types.ModuleName, types.QueryParams))
+	if err != nil {
+		return types.Params{}, errors.Wrap(err, "could not query the evm parameters")
+	}
+
+	var params types.Params
+	if err := clientCtx.Codec.UnmarshalJSON(res, &params); err != nil {
+		return types.Params{}, err
+	}
+
+	return params, nil
+}
+
+// eip155ChainID returns the EIP-155 chain ID from the flag or from the evm module
+// parameters of the node.
+func eip155ChainID(clientCtx context.CLIContext) (*big.Int, error) {
+	if chainID := viper.GetUint64(flagEIP155ChainID); chainID != 0 {
+		return new(big.Int).SetUint64(chainID), nil
+	}
+
+	params, err := queryParams(clientCtx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not query the EIP-155 chain ID")
+	}
+
+	return params.EIP155ChainID(clientCtx.ChainID)
+}
+
+// parseEVMAmount parses an integer amount, or a coin of the evm denom. An empty amount is zero.
+func parseEVMAmount(amount, evmDenom string) (*big.Int, error) {
+	if amount == "" {
+		return new(big.Int), nil
+	}
+
+	if i, ok := sdk.NewIntFromString(amount); ok {
+		if i.IsNegative() {
+			return nil, fmt.Errorf("amount %s cannot be negative", amount)
+		}
+
+		return i.BigInt(), nil
+	}
+
+	coin, err := sdk.ParseCoin(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if coin.Denom != evmDenom {
+		return nil, fmt.Errorf("amount %s must be in the evm denom %s", amount, evmDenom)
+	}
+
+	return coin.Amount.BigInt(), nil
+}
+
+// evmGasPrice returns the gas price of the evm denom from the gas prices of the tx builder, or
+// the default gas price if it's not set.
+func evmGasPrice(txBldr authtypes.TxBuilder, evmDenom string) (*big.Int, error) {
+	if !txBldr.Fees().IsZero() {
+		return nil, fmt.Errorf("the fees of Ethereum transactions are set with the --%s flag", flags.FlagGasPrices)
+	}
+
+	gasPrice := txBldr.GasPrices().AmountOf(evmDenom)
+	if gasPrice.IsZero() {
+		return new(big.Int).SetUint64(ethermint.DefaultGasPrice), nil
+	}
+
+	if !gasPrice.IsInteger() {
+		return nil, fmt.Errorf("gas price %s%s must be an integer", gasPrice, evmDenom)
+	}
+
+	return gasPrice.TruncateInt().BigInt(), nil
+}
+
+// accountNonce returns the sequence of the account from the node, or zero if the account
+// doesn't exist yet.
+func accountNonce(clientCtx context.CLIContext, address sdk.AccAddress) (uint64, error) {
+	accRet := authtypes.NewAccountRetriever(clientCtx)
+	if err := accRet.EnsureExists(address); err != nil {
+		return 0, nil
+	}
+
+	_, nonce, err := accRet.GetAccountNumberSequence(address)
+	return nonce, err
+}
+
+// simulateEthereumTx simulates the Ethereum transaction as an unsigned MsgEthermint from the
+// given address, as the eth_call and eth_estimateGas RPC methods do.
+func simulateEthereumTx(
+	clientCtx context.CLIContext, from sdk.AccAddress, msg types.MsgEthereumTx,
+) (sdk.SimulationResponse, error) {
+	var to *sdk.AccAddress
+	if msg.To() != nil {
+		toAddr := sdk.AccAddress(msg.To().Bytes())
+		to = &toAddr
+	}
+
+	simMsg := types.NewMsgEthermint(
+		msg.Data.AccountNonce, to, msg.Data.Amount, msg.Data.GasLimit,
+		msg.Data.Price, msg.Data.Payload, from,
+	)
+
+	// the signature isn't verified on the simulation
+	tx := authtypes.NewStdTx([]sdk.Msg{simMsg}, authtypes.StdFee{}, []authtypes.StdSignature{{}}, "")
+	txBytes, err := authclient.GetTxEncoder(clientCtx.Codec)(tx)
+	if err != nil {
+		return sdk.SimulationResponse{}, err
+	}
+
+	res, _, err := clientCtx.QueryWithData("app/simulate", txBytes)
+	if err != nil {
+		return sdk.SimulationResponse{}, errors.Wrap(err, "could not simulate the Ethereum transaction")
+	}
+
+	var simRes sdk.SimulationResponse
+	if err := clientCtx.Codec.UnmarshalBinaryBare(res, &simRes); err != nil {
+		return sdk.SimulationResponse{}, err
+	}
+
+	return simRes, nil
+}
+
+// estimateGas simulates the Ethereum transaction with the RPC gas limit and returns the gas
+// used multiplied by the adjustment.
+func estimateGas(
+	clientCtx context.CLIContext, from sdk.AccAddress, msg types.MsgEthereumTx, adjustment float64,
+) (uint64, error) {
+	msg.Data.GasLimit = ethermint.DefaultRPCGasLimit
+
+	simRes, err := simulateEthereumTx(clientCtx, from, msg)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(adjustment * float64(simRes.GasUsed)), nil
+}
+
+// generateOrBroadcastEthereumTx builds an Ethereum transaction from the --from account with the
+// nonce and gas price of the tx builder flags. The nonce is queried from the node if it's not
+// set, and the gas limit is estimated with --gas=auto. The unsigned transaction is printed with
+// --generate-only, and otherwise it's signed with the keyring key and broadcasted. A nil
+// recipient deploys a contract.
+func generateOrBroadcastEthereumTx(
+	clientCtx context.CLIContext, txBldr authtypes.TxBuilder, inBuf *bufio.Reader,
+	to *common.Address, amountArg string, data []byte,
+) error {
+	// the node is not available offline, so the default evm denom and the --sequence nonce are used
+	params := types.DefaultParams()
+	if !clientCtx.GenerateOnly {
+		var err error
+		if params, err = queryParams(clientCtx); err != nil {
+			return err
+		}
+	}
+
+	amount, err := parseEVMAmount(amountArg, params.EvmDenom)
+	if err != nil {
+		return errors.Wrap(err, "could not parse amount")
+	}
+
+	gasPrice, err := evmGasPrice(txBldr, params.EvmDenom)
+	if err != nil {
+		return err
+	}
+
+	from := clientCtx.GetFromAddress()
+	nonce := txBldr.Sequence()
+	if nonce == 0 && !clientCtx.GenerateOnly {
+		if nonce, err = accountNonce(clientCtx, from); err != nil {
+			return err
+		}
+	}
+
+	msg := types.NewMsgEthereumTx(nonce, to, amount, txBldr.Gas(), gasPrice, data)
+	if to == nil {
+		msg = types.NewMsgEthereumTxContract(nonce, amount, txBldr.Gas(), gasPrice, data)
+	}
+
+	if txBldr.SimulateAndExecute() || clientCtx.Simulate {
+		gas, err := estimateGas(clientCtx, from, msg, txBldr.GasAdjustment())
+		if err != nil {
+			return err
+		}
+
+		msg.Data.GasLimit = gas
+		_, _ = fmt.Fprintf(os.Stderr, "%s\n", authclient.GasEstimateResponse{GasEstimate: gas})
+	}
+
+	if clientCtx.Simulate {
+		return nil
+	}
+
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+
+	if clientCtx.GenerateOnly {
+		return clientCtx.PrintOutput(msg)
+	}
+
+	if to == nil {
+		contract := ethcrypto.CreateAddress(common.BytesToAddress(from.Bytes()), nonce)
+		_, _ = fmt.Fprintf(os.Stderr, "contract address: %s\n", contract.Hex())
+	}
+
+	if !clientCtx.SkipConfirm {
+		_, _ = fmt.Fprintf(os.Stderr, "%s\n\n", clientCtx.Codec.MustMarshalJSON(msg))
+
+		ok, err := input.GetConfirmation("confirm transaction before signing and broadcasting", inBuf)
+		if err != nil || !ok {
+			_, _ = fmt.Fprintf(os.Stderr, "%s\n", "cancelled transaction")
+			return err
+		}
+	}
+
+	chainID, err := params.EIP155ChainID(clientCtx.ChainID)
+	if err != nil {
+		return err
+	}
+
+	if err := signWithKeyring(inBuf, clientCtx.GetFromName(), &msg, chainID); err != nil {
+		return err
+	}
+
+	txBytes, err := authclient.GetTxEncoder(clientCtx.Codec)(msg)
+	if err != nil {
+		return err
+	}
+
+	res, err := clientCtx.BroadcastTx(txBytes)
+	if err != nil {
+		return err
+	}
+
+	return clientCtx.PrintOutput(res)
+}
+
+// signWithKeyring signs the Ethereum transaction with the eth_secp256k1 key of the keyring.
+func signWithKeyring(inBuf io.Reader, name string, msg *types.MsgEthereumTx, chainID *big.Int) error {
+	keybase, err := keys.NewKeyring(
+		sdk.KeyringServiceName(),
+		viper.GetString(flags.FlagKeyringBackend),
+		viper.GetString(flags.FlagHome),
+		inBuf,
+		hd.EthSecp256k1Options()...,
+	)
+	if err != nil {
+		return err
+	}
+
+	// with the keyring keybase, the passphrase is not required as it is pulled from the OS prompt
+	privKey, err := keybase.ExportPrivateKeyObject(name, "")
+	if err != nil {
+		return err
+	}
+
+	ethPrivKey, ok := privKey.(ethsecp256k1.PrivKey)
+	if !ok {
+		return fmt.Errorf("key %s must be an %s key, got %T", name, ethsecp256k1.KeyType, privKey)
+	}
+
+	return msg.Sign(chainID, ethPrivKey.ToECDSA())
+}
+
+// signWithExternalSigner signs the Ethereum transaction with the key derived on the HD
+// path of the external signer device. The signature is verified against the address of
+// the device key.
+func signWithExternalSigner(device signer.ExternalSigner, hdPath string, msg *types.MsgEthereumTx, chainID *big.Int) error {
+	address, err := signer.Address(device, hdPath)
+	if err != nil {
+		return err
+	}
+
+	payload, err := msg.RLPSignPayload(chainID)
+	if err != nil {
+		return err
+	}
+
+	sig, err := device.SignEIP155(hdPath, payload)
+	if err != nil {
+		return err
+	}
+
+	if err := msg.SetSignature(chainID, sig); err != nil {
+		return err
+	}
+
+	sender, err := msg.VerifySig(chainID)
+	if err != nil {
+		return err
+	}
+
+	if sender != address {
+		return fmt.Errorf("signature of %s doesn't match the device address %s", sender, address)
+	}
+
+	return nil
+}
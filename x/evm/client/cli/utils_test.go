@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -8,6 +9,10 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/cosmos/ethermint/crypto/signer"
+	ethermint "github.com/cosmos/ethermint/types"
+	"github.com/cosmos/ethermint/x/evm/types"
 )
 
 func TestAddressFormats(t *testing.T) {
@@ -61,3 +66,46 @@ func TestCosmosToEthereumTypes(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, hexString, ethDecoded)
 }
+
+func TestSignWithExternalSigner(t *testing.T) {
+	device := signer.NewMockDevice("test test test test test test test test test test test junk")
+	chainID := big.NewInt(9000)
+	to := common.BytesToAddress([]byte("to"))
+
+	msg := types.NewMsgEthereumTx(0, &to, big.NewInt(100), 21000, big.NewInt(10), nil)
+	require.NoError(t, signWithExternalSigner(device, ethermint.BIP44HDPath, &msg, chainID))
+
+	sender, err := msg.VerifySig(chainID)
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"), sender)
+	require.Equal(t, chainID, msg.ChainID())
+
+	// the signature request is rejected on the device
+	device.Reject = true
+	msg = types.NewMsgEthereumTx(0, &to, big.NewInt(100), 21000, big.NewInt(10), nil)
+	require.Equal(t, signer.ErrSignRejected, signWithExternalSigner(device, ethermint.BIP44HDPath, &msg, chainID))
+}
+
+func TestParseEVMAmount(t *testing.T) {
+	testCases := []struct {
+		amount    string
+		expAmount *big.Int
+		expectErr bool
+	}{
+		{"", big.NewInt(0), false},
+		{"100", big.NewInt(100), false},
+		{"100aphoton", big.NewInt(100), false},
+		{"100stake", nil, true},
+		{"-1", nil, true},
+		{"1.5aphoton", nil, true},
+	}
+
+	for _, tc := range testCases {
+		amount, err := parseEVMAmount(tc.amount, "aphoton")
+		require.Equal(t, tc.expectErr, err != nil, tc.amount)
+
+		if !tc.expectErr {
+			require.Equal(t, tc.expAmount, amount, tc.amount)
+		}
+	}
+}
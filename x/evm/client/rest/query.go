@@ -0,0 +1,159 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+
+	ethermint "github.com/cosmos/ethermint/types"
+	evmtypes "github.com/cosmos/ethermint/x/evm/types"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// registerQueryRoutes registers the REST routes of the EVM module queries
+func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
+	r.HandleFunc("/evm/accounts/{address}", queryAddressHandlerFn(cliCtx, evmtypes.QueryAccount)).Methods("GET")
+	r.HandleFunc("/evm/balances/{address}", queryAddressHandlerFn(cliCtx, evmtypes.QueryBalance)).Methods("GET")
+	r.HandleFunc("/evm/codes/{address}", queryAddressHandlerFn(cliCtx, evmtypes.QueryCode)).Methods("GET")
+	r.HandleFunc("/evm/storage/{address}/{key}", queryStorageHandlerFn(cliCtx)).Methods("GET")
+	r.HandleFunc("/evm/storage_range/{address}", queryStorageRangeHandlerFn(cliCtx)).Methods("GET")
+	r.HandleFunc("/evm/dump", queryDumpHandlerFn(cliCtx)).Methods("GET")
+	r.HandleFunc("/evm/tx_logs/{hash}", queryPathHandlerFn(cliCtx, evmtypes.QueryTransactionLogs, "hash")).Methods("GET")
+	r.HandleFunc("/evm/block_logs/{hash}", queryPathHandlerFn(cliCtx, evmtypes.QueryBlockLogs, "hash")).Methods("GET")
+	r.HandleFunc("/evm/blooms/{height}", queryPathHandlerFn(cliCtx, evmtypes.QueryBloom, "height")).Methods("GET")
+	r.HandleFunc("/evm/state_diff/{height}", queryPathHandlerFn(cliCtx, evmtypes.QueryStateDiff, "height")).Methods("GET")
+}
+
+// queryAddressHandlerFn queries the given endpoint with the address path variable
+func queryAddressHandlerFn(cliCtx context.CLIContext, endpoint string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		address, ok := parseHexAddressOrReturnBadRequest(w, mux.Vars(r)["address"])
+		if !ok {
+			return
+		}
+
+		query(w, r, cliCtx, fmt.Sprintf("custom/%s/%s/%s", evmtypes.ModuleName, endpoint, address), nil)
+	}
+}
+
+// queryPathHandlerFn queries the given endpoint with the given path variable
+func queryPathHandlerFn(cliCtx context.CLIContext, endpoint, key string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query(w, r, cliCtx, fmt.Sprintf("custom/%s/%s/%s", evmtypes.ModuleName, endpoint, mux.Vars(r)[key]), nil)
+	}
+}
+
+func queryStorageHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		address, ok := parseHexAddressOrReturnBadRequest(w, vars["address"])
+		if !ok {
+			return
+		}
+
+		query(w, r, cliCtx, fmt.Sprintf("custom/%s/%s/%s/%s", evmtypes.ModuleName, evmtypes.QueryStorage, address, vars["key"]), nil)
+	}
+}
+
+// queryStorageRangeHandlerFn queries a range of the storage of an account, starting at the
+// optional start_key query parameter and limited to the optional limit query parameter.
+func queryStorageRangeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		address, ok := parseHexAddressOrReturnBadRequest(w, mux.Vars(r)["address"])
+		if !ok {
+			return
+		}
+
+		params := evmtypes.NewQueryStorageRangeParams(address, r.FormValue("start_key"), 0)
+
+		if limit := r.FormValue("limit"); limit != "" {
+			var err error
+			if params.Limit, err = strconv.ParseUint(limit, 10, 64); err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		query(w, r, cliCtx, fmt.Sprintf("custom/%s/%s", evmtypes.ModuleName, evmtypes.QueryStorageRange), bz)
+	}
+}
+
+// queryDumpHandlerFn queries a range of the EthAccounts, starting at the optional start_key
+// query parameter and limited to the optional limit query parameter. The code and storage of
+// the accounts are omitted if the exclude_code and exclude_storage query parameters are true.
+func queryDumpHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := evmtypes.NewQueryDumpParams(r.FormValue("start_key"), 0, false, false)
+
+		var err error
+		if limit := r.FormValue("limit"); limit != "" {
+			if params.Limit, err = strconv.ParseUint(limit, 10, 64); err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		if excludeCode := r.FormValue("exclude_code"); excludeCode != "" {
+			if params.ExcludeCode, err = strconv.ParseBool(excludeCode); err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		if excludeStorage := r.FormValue("exclude_storage"); excludeStorage != "" {
+			if params.ExcludeStorage, err = strconv.ParseBool(excludeStorage); err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		query(w, r, cliCtx, fmt.Sprintf("custom/%s/%s", evmtypes.ModuleName, evmtypes.QueryDump), bz)
+	}
+}
+
+// query queries the given path at the height of the request and writes the JSON response.
+func query(w http.ResponseWriter, r *http.Request, cliCtx context.CLIContext, path string, data []byte) {
+	cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+	if !ok {
+		return
+	}
+
+	res, height, err := cliCtx.QueryWithData(path, data)
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	cliCtx = cliCtx.WithHeight(height)
+	rest.PostProcessResponse(w, cliCtx, res)
+}
+
+// parseHexAddressOrReturnBadRequest returns the Ethereum hex address of an address given in
+// either the Bech32 or the hex format.
+func parseHexAddressOrReturnBadRequest(w http.ResponseWriter, address string) (string, bool) {
+	addr, err := ethermint.ParseAccAddress(address)
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return "", false
+	}
+
+	return common.BytesToAddress(addr.Bytes()).Hex(), true
+}
@@ -0,0 +1,94 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/ethermint/app"
+	"github.com/cosmos/ethermint/x/evm/client/rest"
+	"github.com/cosmos/ethermint/x/evm/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/bytes"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	"github.com/tendermint/tendermint/rpc/client/mock"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+// appClient is an RPC client that serves the ABCI queries with the given app
+type appClient struct {
+	mock.Client
+	app mock.ABCIApp
+}
+
+func (c appClient) ABCIQueryWithOptions(
+	path string, data bytes.HexBytes, opts rpcclient.ABCIQueryOptions,
+) (*ctypes.ResultABCIQuery, error) {
+	return c.app.ABCIQueryWithOptions(path, data, opts)
+}
+
+func TestQueryDumpPagination(t *testing.T) {
+	ethermintApp := app.Setup(false)
+
+	header := abci.Header{Height: 1, ChainID: "ethermint-1"}
+	ethermintApp.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	ctx := ethermintApp.BaseApp.NewContext(false, header)
+	for i := int64(1); i <= 3; i++ {
+		ethermintApp.EvmKeeper.SetBalance(ctx, ethcmn.BigToAddress(big.NewInt(i)), big.NewInt(i))
+	}
+	require.NoError(t, ethermintApp.EvmKeeper.Finalise(ctx, false))
+
+	ethermintApp.EndBlock(abci.RequestEndBlock{Height: header.Height})
+	ethermintApp.Commit()
+
+	cliCtx := context.CLIContext{}.
+		WithCodec(ethermintApp.Codec()).
+		WithClient(appClient{app: mock.ABCIApp{App: ethermintApp}}).
+		WithTrustNode(true)
+
+	router := mux.NewRouter()
+	rest.RegisterRoutes(cliCtx, router)
+
+	queryDump := func(query string) types.QueryResDump {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/evm/dump?"+query, nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var res struct {
+			Result json.RawMessage `json:"result"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+
+		var dump types.QueryResDump
+		ethermintApp.Codec().MustUnmarshalJSON(res.Result, &dump)
+		return dump
+	}
+
+	page := queryDump("limit=2")
+	require.Len(t, page.Accounts, 2)
+	require.NotEmpty(t, page.NextKey)
+
+	// the next page is the same with a hex and a Bech32 start key
+	next := queryDump(fmt.Sprintf("start_key=%s&limit=2", page.NextKey))
+	require.Equal(t, page.NextKey, next.Accounts[0].Address)
+
+	bech32StartKey := sdk.AccAddress(ethcmn.HexToAddress(page.NextKey).Bytes()).String()
+	require.Equal(t, next, queryDump(fmt.Sprintf("start_key=%s&limit=2", bech32StartKey)))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/evm/dump?start_key=0x01", nil))
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -62,6 +62,12 @@ func InitGenesis(
 		}
 	}
 
+	for _, allowance := range data.FeeAllowances {
+		k.SetFeeAllowance(
+			ctx, ethcmn.HexToAddress(allowance.Sender), ethcmn.HexToAddress(allowance.Contract), allowance.SpendLimit,
+		)
+	}
+
 	k.SetChainConfig(ctx, data.ChainConfig)
 
 	// set state objects and code to store
@@ -111,9 +117,10 @@ func ExportGenesis(ctx sdk.Context, k keeper.Keeper, ak types.AccountKeeper) Gen
 	config, _ := k.GetChainConfig(ctx)
 
 	return GenesisState{
-		Accounts:    ethGenAccounts,
-		TxsLogs:     k.GetAllTxLogs(ctx),
-		ChainConfig: config,
-		Params:      k.GetParams(ctx),
+		Accounts:      ethGenAccounts,
+		TxsLogs:       k.GetAllTxLogs(ctx),
+		ChainConfig:   config,
+		Params:        k.GetParams(ctx),
+		FeeAllowances: k.GetAllFeeAllowances(ctx),
 	}
 }
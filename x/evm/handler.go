@@ -1,9 +1,10 @@
 package evm
 
 import (
+	"errors"
+
 	"github.com/ethereum/go-ethereum/common"
 
-	ethermint "github.com/cosmos/ethermint/types"
 	"github.com/cosmos/ethermint/x/evm/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -51,6 +52,10 @@ func NewHandler(k *Keeper) sdk.Handler {
 			result, err = handleMsgEthereumTx(ctx, k, msg)
 		case types.MsgEthermint:
 			result, err = handleMsgEthermint(ctx, k, msg)
+		case types.MsgGrantFeeAllowance:
+			result, err = handleMsgGrantFeeAllowance(ctx, k, msg)
+		case types.MsgRevokeFeeAllowance:
+			result, err = handleMsgRevokeFeeAllowance(ctx, k, msg)
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s message type: %T", ModuleName, msg)
 		}
@@ -77,8 +82,8 @@ func handleMsgEthereumTx(ctx sdk.Context, k *Keeper, msg types.MsgEthereumTx) (*
 
 // handleMsgEthermint handles an sdk.StdTx for an Ethereum state transition
 func handleMsgEthermint(ctx sdk.Context, k *Keeper, msg types.MsgEthermint) (*sdk.Result, error) {
-	// parse the chainID from a string to a base-10 integer
-	chainIDEpoch, err := ethermint.ParseChainID(ctx.ChainID())
+	// get the EIP-155 chain ID from the params or the chain-id epoch
+	chainIDEpoch, err := k.ChainID(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -96,6 +101,7 @@ func handleMsgEthermint(ctx sdk.Context, k *Keeper, msg types.MsgEthermint) (*sd
 		ChainID:      chainIDEpoch,
 		TxHash:       &ethHash,
 		Sender:       common.BytesToAddress(msg.From.Bytes()),
+		Coinbase:     k.GetCoinbaseAddress(ctx),
 		Simulate:     ctx.IsCheckTx(),
 	}
 
@@ -117,6 +123,15 @@ func handleMsgEthermint(ctx sdk.Context, k *Keeper, msg types.MsgEthermint) (*sd
 
 	executionResult, err := st.TransitionDb(ctx, config)
 	if err != nil {
+		if !st.Simulate {
+			// the gas of a failed tx is still charged
+			k.AddBlockGasUsed(ctx.GasMeter().GasConsumed())
+
+			var blocked *types.BlockedTransfer
+			if errors.As(err, &blocked) {
+				k.AddBlockedTransfer(ethHash, *blocked)
+			}
+		}
 		return nil, err
 	}
 
@@ -124,11 +139,16 @@ func handleMsgEthermint(ctx sdk.Context, k *Keeper, msg types.MsgEthermint) (*sd
 	if !st.Simulate {
 		k.Bloom.Or(k.Bloom, executionResult.Bloom)
 
+		// update the cumulative gas used on the block
+		k.AddBlockGasUsed(ctx.GasMeter().GasConsumed())
+
 		// update transaction logs in KVStore
 		err = k.SetLogs(ctx, common.BytesToHash(txHash), executionResult.Logs)
 		if err != nil {
 			panic(err)
 		}
+
+		k.AddAccountDiffs(executionResult.StateDiff)
 	}
 
 	// log successful execution
@@ -159,3 +179,75 @@ func handleMsgEthermint(ctx sdk.Context, k *Keeper, msg types.MsgEthermint) (*sd
 	executionResult.Result.Events = ctx.EventManager().Events()
 	return executionResult.Result, nil
 }
+
+// handleMsgGrantFeeAllowance handles the fee allowance grants from the paymaster
+func handleMsgGrantFeeAllowance(ctx sdk.Context, k *Keeper, msg types.MsgGrantFeeAllowance) (*sdk.Result, error) {
+	if err := validatePaymaster(ctx, k, msg.Paymaster); err != nil {
+		return nil, err
+	}
+
+	sender := common.HexToAddress(msg.Sender)
+	contract := common.HexToAddress(msg.Contract)
+	k.SetFeeAllowance(ctx, sender, contract, msg.SpendLimit)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeGrantFeeAllowance,
+			sdk.NewAttribute(sdk.AttributeKeySender, sender.String()),
+			sdk.NewAttribute(types.AttributeKeyContractAddress, contract.String()),
+			sdk.NewAttribute(types.AttributeKeySpendLimit, msg.SpendLimit.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Paymaster.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// handleMsgRevokeFeeAllowance handles the fee allowance revocations from the paymaster
+func handleMsgRevokeFeeAllowance(ctx sdk.Context, k *Keeper, msg types.MsgRevokeFeeAllowance) (*sdk.Result, error) {
+	if err := validatePaymaster(ctx, k, msg.Paymaster); err != nil {
+		return nil, err
+	}
+
+	sender := common.HexToAddress(msg.Sender)
+	contract := common.HexToAddress(msg.Contract)
+	if _, found := k.GetFeeAllowance(ctx, sender, contract); !found {
+		return nil, sdkerrors.Wrapf(types.ErrFeeAllowanceNotFound, "sender %s, contract %s", sender, contract)
+	}
+
+	k.DeleteFeeAllowance(ctx, sender, contract)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeRevokeFeeAllowance,
+			sdk.NewAttribute(sdk.AttributeKeySender, sender.String()),
+			sdk.NewAttribute(types.AttributeKeyContractAddress, contract.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Paymaster.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// validatePaymaster returns an error if the signer of a fee allowance message is not the
+// paymaster defined on the parameters.
+func validatePaymaster(ctx sdk.Context, k *Keeper, signer sdk.AccAddress) error {
+	paymaster, ok := k.GetParams(ctx).PaymasterAddress()
+	if !ok {
+		return sdkerrors.Wrap(types.ErrInvalidPaymaster, "paymaster is not set")
+	}
+
+	if !paymaster.Equals(signer) {
+		return sdkerrors.Wrapf(types.ErrInvalidPaymaster, "expected %s, got %s", paymaster, signer)
+	}
+
+	return nil
+}
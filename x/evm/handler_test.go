@@ -15,10 +15,13 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/mint"
 
 	"github.com/cosmos/ethermint/app"
 	"github.com/cosmos/ethermint/crypto/ethsecp256k1"
@@ -47,8 +50,13 @@ func (suite *EvmTestSuite) SetupTest() {
 	suite.app = app.Setup(checkTx)
 	suite.ctx = suite.app.BaseApp.NewContext(checkTx, abci.Header{Height: 1, ChainID: "ethermint-3", Time: time.Now().UTC()})
 	suite.handler = evm.NewHandler(suite.app.EvmKeeper)
-	suite.querier = keeper.NewQuerier(*suite.app.EvmKeeper)
+	suite.querier = keeper.NewQuerier(suite.app.EvmKeeper)
 	suite.codec = codec.New()
+
+	// fund the fee collector, as the unused gas is refunded from the fees deducted by the AnteHandler
+	fees := sdk.NewCoins(ethermint.NewPhotonCoin(sdk.NewIntWithDecimal(1, 18)))
+	suite.Require().NoError(suite.app.SupplyKeeper.MintCoins(suite.ctx, mint.ModuleName, fees))
+	suite.Require().NoError(suite.app.SupplyKeeper.SendCoinsFromModuleToModule(suite.ctx, mint.ModuleName, auth.FeeCollectorName, fees))
 }
 
 func TestEvmTestSuite(t *testing.T) {
@@ -237,6 +245,12 @@ func (suite *EvmTestSuite) TestHandlerLogs() {
 	suite.Require().Equal(len(resultData.Logs), 1)
 	suite.Require().Equal(len(resultData.Logs[0].Topics), 2)
 
+	// check the block receipt of the contract creation
+	suite.Require().Len(suite.app.EvmKeeper.Receipts, 1)
+	receipt := suite.app.EvmKeeper.Receipts[0]
+	suite.Require().Equal(ethtypes.ReceiptStatusSuccessful, receipt.Status)
+	suite.Require().Equal(resultData.ContractAddress, *receipt.Contract())
+
 	hash := []byte{1}
 	err = suite.app.EvmKeeper.SetLogs(suite.ctx, ethcmn.BytesToHash(hash), resultData.Logs)
 	suite.Require().NoError(err)
@@ -381,6 +395,16 @@ func (suite *EvmTestSuite) TestDeployAndCallContract() {
 	resultData, err = types.DecodeResultData(result.Data)
 	suite.Require().NoError(err, "failed to decode result data")
 
+	// the state diff of the block contains the owner slot of the contract with the new owner
+	ownerKey := ethcrypto.Keccak256Hash(append(receiver.Bytes(), common.Hash{}.Bytes()...))
+	var contractStorage types.Storage
+	for _, diff := range types.MergeStateDiffs(suite.app.EvmKeeper.AccountDiffs) {
+		if diff.Address == receiver.String() {
+			contractStorage = diff.Storage
+		}
+	}
+	suite.Require().Equal(types.Storage{types.NewState(ownerKey, common.HexToHash(storeAddr[len(storeAddr)-40:]))}, contractStorage)
+
 	// query - getOwner
 	bytecode = common.FromHex("0x893d20e8")
 	tx = types.NewMsgEthereumTx(2, &receiver, big.NewInt(0), gasLimit, gasPrice, bytecode)
@@ -417,6 +441,132 @@ func (suite *EvmTestSuite) TestSendTransaction() {
 	suite.Require().NotNil(result)
 }
 
+func (suite *EvmTestSuite) TestRefundGas() {
+	gasLimit := uint64(100000)
+	gasPrice := big.NewInt(2)
+
+	priv, err := ethsecp256k1.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+	sender := ethcrypto.PubkeyToAddress(priv.ToECDSA().PublicKey)
+
+	suite.app.EvmKeeper.SetBalance(suite.ctx, sender, big.NewInt(100))
+	feeCollectorAddr := suite.app.SupplyKeeper.GetModuleAddress(auth.FeeCollectorName)
+	fees := suite.app.AccountKeeper.GetAccount(suite.ctx, feeCollectorAddr).GetCoins().AmountOf(ethermint.AttoPhoton)
+
+	tx := types.NewMsgEthereumTx(0, &ethcmn.Address{0x1}, big.NewInt(10), gasLimit, gasPrice, nil)
+	suite.Require().NoError(tx.Sign(big.NewInt(3), priv.ToECDSA()))
+
+	ctx := suite.ctx.WithGasMeter(sdk.NewGasMeter(gasLimit))
+	result, err := suite.handler(ctx, tx)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(result)
+
+	gasUsed := ctx.GasMeter().GasConsumed()
+	suite.Require().True(gasUsed > 0 && gasUsed < gasLimit)
+
+	// (gas limit - gas used) * gas price is sent from the fee collector back to the sender
+	refund := sdk.NewIntFromUint64((gasLimit - gasUsed) * gasPrice.Uint64())
+
+	balance := suite.app.AccountKeeper.GetAccount(suite.ctx, sender.Bytes()).GetCoins().AmountOf(ethermint.AttoPhoton)
+	suite.Require().Equal(sdk.NewInt(90).Add(refund), balance)
+
+	feesAfter := suite.app.AccountKeeper.GetAccount(suite.ctx, feeCollectorAddr).GetCoins().AmountOf(ethermint.AttoPhoton)
+	suite.Require().Equal(fees.Sub(refund), feesAfter)
+}
+
+func (suite *EvmTestSuite) TestRefundGasToPaymaster() {
+	gasLimit := uint64(100000)
+	gasPrice := big.NewInt(2)
+
+	priv, err := ethsecp256k1.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+	sender := ethcrypto.PubkeyToAddress(priv.ToECDSA().PublicKey)
+	paymaster := sdk.AccAddress(ethcmn.Address{0x2}.Bytes())
+
+	contract := ethcmn.Address{0x1}
+
+	suite.app.EvmKeeper.SetBalance(suite.ctx, sender, big.NewInt(100))
+
+	// the AnteHandler charges the allowance for the fees of the full gas limit
+	fees := new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), gasPrice)
+	suite.app.EvmKeeper.SetFeeAllowance(suite.ctx, sender, contract, sdk.NewIntFromBigInt(fees))
+	suite.app.EvmKeeper.UseFeeAllowance(suite.ctx, sender, contract, fees)
+
+	tx := types.NewMsgEthereumTx(0, &contract, big.NewInt(10), gasLimit, gasPrice, nil)
+	suite.Require().NoError(tx.Sign(big.NewInt(3), priv.ToECDSA()))
+
+	ctx := types.WithFeePayer(suite.ctx.WithGasMeter(sdk.NewGasMeter(gasLimit)), paymaster)
+	_, err = suite.handler(ctx, tx)
+	suite.Require().NoError(err)
+
+	// the unused gas is refunded to the paymaster that paid the fees
+	refund := sdk.NewIntFromUint64((gasLimit - ctx.GasMeter().GasConsumed()) * gasPrice.Uint64())
+
+	balance := suite.app.AccountKeeper.GetAccount(suite.ctx, sender.Bytes()).GetCoins().AmountOf(ethermint.AttoPhoton)
+	suite.Require().Equal(sdk.NewInt(90), balance)
+
+	paymasterBalance := suite.app.AccountKeeper.GetAccount(suite.ctx, paymaster).GetCoins().AmountOf(ethermint.AttoPhoton)
+	suite.Require().Equal(refund, paymasterBalance)
+
+	// the refund is credited back to the exhausted allowance
+	spendLimit, found := suite.app.EvmKeeper.GetFeeAllowance(suite.ctx, sender, contract)
+	suite.Require().True(found)
+	suite.Require().Equal(refund, spendLimit)
+}
+
+func (suite *EvmTestSuite) TestHandleFeeAllowance() {
+	paymaster := sdk.AccAddress(ethcmn.Address{0x2}.Bytes())
+	sender := ethcmn.Address{0x3}
+	contract := ethcmn.Address{0x4}
+
+	grant := types.NewMsgGrantFeeAllowance(paymaster, sender, contract, sdk.NewInt(100))
+	revoke := types.NewMsgRevokeFeeAllowance(paymaster, sender, contract)
+
+	// the paymaster is not set
+	_, err := suite.handler(suite.ctx, grant)
+	suite.Require().Error(err)
+
+	params := suite.app.EvmKeeper.GetParams(suite.ctx)
+	params.Paymaster = paymaster.String()
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	// the signer is not the paymaster
+	invalid := types.NewMsgGrantFeeAllowance(sdk.AccAddress(sender.Bytes()), sender, contract, sdk.NewInt(100))
+	_, err = suite.handler(suite.ctx, invalid)
+	suite.Require().Error(err)
+
+	_, err = suite.handler(suite.ctx, revoke)
+	suite.Require().Error(err, "allowance not found")
+
+	_, err = suite.handler(suite.ctx, grant)
+	suite.Require().NoError(err)
+
+	spendLimit, found := suite.app.EvmKeeper.GetFeeAllowance(suite.ctx, sender, contract)
+	suite.Require().True(found)
+	suite.Require().Equal(sdk.NewInt(100), spendLimit)
+	suite.Require().Equal(
+		[]types.FeeAllowance{types.NewFeeAllowance(sender, contract, sdk.NewInt(100))},
+		suite.app.EvmKeeper.GetAllFeeAllowances(suite.ctx),
+	)
+
+	// the allowance is removed once it's exhausted
+	suite.app.EvmKeeper.UseFeeAllowance(suite.ctx, sender, contract, big.NewInt(60))
+	spendLimit, _ = suite.app.EvmKeeper.GetFeeAllowance(suite.ctx, sender, contract)
+	suite.Require().Equal(sdk.NewInt(40), spendLimit)
+
+	suite.app.EvmKeeper.UseFeeAllowance(suite.ctx, sender, contract, big.NewInt(40))
+	_, found = suite.app.EvmKeeper.GetFeeAllowance(suite.ctx, sender, contract)
+	suite.Require().False(found)
+
+	_, err = suite.handler(suite.ctx, grant)
+	suite.Require().NoError(err)
+	_, err = suite.handler(suite.ctx, revoke)
+	suite.Require().NoError(err)
+
+	_, found = suite.app.EvmKeeper.GetFeeAllowance(suite.ctx, sender, contract)
+	suite.Require().False(found)
+}
+
 func (suite *EvmTestSuite) TestOutOfGasWhenDeployContract() {
 	// Test contract:
 	//http://remix.ethereum.org/#optimize=false&evmVersion=istanbul&version=soljson-v0.5.15+commit.6a57276f.js
@@ -521,6 +671,12 @@ func (suite *EvmTestSuite) TestErrorWhenDeployContract() {
 	_, sdkErr := suite.handler(suite.ctx, tx)
 	suite.Require().NotNil(sdkErr)
 
+	// the failed tx is still added to the block receipts and its gas to the block gas used
+	suite.Require().Len(suite.app.EvmKeeper.Receipts, 1)
+	suite.Require().Equal(ethtypes.ReceiptStatusFailed, suite.app.EvmKeeper.Receipts[0].Status)
+	suite.Require().NotZero(suite.app.EvmKeeper.Receipts[0].GasUsed)
+	suite.Require().Equal(suite.app.EvmKeeper.Receipts[0].GasUsed, suite.app.EvmKeeper.BlockGasUsed())
+
 	currentCommitStateDBJson, err := json.Marshal(suite.app.EvmKeeper.CommitStateDB)
 	suite.Require().Nil(err)
 	suite.Require().Equal(snapshotCommitStateDBJson, currentCommitStateDBJson)
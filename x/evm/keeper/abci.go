@@ -7,12 +7,15 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
+	"github.com/cosmos/ethermint/x/evm/types"
+
 	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 // BeginBlock sets the block hash -> block height map for the previous block height
-// and resets the Bloom filter and the transaction count to 0.
+// and resets the Bloom filter, the transaction receipts, the blocked transfers, the state
+// diff, the block gas used and the transaction count to 0.
 func (k *Keeper) BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) {
 	if req.Header.LastBlockId.GetHash() == nil || req.Header.GetHeight() < 1 {
 		return
@@ -32,12 +35,18 @@ func (k *Keeper) BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) {
 	// reset counters that are used on CommitStateDB.Prepare
 	k.Bloom = big.NewInt(0)
 	k.TxCount = 0
+	k.GasUsed = 0
+	k.Receipts = []types.TxReceipt{}
+	k.BlockedTransfers = nil
+	k.AccountDiffs = nil
 }
 
 // EndBlock updates the accounts and commits state objects to the KV Store, while
-// deleting the empty ones. It also sets the bloom filers for the request block to
-// the store. The EVM end block logic doesn't update the validator set, thus it returns
-// an empty slice.
+// deleting the empty ones. It also sets the bloom filers, the gas used, the transaction receipts
+// and the state diff for the request block to the store and prunes the block data that is outside of the
+// retention window. The value transfers blocked on the block are emitted as events, as
+// the events of the failed transactions are discarded. The EVM end block logic doesn't
+// update the validator set, thus it returns an empty slice.
 func (k Keeper) EndBlock(ctx sdk.Context, req abci.RequestEndBlock) []abci.ValidatorUpdate {
 	// Gas costs are handled within msg handler so costs should be ignored
 	ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
@@ -61,5 +70,34 @@ func (k Keeper) EndBlock(ctx sdk.Context, req abci.RequestEndBlock) []abci.Valid
 	bloom := ethtypes.BytesToBloom(k.Bloom.Bytes())
 	k.SetBlockBloom(ctx, req.Height, bloom)
 
+	// set the cumulative gas used by the block transactions to store
+	if k.GasUsed > 0 {
+		k.SetBlockGasUsed(ctx, req.Height, k.GasUsed)
+	}
+
+	// set the block transaction receipts to store
+	if err := k.SetBlockReceipts(ctx, req.Height, k.Receipts); err != nil {
+		panic(err)
+	}
+
+	// set the accounts and storage slots changed on the block to store
+	if err := k.SetBlockStateDiff(ctx, req.Height, types.MergeStateDiffs(k.AccountDiffs)); err != nil {
+		panic(err)
+	}
+
+	// index the transaction logs stored by previous versions, so that they can be pruned
+	if err := k.IndexLogs(ctx); err != nil {
+		panic(err)
+	}
+
+	// prune the block data that is outside of the retention window
+	if retainBlocks := int64(k.GetParams(ctx).RetainBlocks); retainBlocks > 0 && req.Height > retainBlocks {
+		k.PruneHistory(ctx, req.Height-retainBlocks+1)
+	}
+
+	for _, transfer := range k.BlockedTransfers {
+		ctx.EventManager().EmitEvent(transfer.Event())
+	}
+
 	return []abci.ValidatorUpdate{}
 }
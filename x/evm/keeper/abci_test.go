@@ -1,7 +1,16 @@
 package keeper_test
 
 import (
+	"math/big"
+
 	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/ethermint/x/evm/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 func (suite *KeeperTestSuite) TestBeginBlock() {
@@ -21,16 +30,22 @@ func (suite *KeeperTestSuite) TestBeginBlock() {
 	// update the counters
 	suite.app.EvmKeeper.Bloom.SetInt64(10)
 	suite.app.EvmKeeper.TxCount = 10
+	suite.app.EvmKeeper.AddTxReceipt(types.NewTxReceipt(ethcmn.BytesToHash(hash), 21000, ethtypes.ReceiptStatusSuccessful, nil))
+	suite.app.EvmKeeper.AddAccountDiffs([]types.AccountDiff{{Address: suite.address.String(), Storage: types.Storage{}}})
 
 	suite.app.EvmKeeper.BeginBlock(suite.ctx, abci.RequestBeginBlock{})
 	suite.Require().NotZero(suite.app.EvmKeeper.Bloom.Int64())
 	suite.Require().NotZero(suite.app.EvmKeeper.TxCount)
+	suite.Require().NotEmpty(suite.app.EvmKeeper.Receipts)
+	suite.Require().NotEmpty(suite.app.EvmKeeper.AccountDiffs)
 
 	suite.Require().Equal(int64(initialConsumed), int64(suite.ctx.GasMeter().GasConsumed()))
 
 	suite.app.EvmKeeper.BeginBlock(suite.ctx, req)
 	suite.Require().Zero(suite.app.EvmKeeper.Bloom.Int64())
 	suite.Require().Zero(suite.app.EvmKeeper.TxCount)
+	suite.Require().Empty(suite.app.EvmKeeper.Receipts)
+	suite.Require().Empty(suite.app.EvmKeeper.AccountDiffs)
 
 	suite.Require().Equal(int64(initialConsumed), int64(suite.ctx.GasMeter().GasConsumed()))
 
@@ -43,6 +58,15 @@ func (suite *KeeperTestSuite) TestEndBlock() {
 	// update the counters
 	suite.app.EvmKeeper.Bloom.SetInt64(10)
 
+	receipt := types.NewTxReceipt(ethcmn.BytesToHash(hash), 21000, ethtypes.ReceiptStatusSuccessful, nil)
+	suite.app.EvmKeeper.Receipts = []types.TxReceipt{receipt}
+
+	key := ethcmn.BytesToHash([]byte("key"))
+	suite.app.EvmKeeper.AccountDiffs = []types.AccountDiff{
+		{Address: suite.address.String(), Storage: types.Storage{types.NewState(key, ethcmn.BytesToHash([]byte("value")))}},
+		{Address: suite.address.String(), Storage: types.Storage{types.NewState(key, ethcmn.Hash{})}},
+	}
+
 	// set gas limit to 1 to ensure no gas is consumed during the operation
 	initialConsumed := suite.ctx.GasMeter().GasConsumed()
 
@@ -54,4 +78,63 @@ func (suite *KeeperTestSuite) TestEndBlock() {
 	suite.Require().True(found)
 	suite.Require().Equal(int64(10), bloom.Big().Int64())
 
+	receipts, err := suite.app.EvmKeeper.GetBlockReceipts(suite.ctx, 100)
+	suite.Require().NoError(err)
+	suite.Require().Equal([]types.TxReceipt{receipt}, receipts)
+
+	// the account diffs of the block are merged
+	diffs, err := suite.app.EvmKeeper.GetBlockStateDiff(suite.ctx, 100)
+	suite.Require().NoError(err)
+	suite.Require().Equal([]types.AccountDiff{
+		{Address: suite.address.String(), Storage: types.Storage{types.NewState(key, ethcmn.Hash{})}},
+	}, diffs)
+}
+
+func (suite *KeeperTestSuite) TestEndBlockPruning() {
+	params := suite.app.EvmKeeper.GetParams(suite.ctx)
+	params.RetainBlocks = 2
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	for height := int64(1); height <= 4; height++ {
+		_ = suite.app.EvmKeeper.EndBlock(suite.ctx.WithBlockHeight(height), abci.RequestEndBlock{Height: height})
+	}
+
+	// only the last 2 blocks are retained
+	for height := int64(1); height <= 4; height++ {
+		_, found := suite.app.EvmKeeper.GetBlockBloom(suite.ctx, height)
+		suite.Require().Equal(height > 2, found, height)
+		suite.Require().Equal(height <= 2, suite.app.EvmKeeper.IsHistoryPruned(suite.ctx, height), height)
+	}
+}
+
+func (suite *KeeperTestSuite) TestEndBlockBlockedTransfers() {
+	params := types.DefaultParams()
+	params.BlockedAddresses = []string{suite.address.Hex()}
+
+	sender := ethcmn.BytesToAddress([]byte("sender"))
+	transfer := types.NewBlockedTransfer(params, sender, suite.address, big.NewInt(10))
+	suite.Require().NotNil(transfer)
+
+	txHash := ethcmn.BytesToHash(hash)
+	suite.app.EvmKeeper.AddBlockedTransfer(txHash, *transfer)
+
+	ctx := suite.ctx.WithEventManager(sdk.NewEventManager())
+	_ = suite.app.EvmKeeper.EndBlock(ctx, abci.RequestEndBlock{Height: 100})
+
+	events := ctx.EventManager().Events()
+	suite.Require().Len(events, 1)
+	suite.Require().Equal(types.EventTypeBlockedTransfer, events[0].Type)
+	suite.Require().Equal(txHash.Hex(), string(events[0].Attributes[0].Value))
+
+	// the blocked transfers are reset on the next block
+	suite.app.EvmKeeper.BeginBlock(suite.ctx, abci.RequestBeginBlock{
+		Header: abci.Header{
+			LastBlockId: abci.BlockID{
+				Hash: []byte("last hash"),
+			},
+			Height: 101,
+		},
+		Hash: []byte("hash"),
+	})
+	suite.Require().Empty(suite.app.EvmKeeper.BlockedTransfers)
 }
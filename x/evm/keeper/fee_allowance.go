@@ -0,0 +1,114 @@
+package keeper
+
+import (
+	"math/big"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/cosmos/ethermint/x/evm/types"
+)
+
+// ----------------------------------------------------------------------------
+// Fee allowance functions
+// Required by the AnteHandler to sponsor the fees of Ethereum transactions.
+// ----------------------------------------------------------------------------
+
+// GetFeeAllowance returns the remaining fees that the paymaster pays on behalf of the
+// sender for the transactions that call the given contract.
+func (k Keeper) GetFeeAllowance(ctx sdk.Context, sender, contract common.Address) (sdk.Int, bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixFeeAllowance)
+	bz := store.Get(types.FeeAllowanceKey(sender, contract))
+	if len(bz) == 0 {
+		return sdk.ZeroInt(), false
+	}
+
+	var spendLimit sdk.Int
+	k.cdc.MustUnmarshalBinaryBare(bz, &spendLimit)
+	return spendLimit, true
+}
+
+// SetFeeAllowance sets the fees that the paymaster pays on behalf of the sender for the
+// transactions that call the given contract.
+func (k Keeper) SetFeeAllowance(ctx sdk.Context, sender, contract common.Address, spendLimit sdk.Int) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixFeeAllowance)
+	store.Set(types.FeeAllowanceKey(sender, contract), k.cdc.MustMarshalBinaryBare(spendLimit))
+}
+
+// DeleteFeeAllowance removes the fee allowance of the sender for the given contract.
+func (k Keeper) DeleteFeeAllowance(ctx sdk.Context, sender, contract common.Address) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixFeeAllowance)
+	store.Delete(types.FeeAllowanceKey(sender, contract))
+}
+
+// GetAllFeeAllowances returns all the fee allowances from the store.
+func (k Keeper) GetAllFeeAllowances(ctx sdk.Context) []types.FeeAllowance {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixFeeAllowance)
+	iterator := store.Iterator(nil, nil)
+	defer iterator.Close()
+
+	allowances := []types.FeeAllowance{}
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()
+
+		var spendLimit sdk.Int
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &spendLimit)
+
+		allowances = append(allowances, types.NewFeeAllowance(
+			common.BytesToAddress(key[:common.AddressLength]),
+			common.BytesToAddress(key[common.AddressLength:]),
+			spendLimit,
+		))
+	}
+
+	return allowances
+}
+
+// GetFeeSponsor returns the paymaster if it's set on the parameters, the fee allowance of
+// the sender for the given contract covers the fee and the paymaster balance is enough to
+// pay it.
+func (k Keeper) GetFeeSponsor(ctx sdk.Context, sender, contract common.Address, fee *big.Int) (sdk.AccAddress, bool) {
+	params := k.GetParams(ctx)
+	paymaster, ok := params.PaymasterAddress()
+	if !ok {
+		return nil, false
+	}
+
+	spendLimit, found := k.GetFeeAllowance(ctx, sender, contract)
+	if !found || spendLimit.BigInt().Cmp(fee) < 0 {
+		return nil, false
+	}
+
+	acc := k.accountKeeper.GetAccount(ctx, paymaster)
+	if acc == nil || acc.GetCoins().AmountOf(params.EvmDenom).BigInt().Cmp(fee) < 0 {
+		return nil, false
+	}
+
+	return paymaster, true
+}
+
+// UseFeeAllowance deducts the fee paid by the paymaster from the fee allowance of the
+// sender for the given contract. The allowance is removed once it's exhausted.
+//
+// CONTRACT: the allowance must cover the fee.
+func (k Keeper) UseFeeAllowance(ctx sdk.Context, sender, contract common.Address, fee *big.Int) {
+	spendLimit, _ := k.GetFeeAllowance(ctx, sender, contract)
+	spendLimit = spendLimit.Sub(sdk.NewIntFromBigInt(fee))
+
+	if !spendLimit.IsPositive() {
+		k.DeleteFeeAllowance(ctx, sender, contract)
+		return
+	}
+
+	k.SetFeeAllowance(ctx, sender, contract, spendLimit)
+}
+
+// RefundFeeAllowance credits the refunded fee back to the fee allowance of the sender for the
+// given contract, so that the allowance is only charged for the gas used by the transaction.
+// The allowance is restored if it was exhausted by UseFeeAllowance.
+func (k Keeper) RefundFeeAllowance(ctx sdk.Context, sender, contract common.Address, refund *big.Int) {
+	spendLimit, _ := k.GetFeeAllowance(ctx, sender, contract)
+	k.SetFeeAllowance(ctx, sender, contract, spendLimit.Add(sdk.NewIntFromBigInt(refund)))
+}
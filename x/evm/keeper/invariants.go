@@ -13,12 +13,14 @@ import (
 const (
 	balanceInvariant = "balance"
 	nonceInvariant   = "nonce"
+	supplyInvariant  = "supply"
 )
 
 // RegisterInvariants registers the evm module invariants
 func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
 	ir.RegisterRoute(types.ModuleName, balanceInvariant, k.BalanceInvariant())
 	ir.RegisterRoute(types.ModuleName, nonceInvariant, k.NonceInvariant())
+	ir.RegisterRoute(types.ModuleName, supplyInvariant, k.SupplyInvariant())
 }
 
 // BalanceInvariant checks that all auth module's EthAccounts in the application have the same balance
@@ -98,3 +100,29 @@ func (k Keeper) NonceInvariant() sdk.Invariant {
 		), broken
 	}
 }
+
+// SupplyInvariant checks that the total supply of the EVM denomination matches the sum of
+// the balances of all the accounts in the application, i.e that the EVM doesn't mint nor
+// burn coins.
+func (k Keeper) SupplyInvariant() sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		evmDenom := k.GetParams(ctx).EvmDenom
+		balances := sdk.ZeroInt()
+
+		k.accountKeeper.IterateAccounts(ctx, func(account authexported.Account) bool {
+			balances = balances.Add(account.GetCoins().AmountOf(evmDenom))
+			return false
+		})
+
+		supply := k.supplyKeeper.GetSupply(ctx).GetTotal().AmountOf(evmDenom)
+		broken := !supply.Equal(balances)
+
+		return sdk.FormatInvariant(
+			types.ModuleName, supplyInvariant,
+			fmt.Sprintf(
+				"\tsum of %s account balances: %s\n\ttotal supply: %s\n",
+				evmDenom, balances, supply,
+			),
+		), broken
+	}
+}
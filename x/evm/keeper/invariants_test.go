@@ -5,6 +5,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/cosmos-sdk/x/mint"
 
 	"github.com/cosmos/ethermint/crypto/ethsecp256k1"
 	ethermint "github.com/cosmos/ethermint/types"
@@ -137,3 +138,43 @@ func (suite *KeeperTestSuite) TestNonceInvariant() {
 		})
 	}
 }
+
+func (suite *KeeperTestSuite) TestSupplyInvariant() {
+	testCases := []struct {
+		name      string
+		malleate  func()
+		expBroken bool
+	}{
+		{
+			"supply ok",
+			func() {
+				coins := sdk.NewCoins(ethermint.NewPhotonCoinInt64(100))
+				suite.Require().NoError(suite.app.SupplyKeeper.MintCoins(suite.ctx, mint.ModuleName, coins))
+				suite.Require().NoError(
+					suite.app.SupplyKeeper.SendCoinsFromModuleToAccount(suite.ctx, mint.ModuleName, suite.address.Bytes(), coins),
+				)
+			},
+			false,
+		},
+		{
+			"balance added outside of the supply",
+			func() {
+				acc := suite.app.AccountKeeper.GetAccount(suite.ctx, suite.address.Bytes())
+				suite.Require().NoError(acc.SetCoins(sdk.NewCoins(ethermint.NewPhotonCoinInt64(100))))
+				suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset values
+
+			tc.malleate()
+
+			_, broken := suite.app.EvmKeeper.SupplyInvariant()(suite.ctx)
+			suite.Require().Equal(tc.expBroken, broken)
+		})
+	}
+}
@@ -29,9 +29,16 @@ type Keeper struct {
 	// - storing transaction Logs
 	// - storing block height -> bloom filter map. Needed for the Web3 API.
 	// - storing block hash -> block height map. Needed for the Web3 API.
+	// - storing block height -> cumulative gas used map. Needed for the Web3 API.
+	// - storing block height -> transaction receipts map. Needed for the Web3 API.
+	// - storing block height -> changed accounts and storage map. Needed for the debug API.
 	storeKey sdk.StoreKey
 	// Account Keeper for fetching accounts
 	accountKeeper types.AccountKeeper
+	// Staking Keeper for fetching the block proposer validator. Needed for the EVM coinbase.
+	stakingKeeper types.StakingKeeper
+	// Supply Keeper for refunding the unused gas from the fee collector module account
+	supplyKeeper types.SupplyKeeper
 	// Ethermint concrete implementation on the EVM StateDB interface
 	CommitStateDB *types.CommitStateDB
 	// Transaction counter in a block. Used on StateSB's Prepare function.
@@ -39,11 +46,28 @@ type Keeper struct {
 	// on the KVStore or adding it as a field on the EVM genesis state.
 	TxCount int
 	Bloom   *big.Int
+	// GasUsed is the cumulative gas used by the Ethereum transactions executed in the current
+	// block, including the failed ones. It's kept outside of the KVStore as the state changes
+	// of a failed transaction are reverted. It is persisted to the KVStore on EndBlock and
+	// reset every block on BeginBlock.
+	GasUsed uint64
+	// Receipts of the Ethereum transactions executed in the current block. They are persisted
+	// to the KVStore on EndBlock and reset every block on BeginBlock.
+	Receipts []types.TxReceipt
+	// BlockedTransfers are the value transfers from or to a blocked address that caused an
+	// Ethereum transaction of the current block to fail. They are emitted as events on
+	// EndBlock and reset every block on BeginBlock.
+	BlockedTransfers []types.BlockedTransfer
+	// AccountDiffs hold the accounts and storage slots changed by the Ethereum transactions
+	// executed in the current block. They are merged and persisted to the KVStore on
+	// EndBlock and reset every block on BeginBlock.
+	AccountDiffs []types.AccountDiff
 }
 
 // NewKeeper generates new evm module keeper
 func NewKeeper(
-	cdc *codec.Codec, storeKey sdk.StoreKey, paramSpace params.Subspace, ak types.AccountKeeper,
+	cdc *codec.Codec, storeKey, accountKey sdk.StoreKey, paramSpace params.Subspace, ak types.AccountKeeper,
+	sk types.StakingKeeper, supplyKeeper types.SupplyKeeper,
 ) *Keeper {
 	// set KeyTable if it has not already been set
 	if !paramSpace.HasKeyTable() {
@@ -55,9 +79,12 @@ func NewKeeper(
 		cdc:           cdc,
 		storeKey:      storeKey,
 		accountKeeper: ak,
-		CommitStateDB: types.NewCommitStateDB(sdk.Context{}, storeKey, paramSpace, ak),
+		stakingKeeper: sk,
+		supplyKeeper:  supplyKeeper,
+		CommitStateDB: types.NewCommitStateDB(sdk.Context{}, storeKey, accountKey, paramSpace, ak),
 		TxCount:       0,
 		Bloom:         big.NewInt(0),
+		Receipts:      []types.TxReceipt{},
 	}
 }
 
@@ -129,6 +156,267 @@ func (k Keeper) SetBlockBloom(ctx sdk.Context, height int64, bloom ethtypes.Bloo
 	store.Set(types.BloomKey(height), bloom.Bytes())
 }
 
+// ----------------------------------------------------------------------------
+// Coinbase functions
+// Required by the EVM context and the Web3 API.
+// ----------------------------------------------------------------------------
+
+// GetCoinbaseAddress returns the operator address of the block proposer validator
+// as an Ethereum address. It returns an empty address if the proposer validator is
+// not found (eg: during genesis).
+func (k Keeper) GetCoinbaseAddress(ctx sdk.Context) common.Address {
+	consAddr := sdk.ConsAddress(ctx.BlockHeader().ProposerAddress)
+	coinbase, _ := k.GetValidatorOperatorAddress(ctx, consAddr)
+	return coinbase
+}
+
+// GetValidatorOperatorAddress returns the operator address as an Ethereum address of
+// the validator with the given consensus address.
+func (k Keeper) GetValidatorOperatorAddress(ctx sdk.Context, consAddr sdk.ConsAddress) (common.Address, bool) {
+	// fetching the validator shouldn't consume gas from the transaction
+	ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+
+	validator := k.stakingKeeper.ValidatorByConsAddr(ctx, consAddr)
+	if validator == nil {
+		return common.Address{}, false
+	}
+
+	return common.BytesToAddress(validator.GetOperator()), true
+}
+
+// ----------------------------------------------------------------------------
+// Block gas used mapping functions
+// Required by the AnteHandler and the Web3 API.
+// ----------------------------------------------------------------------------
+
+// GetBlockGasUsed returns the cumulative gas used by the EVM transactions on the
+// given block height.
+func (k Keeper) GetBlockGasUsed(ctx sdk.Context, height int64) uint64 {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixBlockGas)
+	bz := store.Get(types.BlockGasKey(height))
+	if len(bz) == 0 {
+		return 0
+	}
+
+	return binary.BigEndian.Uint64(bz)
+}
+
+// SetBlockGasUsed sets the cumulative gas used by the EVM transactions on the
+// given block height.
+func (k Keeper) SetBlockGasUsed(ctx sdk.Context, height int64, gasUsed uint64) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixBlockGas)
+	store.Set(types.BlockGasKey(height), sdk.Uint64ToBigEndian(gasUsed))
+}
+
+// BlockGasUsed returns the cumulative gas used by the EVM transactions executed so far on
+// the current block.
+func (k Keeper) BlockGasUsed() uint64 {
+	return k.GasUsed
+}
+
+// AddBlockGasUsed adds the given gas to the cumulative gas used on the current block
+// and returns the updated value.
+func (k *Keeper) AddBlockGasUsed(gas uint64) uint64 {
+	k.GasUsed += gas
+	return k.GasUsed
+}
+
+// ----------------------------------------------------------------------------
+// Block receipts mapping functions
+// Required by Web3 API.
+// ----------------------------------------------------------------------------
+
+// GetBlockReceipts returns the receipts of the EVM transactions executed on the given
+// block height.
+func (k Keeper) GetBlockReceipts(ctx sdk.Context, height int64) ([]types.TxReceipt, error) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixReceipts)
+	bz := store.Get(types.ReceiptsKey(height))
+	if len(bz) == 0 {
+		return []types.TxReceipt{}, nil
+	}
+
+	return types.UnmarshalReceipts(bz)
+}
+
+// SetBlockReceipts sets the receipts of the EVM transactions executed on the given block
+// height. Empty receipts are not stored.
+func (k Keeper) SetBlockReceipts(ctx sdk.Context, height int64, receipts []types.TxReceipt) error {
+	if len(receipts) == 0 {
+		return nil
+	}
+
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixReceipts)
+	bz, err := types.MarshalReceipts(receipts)
+	if err != nil {
+		return err
+	}
+
+	store.Set(types.ReceiptsKey(height), bz)
+	return nil
+}
+
+// GetBlockStateDiff returns the accounts and storage slots changed on the given block
+// height. It returns an empty slice if the block didn't change the EVM state.
+func (k Keeper) GetBlockStateDiff(ctx sdk.Context, height int64) ([]types.AccountDiff, error) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixStateDiff)
+	bz := store.Get(types.StateDiffKey(height))
+	if len(bz) == 0 {
+		return []types.AccountDiff{}, nil
+	}
+
+	return types.UnmarshalStateDiff(bz)
+}
+
+// SetBlockStateDiff sets the accounts and storage slots changed on the given block height.
+// Empty diffs are not stored.
+func (k Keeper) SetBlockStateDiff(ctx sdk.Context, height int64, diffs []types.AccountDiff) error {
+	if len(diffs) == 0 {
+		return nil
+	}
+
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixStateDiff)
+	bz, err := types.MarshalStateDiff(diffs)
+	if err != nil {
+		return err
+	}
+
+	store.Set(types.StateDiffKey(height), bz)
+	return nil
+}
+
+// AddAccountDiffs appends the accounts changed by an EVM transaction to the state diff of the
+// current block.
+func (k *Keeper) AddAccountDiffs(diffs []types.AccountDiff) {
+	k.AccountDiffs = append(k.AccountDiffs, diffs...)
+}
+
+// AddBlockedTransfer records a blocked value transfer of the given transaction in order to
+// emit it as an event on EndBlock.
+func (k *Keeper) AddBlockedTransfer(txHash common.Hash, transfer types.BlockedTransfer) {
+	transfer.TxHash = txHash
+	k.BlockedTransfers = append(k.BlockedTransfers, transfer)
+}
+
+// AddTxReceipt appends the receipt of an EVM transaction to the receipts of the current block.
+func (k *Keeper) AddTxReceipt(receipt types.TxReceipt) {
+	k.Receipts = append(k.Receipts, receipt)
+}
+
+// ----------------------------------------------------------------------------
+// History pruning functions
+// ----------------------------------------------------------------------------
+
+// historyBatchSize is the maximum number of entries of each kind of block data that are
+// pruned, as well as the maximum number of transaction logs that are indexed by height, on a
+// single block. It bounds the work done on EndBlock when the retention window is enabled or
+// reduced on a running chain.
+const historyBatchSize = 1000
+
+// GetHistoryPrunedHeight returns the lowest block height whose transaction logs, bloom filter,
+// receipts, gas used and state diff are kept on the store.
+func (k Keeper) GetHistoryPrunedHeight(ctx sdk.Context) int64 {
+	bz := ctx.KVStore(k.storeKey).Get(types.KeyHistoryPrunedHeight)
+	if len(bz) == 0 {
+		return 0
+	}
+
+	return int64(binary.BigEndian.Uint64(bz))
+}
+
+// IsHistoryPruned returns true if the transaction logs, bloom filter, receipts, gas used
+// and state diff of the given block height have been pruned according to the RetainBlocks
+// parameter. The block data is still available on the versions of the state up to the
+// given height, for the nodes that keep them (i.e archive nodes).
+func (k Keeper) IsHistoryPruned(ctx sdk.Context, height int64) bool {
+	return height < k.GetHistoryPrunedHeight(ctx)
+}
+
+// PruneHistory deletes the transaction logs, bloom filters, receipts, gas used and state
+// diffs of all the blocks with a height lower than the given one. At most historyBatchSize
+// entries of each kind are deleted, the remaining ones are deleted on the next calls.
+func (k Keeper) PruneHistory(ctx sdk.Context, height int64) {
+	// the pruned height never decreases, as the pruned entries can't be restored
+	if prunedHeight := k.GetHistoryPrunedHeight(ctx); height < prunedHeight {
+		height = prunedHeight
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	end := sdk.Uint64ToBigEndian(uint64(height))
+
+	logsStore := prefix.NewStore(store, types.KeyPrefixLogs)
+	logsHeightStore := prefix.NewStore(store, types.KeyPrefixLogsHeight)
+
+	for _, key := range prefixStoreKeys(logsHeightStore, nil, end, historyBatchSize) {
+		// the tx hash is stored after the 8 bytes of the height
+		logsStore.Delete(key[8:])
+		logsHeightStore.Delete(key)
+	}
+
+	for _, keyPrefix := range [][]byte{types.KeyPrefixBloom, types.KeyPrefixReceipts, types.KeyPrefixBlockGas, types.KeyPrefixStateDiff} {
+		prefixStore := prefix.NewStore(store, keyPrefix)
+		for _, key := range prefixStoreKeys(prefixStore, nil, end, historyBatchSize) {
+			prefixStore.Delete(key)
+		}
+	}
+
+	store.Set(types.KeyHistoryPrunedHeight, end)
+}
+
+// IndexLogs indexes by block height the transaction logs stored by the previous versions, so
+// that they can be pruned. Each call indexes at most historyBatchSize transactions, from where
+// the previous call stopped, and the empty logs are deleted. Once all the logs have been
+// indexed it's a no-op.
+func (k Keeper) IndexLogs(ctx sdk.Context) error {
+	store := ctx.KVStore(k.storeKey)
+	if store.Has(types.KeyLogsIndexed) {
+		return nil
+	}
+
+	logsStore := prefix.NewStore(store, types.KeyPrefixLogs)
+	logsHeightStore := prefix.NewStore(store, types.KeyPrefixLogsHeight)
+
+	// an extra key is fetched to know where the next call starts
+	keys := prefixStoreKeys(logsStore, store.Get(types.KeyLogsIndexCursor), nil, historyBatchSize+1)
+	for i, key := range keys {
+		if i == historyBatchSize {
+			store.Set(types.KeyLogsIndexCursor, key)
+			return nil
+		}
+
+		logs, err := types.UnmarshalLogs(logsStore.Get(key))
+		if err != nil {
+			return err
+		}
+
+		if len(logs) == 0 {
+			logsStore.Delete(key)
+			continue
+		}
+
+		txHash := common.BytesToHash(key)
+		logsHeightStore.Set(types.LogsHeightKey(int64(logs[0].BlockNumber), txHash), []byte{0x1})
+	}
+
+	store.Delete(types.KeyLogsIndexCursor)
+	store.Set(types.KeyLogsIndexed, []byte{0x1})
+	return nil
+}
+
+// prefixStoreKeys returns at most limit keys from the store within the [start, end) range (a nil
+// start or end is unbounded).
+// The keys are collected first as the store can't be modified while iterating.
+func prefixStoreKeys(store prefix.Store, start, end []byte, limit int) [][]byte {
+	iterator := store.Iterator(start, end)
+	defer iterator.Close()
+
+	keys := [][]byte{}
+	for ; iterator.Valid() && len(keys) < limit; iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+
+	return keys
+}
+
 // GetAllTxLogs return all the transaction logs from the store.
 func (k Keeper) GetAllTxLogs(ctx sdk.Context) []types.TransactionLogs {
 	store := ctx.KVStore(k.storeKey)
@@ -163,6 +451,28 @@ func (k Keeper) GetAccountStorage(ctx sdk.Context, address common.Address) (type
 	return storage, nil
 }
 
+// GetStorageRange returns at most limit storage entries of an account, sorted by store
+// key and starting at the given key. It also returns the key of the entry that follows
+// the range, which is nil if the range reached the end of the storage. The committed
+// storage is read from the KVStore, without the state changes of the current block.
+func (k Keeper) GetStorageRange(ctx sdk.Context, address common.Address, start common.Hash, limit int) (types.Storage, *common.Hash) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.AddressStoragePrefix(address))
+	iterator := store.Iterator(start.Bytes(), nil)
+	defer iterator.Close()
+
+	storage := types.Storage{}
+	for ; iterator.Valid(); iterator.Next() {
+		key := common.BytesToHash(iterator.Key())
+		if len(storage) == limit {
+			return storage, &key
+		}
+
+		storage = append(storage, types.NewState(key, common.BytesToHash(iterator.Value())))
+	}
+
+	return storage, nil
+}
+
 // GetChainConfig gets block height from block consensus hash
 func (k Keeper) GetChainConfig(ctx sdk.Context) (types.ChainConfig, bool) {
 	store := ctx.KVStore(k.storeKey)
@@ -9,6 +9,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/staking"
 
 	"github.com/cosmos/ethermint/app"
 	ethermint "github.com/cosmos/ethermint/types"
@@ -20,6 +21,7 @@ import (
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 )
 
 const addrHex = "0x756F45E3FA69347A9A973A725E3C98bC4db0b4c1"
@@ -43,7 +45,7 @@ func (suite *KeeperTestSuite) SetupTest() {
 
 	suite.app = app.Setup(checkTx)
 	suite.ctx = suite.app.BaseApp.NewContext(checkTx, abci.Header{Height: 1, ChainID: "ethermint-3", Time: time.Now().UTC()})
-	suite.querier = keeper.NewQuerier(*suite.app.EvmKeeper)
+	suite.querier = keeper.NewQuerier(suite.app.EvmKeeper)
 	suite.address = ethcmn.HexToAddress(addrHex)
 
 	balance := sdk.NewCoins(ethermint.NewPhotonCoin(sdk.ZeroInt()))
@@ -150,6 +152,152 @@ func (suite *KeeperTestSuite) TestDBStorage() {
 	suite.app.Commit()
 }
 
+func (suite *KeeperTestSuite) TestBlockGasUsed() {
+	height := suite.ctx.BlockHeight()
+	suite.Require().Zero(suite.app.EvmKeeper.BlockGasUsed())
+
+	gasUsed := suite.app.EvmKeeper.AddBlockGasUsed(21000)
+	suite.Require().Equal(uint64(21000), gasUsed)
+
+	gasUsed = suite.app.EvmKeeper.AddBlockGasUsed(50000)
+	suite.Require().Equal(uint64(71000), gasUsed)
+	suite.Require().Equal(uint64(71000), suite.app.EvmKeeper.BlockGasUsed())
+
+	// the gas used is persisted on EndBlock
+	suite.Require().Zero(suite.app.EvmKeeper.GetBlockGasUsed(suite.ctx, height))
+	suite.app.EvmKeeper.EndBlock(suite.ctx, abci.RequestEndBlock{Height: height})
+	suite.Require().Equal(uint64(71000), suite.app.EvmKeeper.GetBlockGasUsed(suite.ctx, height))
+
+	// other heights are not affected
+	suite.Require().Zero(suite.app.EvmKeeper.GetBlockGasUsed(suite.ctx, height+1))
+
+	// the gas used is reset on BeginBlock
+	suite.app.EvmKeeper.BeginBlock(suite.ctx, abci.RequestBeginBlock{
+		Header: abci.Header{Height: height + 1, LastBlockId: abci.BlockID{Hash: hash}},
+		Hash:   hash,
+	})
+	suite.Require().Zero(suite.app.EvmKeeper.BlockGasUsed())
+}
+
+func (suite *KeeperTestSuite) TestCoinbaseAddress() {
+	pubKey := ed25519.GenPrivKey().PubKey()
+	valAddr := sdk.ValAddress(suite.address.Bytes())
+	consAddr := sdk.ConsAddress(pubKey.Address())
+
+	// no proposer validator
+	suite.Require().Equal(ethcmn.Address{}, suite.app.EvmKeeper.GetCoinbaseAddress(suite.ctx))
+
+	_, found := suite.app.EvmKeeper.GetValidatorOperatorAddress(suite.ctx, consAddr)
+	suite.Require().False(found)
+
+	validator := staking.NewValidator(valAddr, pubKey, staking.Description{})
+	suite.app.StakingKeeper.SetValidator(suite.ctx, validator)
+	suite.app.StakingKeeper.SetValidatorByConsAddr(suite.ctx, validator)
+
+	coinbase, found := suite.app.EvmKeeper.GetValidatorOperatorAddress(suite.ctx, consAddr)
+	suite.Require().True(found)
+	suite.Require().Equal(suite.address, coinbase)
+
+	ctx := suite.ctx.WithBlockHeader(abci.Header{ProposerAddress: consAddr})
+	suite.Require().Equal(suite.address, suite.app.EvmKeeper.GetCoinbaseAddress(ctx))
+}
+
+func (suite *KeeperTestSuite) TestPruneHistory() {
+	txHash := ethcmn.BytesToHash(hash)
+	logs := []*ethtypes.Log{{Address: suite.address, Data: []byte("log"), BlockNumber: 1}}
+	receipts := []types.TxReceipt{types.NewTxReceipt(txHash, 21000, ethtypes.ReceiptStatusSuccessful, nil)}
+
+	// set the block data for heights 1 to 3
+	for height := int64(1); height <= 3; height++ {
+		ctx := suite.ctx.WithBlockHeight(height)
+		txHash := ethcmn.BigToHash(big.NewInt(height))
+
+		suite.Require().NoError(suite.app.EvmKeeper.SetLogs(ctx, txHash, logs))
+		suite.app.EvmKeeper.SetBlockBloom(ctx, height, ethtypes.BytesToBloom([]byte{0x1}))
+		suite.app.EvmKeeper.SetBlockGasUsed(ctx, height, 21000)
+		suite.Require().NoError(suite.app.EvmKeeper.SetBlockReceipts(ctx, height, receipts))
+	}
+
+	suite.app.EvmKeeper.PruneHistory(suite.ctx, 3)
+
+	for height := int64(1); height <= 3; height++ {
+		pruned := height < 3
+
+		logs, err := suite.app.EvmKeeper.GetLogs(suite.ctx, ethcmn.BigToHash(big.NewInt(height)))
+		suite.Require().NoError(err)
+		suite.Require().Equal(pruned, len(logs) == 0)
+
+		_, found := suite.app.EvmKeeper.GetBlockBloom(suite.ctx, height)
+		suite.Require().Equal(!pruned, found)
+
+		suite.Require().Equal(pruned, suite.app.EvmKeeper.GetBlockGasUsed(suite.ctx, height) == 0)
+
+		blockReceipts, err := suite.app.EvmKeeper.GetBlockReceipts(suite.ctx, height)
+		suite.Require().NoError(err)
+		suite.Require().Equal(pruned, len(blockReceipts) == 0)
+	}
+}
+
+func (suite *KeeperTestSuite) TestIndexLogs() {
+	store := suite.ctx.KVStore(suite.app.GetKey(types.StoreKey))
+	emptyTxHash := ethcmn.BytesToHash([]byte("empty"))
+
+	// logs written to the EVM store by a previous version, without the logs height index, and
+	// more than the transactions indexed on a single call
+	logs := []*ethtypes.Log{{Address: suite.address, Data: []byte("log"), BlockNumber: 2}}
+	bz, err := types.MarshalLogs(logs)
+	suite.Require().NoError(err)
+	emptyBz, err := types.MarshalLogs([]*ethtypes.Log{})
+	suite.Require().NoError(err)
+
+	for i := int64(1); i <= 1500; i++ {
+		store.Set(append(types.KeyPrefixLogs, ethcmn.BigToHash(big.NewInt(i)).Bytes()...), bz)
+	}
+	store.Set(append(types.KeyPrefixLogs, emptyTxHash.Bytes()...), emptyBz)
+
+	// the logs are indexed in batches
+	suite.Require().NoError(suite.app.EvmKeeper.IndexLogs(suite.ctx))
+	suite.Require().True(store.Has(types.KeyLogsIndexCursor))
+	suite.Require().False(store.Has(types.KeyLogsIndexed))
+
+	suite.Require().NoError(suite.app.EvmKeeper.IndexLogs(suite.ctx))
+	suite.Require().False(store.Has(types.KeyLogsIndexCursor))
+	suite.Require().True(store.Has(types.KeyLogsIndexed))
+
+	// the empty logs are deleted
+	suite.Require().False(store.Has(append(types.KeyPrefixLogs, emptyTxHash.Bytes()...)))
+
+	// the indexed logs are pruned by the height of the block that emitted them
+	suite.app.EvmKeeper.PruneHistory(suite.ctx, 2)
+
+	indexed, err := suite.app.EvmKeeper.GetLogs(suite.ctx, ethcmn.BigToHash(big.NewInt(1500)))
+	suite.Require().NoError(err)
+	suite.Require().Equal(logs, indexed)
+
+	suite.app.EvmKeeper.PruneHistory(suite.ctx, 3)
+	suite.app.EvmKeeper.PruneHistory(suite.ctx, 3)
+
+	for i := int64(1); i <= 1500; i++ {
+		suite.Require().False(store.Has(append(types.KeyPrefixLogs, ethcmn.BigToHash(big.NewInt(i)).Bytes()...)), i)
+	}
+}
+
+func (suite *KeeperTestSuite) TestIsHistoryPruned() {
+	// archive by default
+	suite.Require().False(suite.app.EvmKeeper.IsHistoryPruned(suite.ctx, 1))
+
+	suite.app.EvmKeeper.PruneHistory(suite.ctx, 6)
+
+	suite.Require().True(suite.app.EvmKeeper.IsHistoryPruned(suite.ctx, 1))
+	suite.Require().True(suite.app.EvmKeeper.IsHistoryPruned(suite.ctx, 5))
+	suite.Require().False(suite.app.EvmKeeper.IsHistoryPruned(suite.ctx, 6))
+	suite.Require().False(suite.app.EvmKeeper.IsHistoryPruned(suite.ctx, 10))
+
+	// the pruned height doesn't decrease
+	suite.app.EvmKeeper.PruneHistory(suite.ctx, 3)
+	suite.Require().True(suite.app.EvmKeeper.IsHistoryPruned(suite.ctx, 5))
+}
+
 func (suite *KeeperTestSuite) TestChainConfig() {
 	config, found := suite.app.EvmKeeper.GetChainConfig(suite.ctx)
 	suite.Require().True(found)
@@ -1,19 +1,22 @@
 package keeper
 
 import (
+	"errors"
+
 	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
-	ethermint "github.com/cosmos/ethermint/types"
 	"github.com/cosmos/ethermint/x/evm/types"
 )
 
 // EthereumTx implements the Msg/EthereumTx gRPC method.
-func (k Keeper) EthereumTx(ctx sdk.Context, msg types.MsgEthereumTx) (*sdk.Result, error) {
-	// parse the chainID from a string to a base-10 integer
-	chainIDEpoch, err := ethermint.ParseChainID(ctx.ChainID())
+func (k *Keeper) EthereumTx(ctx sdk.Context, msg types.MsgEthereumTx) (*sdk.Result, error) {
+	// get the EIP-155 chain ID from the params or the chain-id epoch
+	chainIDEpoch, err := k.ChainID(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -44,6 +47,7 @@ func (k Keeper) EthereumTx(ctx sdk.Context, msg types.MsgEthereumTx) (*sdk.Resul
 		ChainID:      chainIDEpoch,
 		TxHash:       &ethHash,
 		Sender:       sender,
+		Coinbase:     k.GetCoinbaseAddress(ctx),
 		Simulate:     ctx.IsCheckTx(),
 	}
 
@@ -63,6 +67,18 @@ func (k Keeper) EthereumTx(ctx sdk.Context, msg types.MsgEthereumTx) (*sdk.Resul
 
 	executionResult, err := st.TransitionDb(ctx, config)
 	if err != nil {
+		if !st.Simulate {
+			// the gas of a failed tx is still charged, so it's added to the block gas used
+			// along with a failed receipt as the tx is still included in the block
+			gasUsed := ctx.GasMeter().GasConsumed()
+			k.AddBlockGasUsed(gasUsed)
+			k.AddTxReceipt(types.NewTxReceipt(ethHash, gasUsed, ethtypes.ReceiptStatusFailed, nil))
+
+			var blocked *types.BlockedTransfer
+			if errors.As(err, &blocked) {
+				k.AddBlockedTransfer(ethHash, *blocked)
+			}
+		}
 		return nil, err
 	}
 
@@ -75,6 +91,24 @@ func (k Keeper) EthereumTx(ctx sdk.Context, msg types.MsgEthereumTx) (*sdk.Resul
 		if err != nil {
 			panic(err)
 		}
+
+		var contractAddress *common.Address
+		if recipient == nil {
+			addr := ethcrypto.CreateAddress(sender, msg.Data.AccountNonce)
+			contractAddress = &addr
+		}
+
+		// update the cumulative gas used on the block
+		gasUsed := ctx.GasMeter().GasConsumed()
+		k.AddBlockGasUsed(gasUsed)
+
+		k.AddTxReceipt(types.NewTxReceipt(ethHash, gasUsed, ethtypes.ReceiptStatusSuccessful, contractAddress))
+		k.AddAccountDiffs(executionResult.StateDiff)
+
+		// refund the fees of the unused gas deducted by the AnteHandler
+		if err := k.refundGas(ctx, sdk.AccAddress(sender.Bytes()), recipient, msg.Data.GasLimit, st.Price); err != nil {
+			return nil, err
+		}
 	}
 
 	ctx.EventManager().EmitEvents(sdk.Events{
@@ -1,6 +1,8 @@
 package keeper
 
 import (
+	"math/big"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/cosmos/ethermint/x/evm/types"
@@ -15,3 +17,8 @@ func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
 func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
 	k.CommitStateDB.WithContext(ctx).SetParams(params)
 }
+
+// ChainID returns the EIP-155 chain ID used to sign and verify the Ethereum transactions.
+func (k Keeper) ChainID(ctx sdk.Context) (*big.Int, error) {
+	return k.GetParams(ctx).EIP155ChainID(ctx.ChainID())
+}
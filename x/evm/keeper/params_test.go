@@ -1,6 +1,9 @@
 package keeper_test
 
 import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	"github.com/cosmos/cosmos-sdk/x/params"
+
 	"github.com/cosmos/ethermint/x/evm/types"
 )
 
@@ -12,3 +15,26 @@ func (suite *KeeperTestSuite) TestParams() {
 	newParams := suite.app.EvmKeeper.GetParams(suite.ctx)
 	suite.Require().Equal(newParams, params)
 }
+
+func (suite *KeeperTestSuite) TestParamsNotSet() {
+	evmParams := types.DefaultParams()
+	evmParams.EvmDenom = "ara"
+	evmParams.ChainID = 9000
+	suite.app.EvmKeeper.SetParams(suite.ctx, evmParams)
+
+	// remove the parameters that aren't set on the chains started before they were added
+	store := prefix.NewStore(suite.ctx.KVStore(suite.app.GetKey(params.StoreKey)), []byte(types.DefaultParamspace+"/"))
+	for _, key := range [][]byte{
+		types.ParamStoreKeyPaymaster, types.ParamStoreKeyDeployers, types.ParamStoreKeyCodeHashes,
+		types.ParamStoreKeyBlocklist, types.ParamStoreKeyChainID,
+	} {
+		store.Delete(key)
+	}
+
+	expParams := types.DefaultParams()
+	expParams.EvmDenom = "ara"
+
+	suite.Require().NotPanics(func() {
+		suite.Require().Equal(expParams, suite.app.EvmKeeper.GetParams(suite.ctx))
+	})
+}
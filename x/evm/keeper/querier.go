@@ -7,7 +7,9 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/staking"
 
+	ethermint "github.com/cosmos/ethermint/types"
 	"github.com/cosmos/ethermint/utils"
 	"github.com/cosmos/ethermint/x/evm/types"
 
@@ -17,8 +19,12 @@ import (
 )
 
 // NewQuerier is the module level router for state queries
-func NewQuerier(keeper Keeper) sdk.Querier {
-	return func(ctx sdk.Context, path []string, _ abci.RequestQuery) ([]byte, error) {
+func NewQuerier(k *Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+		// the keeper is read on each query as the node settings (i.e the history retention)
+		// can be set after the querier is created
+		keeper := *k
+
 		if len(path) < 1 {
 			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
 				"Insufficient parameters, at least 1 parameter is required")
@@ -43,6 +49,26 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryLogs(ctx, keeper)
 		case types.QueryAccount:
 			return queryAccount(ctx, path, keeper)
+		case types.QueryBlockGasUsed:
+			return queryBlockGasUsed(ctx, path, keeper)
+		case types.QueryCoinbase:
+			return queryCoinbase(ctx, path, keeper)
+		case types.QueryBlockReceipts:
+			return queryBlockReceipts(ctx, path, keeper)
+		case types.QueryFeeAllowance:
+			return queryFeeAllowance(ctx, path, keeper)
+		case types.QueryFeeAllowances:
+			return queryFeeAllowances(ctx, keeper)
+		case types.QueryParams:
+			return queryParams(ctx, keeper)
+		case types.QueryStorageRange:
+			return queryStorageRange(ctx, req, keeper)
+		case types.QueryDump:
+			return queryDump(ctx, req, keeper)
+		case types.QueryBlockLogs:
+			return queryBlockLogs(ctx, path, keeper)
+		case types.QueryStateDiff:
+			return queryStateDiff(ctx, path, keeper)
 		default:
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown query endpoint")
 		}
@@ -148,6 +174,10 @@ func queryBlockBloom(ctx sdk.Context, path []string, keeper Keeper) ([]byte, err
 		return nil, fmt.Errorf("could not unmarshal block height: %w", err)
 	}
 
+	if err := checkHistoryPruned(ctx, keeper, num); err != nil {
+		return nil, err
+	}
+
 	bloom, found := keeper.GetBlockBloom(ctx.WithBlockHeight(num), num)
 	if !found {
 		return nil, fmt.Errorf("block bloom not found for height %d", num)
@@ -162,6 +192,332 @@ func queryBlockBloom(ctx sdk.Context, path []string, keeper Keeper) ([]byte, err
 	return bz, nil
 }
 
+func queryBlockGasUsed(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	if len(path) < 2 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+			"Insufficient parameters, at least 2 parameters is required")
+	}
+
+	num, err := strconv.ParseInt(path[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal block height: %w", err)
+	}
+
+	if err := checkHistoryPruned(ctx, keeper, num); err != nil {
+		return nil, err
+	}
+
+	res := types.QueryResBlockGasUsed{GasUsed: keeper.GetBlockGasUsed(ctx, num)}
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryCoinbase(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	if len(path) < 2 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+			"Insufficient parameters, at least 2 parameters is required")
+	}
+
+	consAddr, err := sdk.ConsAddressFromHex(path[1])
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+
+	coinbase, found := keeper.GetValidatorOperatorAddress(ctx, consAddr)
+	if !found {
+		return nil, sdkerrors.Wrapf(staking.ErrNoValidatorFound, "consensus address %s", consAddr)
+	}
+
+	res := types.QueryResCoinbase{Coinbase: coinbase.Hex()}
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryBlockReceipts(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	if len(path) < 2 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+			"Insufficient parameters, at least 2 parameters is required")
+	}
+
+	num, err := strconv.ParseInt(path[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal block height: %w", err)
+	}
+
+	if err := checkHistoryPruned(ctx, keeper, num); err != nil {
+		return nil, err
+	}
+
+	receipts, err := keeper.GetBlockReceipts(ctx, num)
+	if err != nil {
+		return nil, err
+	}
+
+	// the bloom is empty if the block doesn't contain any EVM logs
+	bloom, _ := keeper.GetBlockBloom(ctx, num)
+
+	res := types.QueryResBlockReceipts{
+		Height:   num,
+		Bloom:    bloom,
+		GasUsed:  keeper.GetBlockGasUsed(ctx, num),
+		Receipts: receipts,
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryFeeAllowance(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	if len(path) < 3 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+			"Insufficient parameters, at least 3 parameters is required")
+	}
+
+	sender := ethcmn.HexToAddress(path[1])
+	contract := ethcmn.HexToAddress(path[2])
+
+	spendLimit, found := keeper.GetFeeAllowance(ctx, sender, contract)
+	if !found {
+		return nil, sdkerrors.Wrapf(types.ErrFeeAllowanceNotFound, "sender %s, contract %s", sender, contract)
+	}
+
+	res := types.NewFeeAllowance(sender, contract, spendLimit)
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryFeeAllowances(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+	res := types.QueryResFeeAllowances{
+		Paymaster:  keeper.GetParams(ctx).Paymaster,
+		Allowances: keeper.GetAllFeeAllowances(ctx),
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryParams(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+	params := keeper.GetParams(ctx)
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryStorageRange(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, error) {
+	var params types.QueryStorageRangeParams
+	if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	addr, err := ethermint.ParseHexAddress(params.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	var start ethcmn.Hash
+	if params.StartKey != "" {
+		if err := types.ValidateHash(params.StartKey); err != nil {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid start key: %s", err)
+		}
+
+		start = ethcmn.HexToHash(params.StartKey)
+	}
+
+	limit := params.Limit
+	switch {
+	case limit == 0:
+		limit = types.DefaultStorageRangeLimit
+	case limit > types.MaxStorageRangeLimit:
+		return nil, sdkerrors.Wrapf(
+			sdkerrors.ErrInvalidRequest, "limit %d exceeds the maximum of %d storage entries", limit, types.MaxStorageRangeLimit,
+		)
+	}
+
+	// the storage entries are keyed by their store key, i.e the hash of the address and the
+	// slot, as the slot preimages are not persisted
+	storage, nextKey := keeper.GetStorageRange(ctx, addr, start, int(limit))
+
+	res := types.QueryResStorageRange{Storage: storage}
+	if nextKey != nil {
+		res.NextKey = nextKey.String()
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryDump(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, error) {
+	var params types.QueryDumpParams
+	if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	var start []byte
+	if params.StartKey != "" {
+		// the start key is accepted in both the hex and the Bech32 formats, as the REST
+		// clients may send the Bech32 address of the account
+		addr, err := ethermint.ParseAccAddress(params.StartKey)
+		if err != nil {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid start key: %s", err)
+		}
+
+		start = addr.Bytes()
+	}
+
+	limit := params.Limit
+	switch {
+	case limit == 0:
+		limit = types.DefaultDumpLimit
+	case limit > types.MaxDumpLimit:
+		return nil, sdkerrors.Wrapf(
+			sdkerrors.ErrInvalidRequest, "limit %d exceeds the maximum of %d accounts", limit, types.MaxDumpLimit,
+		)
+	}
+
+	res := types.QueryResDump{Accounts: []types.DumpAccount{}}
+	nextKey := keeper.CommitStateDB.WithContext(ctx).DumpAccounts(
+		params.ExcludeCode, params.ExcludeStorage, start, int(limit), func(account types.DumpAccount) {
+			res.Accounts = append(res.Accounts, account)
+		},
+	)
+
+	if nextKey != nil {
+		res.NextKey = ethcmn.BytesToAddress(nextKey).String()
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryBlockLogs(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	if len(path) < 2 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+			"Insufficient parameters, at least 2 parameters is required")
+	}
+
+	if err := types.ValidateHash(path[1]); err != nil {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid block hash: %s", err)
+	}
+
+	height, found := keeper.GetBlockHash(ctx, ethcmn.FromHex(path[1]))
+	if !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "block height not found for hash %s", path[1])
+	}
+
+	if err := checkHistoryPruned(ctx, keeper, height); err != nil {
+		return nil, err
+	}
+
+	receipts, err := keeper.GetBlockReceipts(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	// the logs are returned in the order of the EVM transactions of the block
+	res := types.QueryResBlockLogs{TxLogs: make([]types.TransactionLogs, 0, len(receipts))}
+	for _, receipt := range receipts {
+		txHash := ethcmn.HexToHash(receipt.Hash)
+
+		logs, err := keeper.GetLogs(ctx, txHash)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(logs) == 0 {
+			continue
+		}
+
+		res.TxLogs = append(res.TxLogs, types.NewTransactionLogs(txHash, logs))
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryStateDiff(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	if len(path) < 2 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+			"Insufficient parameters, at least 2 parameters is required")
+	}
+
+	num, err := strconv.ParseInt(path[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal block height: %w", err)
+	}
+
+	if num < 1 || num > ctx.BlockHeight() {
+		return nil, sdkerrors.Wrapf(
+			sdkerrors.ErrInvalidRequest, "invalid height %d, latest height is %d", num, ctx.BlockHeight(),
+		)
+	}
+
+	if err := checkHistoryPruned(ctx, keeper, num); err != nil {
+		return nil, err
+	}
+
+	accounts, err := keeper.GetBlockStateDiff(ctx, num)
+	if err != nil {
+		return nil, err
+	}
+
+	res := types.QueryResStateDiff{Accounts: accounts}
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+// checkHistoryPruned returns an error if the block data of the given height has been pruned.
+func checkHistoryPruned(ctx sdk.Context, keeper Keeper, height int64) error {
+	if !keeper.IsHistoryPruned(ctx, height) {
+		return nil
+	}
+
+	return sdkerrors.Wrapf(
+		types.ErrHistoryPruned,
+		"block %d is lower than the pruned height %d, query it at its own height on an archive node",
+		height, keeper.GetHistoryPrunedHeight(ctx),
+	)
+}
+
 func queryTransactionLogs(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
 	if len(path) < 2 {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
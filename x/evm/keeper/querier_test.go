@@ -3,7 +3,10 @@ package keeper_test
 import (
 	"math/big"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
 	"github.com/cosmos/ethermint/x/evm/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -34,6 +37,28 @@ func (suite *KeeperTestSuite) TestQuerier() {
 		}, true},
 		{"logs", []string{types.QueryLogs, "0x0"}, func() {}, true},
 		{"account", []string{types.QueryAccount, "0x0"}, func() {}, true},
+		{"block gas used", []string{types.QueryBlockGasUsed, "4"}, func() {
+			suite.app.EvmKeeper.SetBlockGasUsed(suite.ctx, 4, 21000)
+		}, true},
+		{"block gas used, invalid height", []string{types.QueryBlockGasUsed, "four"}, func() {}, false},
+		{"block receipts", []string{types.QueryBlockReceipts, "4"}, func() {
+			receipts := []types.TxReceipt{types.NewTxReceipt(ethcmn.BytesToHash(hash), 21000, ethtypes.ReceiptStatusSuccessful, nil)}
+			suite.Require().NoError(suite.app.EvmKeeper.SetBlockReceipts(suite.ctx, 4, receipts))
+		}, true},
+		{"block receipts, empty", []string{types.QueryBlockReceipts, "5"}, func() {}, true},
+		{"block receipts, pruned", []string{types.QueryBlockReceipts, "1"}, func() {
+			suite.app.EvmKeeper.PruneHistory(suite.ctx, 2)
+		}, false},
+		{"block receipts, invalid height", []string{types.QueryBlockReceipts, "four"}, func() {}, false},
+		{"coinbase, validator not found", []string{types.QueryCoinbase, "0102030405060708090A0B0C0D0E0F1011121314"}, func() {}, false},
+		{"coinbase, invalid address", []string{types.QueryCoinbase, "0xinvalid"}, func() {}, false},
+		{"fee allowance", []string{types.QueryFeeAllowance, "0x1", "0x2"}, func() {
+			suite.app.EvmKeeper.SetFeeAllowance(suite.ctx, ethcmn.HexToAddress("0x1"), ethcmn.HexToAddress("0x2"), sdk.NewInt(100))
+		}, true},
+		{"fee allowance, not found", []string{types.QueryFeeAllowance, "0x1", "0x3"}, func() {}, false},
+		{"fee allowance, insufficient parameters", []string{types.QueryFeeAllowance, "0x1"}, func() {}, false},
+		{"fee allowances", []string{types.QueryFeeAllowances}, func() {}, true},
+		{"params", []string{types.QueryParams}, func() {}, true},
 		{"unknown request", []string{"other"}, func() {}, false},
 	}
 
@@ -59,3 +84,154 @@ func (suite *KeeperTestSuite) TestQuerier() {
 		})
 	}
 }
+
+func (suite *KeeperTestSuite) TestQueryStorageRange() {
+	for i := int64(1); i <= 3; i++ {
+		suite.app.EvmKeeper.SetState(suite.ctx, suite.address, ethcmn.BigToHash(big.NewInt(i)), ethcmn.BigToHash(big.NewInt(i*10)))
+	}
+	suite.Require().NoError(suite.app.EvmKeeper.Finalise(suite.ctx, false))
+
+	storage, err := suite.app.EvmKeeper.GetAccountStorage(suite.ctx, suite.address)
+	suite.Require().NoError(err)
+	suite.Require().Len(storage, 3)
+
+	queryStorageRange := func(startKey string, limit uint64) (types.QueryResStorageRange, error) {
+		data := suite.app.Codec().MustMarshalJSON(types.NewQueryStorageRangeParams(suite.address.Hex(), startKey, limit))
+
+		var res types.QueryResStorageRange
+		bz, err := suite.querier(suite.ctx, []string{types.QueryStorageRange}, abci.RequestQuery{Data: data})
+		if err == nil {
+			suite.app.Codec().MustUnmarshalJSON(bz, &res)
+		}
+
+		return res, err
+	}
+
+	res, err := queryStorageRange("", 2)
+	suite.Require().NoError(err)
+	suite.Require().Equal(storage[:2], res.Storage)
+	suite.Require().Equal(storage[2].Key, res.NextKey)
+
+	res, err = queryStorageRange(res.NextKey, 0)
+	suite.Require().NoError(err)
+	suite.Require().Equal(storage[2:], res.Storage)
+	suite.Require().Empty(res.NextKey)
+
+	_, err = queryStorageRange("0x01", 0)
+	suite.Require().Error(err)
+
+	_, err = queryStorageRange("", types.MaxStorageRangeLimit+1)
+	suite.Require().Error(err)
+
+	_, err = suite.querier(suite.ctx, []string{types.QueryStorageRange}, abci.RequestQuery{Data: []byte("invalid")})
+	suite.Require().Error(err)
+}
+
+func (suite *KeeperTestSuite) TestQueryDump() {
+	contract := ethcmn.BytesToAddress([]byte("contract"))
+	suite.app.EvmKeeper.SetCode(suite.ctx, contract, []byte("code"))
+	suite.app.EvmKeeper.SetState(suite.ctx, contract, ethcmn.BigToHash(big.NewInt(1)), ethcmn.BigToHash(big.NewInt(7)))
+	suite.Require().NoError(suite.app.EvmKeeper.Finalise(suite.ctx, false))
+
+	queryDump := func(params types.QueryDumpParams) (types.QueryResDump, error) {
+		data := suite.app.Codec().MustMarshalJSON(params)
+
+		var res types.QueryResDump
+		bz, err := suite.querier(suite.ctx, []string{types.QueryDump}, abci.RequestQuery{Data: data})
+		if err == nil {
+			suite.app.Codec().MustUnmarshalJSON(bz, &res)
+		}
+
+		return res, err
+	}
+
+	res, err := queryDump(types.NewQueryDumpParams("", 0, false, false))
+	suite.Require().NoError(err)
+	suite.Require().Len(res.Accounts, 2)
+	suite.Require().Empty(res.NextKey)
+
+	res, err = queryDump(types.NewQueryDumpParams("", 1, false, true))
+	suite.Require().NoError(err)
+	suite.Require().Len(res.Accounts, 1)
+	suite.Require().Nil(res.Accounts[0].Storage)
+	suite.Require().NotEmpty(res.NextKey)
+
+	next, err := queryDump(types.NewQueryDumpParams(res.NextKey, 0, false, false))
+	suite.Require().NoError(err)
+	suite.Require().Len(next.Accounts, 1)
+	suite.Require().Equal(res.NextKey, next.Accounts[0].Address)
+	suite.Require().Empty(next.NextKey)
+
+	// the start key can also be given in the Bech32 format
+	bech32Next, err := queryDump(types.NewQueryDumpParams(sdk.AccAddress(ethcmn.HexToAddress(res.NextKey).Bytes()).String(), 0, false, false))
+	suite.Require().NoError(err)
+	suite.Require().Equal(next, bech32Next)
+
+	_, err = queryDump(types.NewQueryDumpParams("0x01", 0, false, false))
+	suite.Require().Error(err)
+
+	_, err = queryDump(types.NewQueryDumpParams("", types.MaxDumpLimit+1, false, false))
+	suite.Require().Error(err)
+}
+
+func (suite *KeeperTestSuite) TestQueryBlockLogs() {
+	txHash := ethcmn.BytesToHash([]byte("tx"))
+	emptyTxHash := ethcmn.BytesToHash([]byte("empty tx"))
+	blockHash := ethcmn.BytesToHash(hash)
+	logs := []*ethtypes.Log{{Address: suite.address, Topics: []ethcmn.Hash{ethcmn.BytesToHash([]byte("topic"))}, Data: []byte("log"), TxHash: txHash, BlockNumber: 1}}
+
+	suite.Require().NoError(suite.app.EvmKeeper.SetLogs(suite.ctx, txHash, logs))
+	suite.app.EvmKeeper.SetBlockHash(suite.ctx, blockHash.Bytes(), 1)
+	receipts := []types.TxReceipt{
+		types.NewTxReceipt(emptyTxHash, 21000, ethtypes.ReceiptStatusSuccessful, nil),
+		types.NewTxReceipt(txHash, 30000, ethtypes.ReceiptStatusSuccessful, nil),
+	}
+	suite.Require().NoError(suite.app.EvmKeeper.SetBlockReceipts(suite.ctx, 1, receipts))
+
+	bz, err := suite.querier(suite.ctx, []string{types.QueryBlockLogs, blockHash.String()}, abci.RequestQuery{})
+	suite.Require().NoError(err)
+
+	var res types.QueryResBlockLogs
+	suite.app.Codec().MustUnmarshalJSON(bz, &res)
+	suite.Require().Equal([]types.TransactionLogs{types.NewTransactionLogs(txHash, logs)}, res.TxLogs)
+
+	_, err = suite.querier(suite.ctx, []string{types.QueryBlockLogs, ethcmn.BytesToHash([]byte("unknown")).String()}, abci.RequestQuery{})
+	suite.Require().Error(err)
+
+	_, err = suite.querier(suite.ctx, []string{types.QueryBlockLogs, "0x01"}, abci.RequestQuery{})
+	suite.Require().Error(err)
+}
+
+func (suite *KeeperTestSuite) TestQueryStateDiff() {
+	ctx := suite.ctx.WithBlockHeight(10)
+	diffs := []types.AccountDiff{
+		{Address: suite.address.String(), Storage: types.Storage{types.NewState(ethcmn.BytesToHash([]byte("key")), ethcmn.Hash{})}},
+	}
+	suite.Require().NoError(suite.app.EvmKeeper.SetBlockStateDiff(ctx, 5, diffs))
+
+	bz, err := suite.querier(ctx, []string{types.QueryStateDiff, "5"}, abci.RequestQuery{})
+	suite.Require().NoError(err)
+
+	var res types.QueryResStateDiff
+	suite.app.Codec().MustUnmarshalJSON(bz, &res)
+	suite.Require().Equal(diffs, res.Accounts)
+
+	// a block without EVM state changes
+	bz, err = suite.querier(ctx, []string{types.QueryStateDiff, "6"}, abci.RequestQuery{})
+	suite.Require().NoError(err)
+
+	res = types.QueryResStateDiff{}
+	suite.app.Codec().MustUnmarshalJSON(bz, &res)
+	suite.Require().Empty(res.Accounts)
+
+	_, err = suite.querier(ctx, []string{types.QueryStateDiff, "11"}, abci.RequestQuery{})
+	suite.Require().Error(err)
+
+	_, err = suite.querier(ctx, []string{types.QueryStateDiff, "five"}, abci.RequestQuery{})
+	suite.Require().Error(err)
+
+	suite.app.EvmKeeper.PruneHistory(ctx, 6)
+
+	_, err = suite.querier(ctx, []string{types.QueryStateDiff, "5"}, abci.RequestQuery{})
+	suite.Require().True(types.ErrHistoryPruned.Is(err))
+}
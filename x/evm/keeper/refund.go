@@ -0,0 +1,48 @@
+package keeper
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/cosmos/ethermint/x/evm/types"
+)
+
+// refundGas refunds the fees of the gas that hasn't been consumed by the transaction, i.e
+// (gas limit - gas used) * gas price, to the sender or to the paymaster if it paid the fees
+// on behalf of the sender. In the latter case, the refund is also credited back to the fee
+// allowance of the sender for the contract, which is charged for the full gas limit by the
+// AnteHandler. The fees are sent from the fee collector module account, where the fees for
+// the full gas limit are deducted to by the AnteHandler.
+//
+// NOTE: the gas used must already include the gas refunded by the EVM.
+func (k *Keeper) refundGas(
+	ctx sdk.Context, sender sdk.AccAddress, contract *common.Address, gasLimit uint64, gasPrice *big.Int,
+) error {
+	gasUsed := ctx.GasMeter().GasConsumed()
+	if gasUsed >= gasLimit || gasPrice.Sign() == 0 {
+		return nil
+	}
+
+	// the refund must not consume gas from the transaction, as the gas used is already settled
+	ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+
+	amount := new(big.Int).Mul(new(big.Int).SetUint64(gasLimit-gasUsed), gasPrice)
+
+	recipient := sender
+	if payer := types.FeePayer(ctx); payer != nil && contract != nil {
+		recipient = payer
+		k.RefundFeeAllowance(ctx, common.BytesToAddress(sender.Bytes()), *contract, amount)
+	}
+	refund := sdk.NewCoins(sdk.NewCoin(k.GetParams(ctx).EvmDenom, sdk.NewIntFromBigInt(amount)))
+
+	if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, auth.FeeCollectorName, recipient, refund); err != nil {
+		return sdkerrors.Wrapf(err, "failed to refund %s of unused gas to %s", refund, recipient)
+	}
+
+	return nil
+}
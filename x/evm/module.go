@@ -61,7 +61,7 @@ func (AppModuleBasic) GetQueryCmd(cdc *codec.Codec) *cobra.Command {
 
 // GetTxCmd Gets the root tx command of this module
 func (AppModuleBasic) GetTxCmd(cdc *codec.Codec) *cobra.Command {
-	return nil
+	return cli.GetTxCmd(cdc)
 }
 
 //____________________________________________________________________________
@@ -109,7 +109,7 @@ func (am AppModule) QuerierRoute() string {
 
 // NewQuerierHandler sets up new querier handler for module
 func (am AppModule) NewQuerierHandler() sdk.Querier {
-	return keeper.NewQuerier(*am.keeper)
+	return keeper.NewQuerier(am.keeper)
 }
 
 // BeginBlock function for module at start of each block
@@ -0,0 +1,135 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// BlockedTransfer defines an EVM value transfer from or to an address on the
+// BlockedAddresses parameter. It's returned as the error of the transaction that
+// attempted the transfer and it's emitted as an event on EndBlock.
+type BlockedTransfer struct {
+	TxHash    common.Hash
+	Sender    common.Address
+	Recipient common.Address
+	Amount    *big.Int
+	// Blocked is either the sender or the recipient address
+	Blocked common.Address
+}
+
+// NewBlockedTransfer returns the BlockedTransfer of a value transfer if either the sender
+// or the recipient are blocked. It returns nil otherwise.
+func NewBlockedTransfer(params Params, sender, recipient common.Address, amount *big.Int) *BlockedTransfer {
+	if amount == nil || amount.Sign() <= 0 {
+		return nil
+	}
+
+	transfer := &BlockedTransfer{
+		Sender:    sender,
+		Recipient: recipient,
+		Amount:    amount,
+	}
+
+	switch {
+	case params.IsBlocked(sender):
+		transfer.Blocked = sender
+	case params.IsBlocked(recipient):
+		transfer.Blocked = recipient
+	default:
+		return nil
+	}
+
+	return transfer
+}
+
+// Error implements the error interface.
+func (bt *BlockedTransfer) Error() string {
+	return fmt.Sprintf(
+		"value transfer of %s from %s to %s: %s: %s", bt.Amount, bt.Sender, bt.Recipient, ErrAddressBlocked, bt.Blocked,
+	)
+}
+
+// Cause returns the ErrAddressBlocked registered error, which defines the ABCI error code.
+func (bt *BlockedTransfer) Cause() error {
+	return ErrAddressBlocked
+}
+
+// Unwrap implements the errors.Unwrap interface.
+func (bt *BlockedTransfer) Unwrap() error {
+	return ErrAddressBlocked
+}
+
+// Event returns the event emitted for the blocked transfer.
+func (bt BlockedTransfer) Event() sdk.Event {
+	return sdk.NewEvent(
+		EventTypeBlockedTransfer,
+		sdk.NewAttribute(AttributeKeyTxHash, bt.TxHash.Hex()),
+		sdk.NewAttribute(sdk.AttributeKeySender, bt.Sender.Hex()),
+		sdk.NewAttribute(AttributeKeyRecipient, bt.Recipient.Hex()),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, bt.Amount.String()),
+		sdk.NewAttribute(AttributeKeyBlockedAddress, bt.Blocked.Hex()),
+	)
+}
+
+var _ vm.Tracer = &transferGuard{}
+
+// transferGuard wraps the EVM value transfer function in order to detect the value
+// transfers from and to the blocked addresses, including the internal ones. The balance
+// sent to the beneficiary of the SELFDESTRUCT opcode doesn't go through the transfer
+// function, so the guard also traces the opcode.
+type transferGuard struct {
+	params Params
+	// blocked is the first blocked transfer attempted by the transaction
+	blocked *BlockedTransfer
+}
+
+// transfer implements vm.TransferFunc. The blocked transfers are recorded so that the
+// transaction fails after the execution, which reverts all of its state changes.
+func (tg *transferGuard) transfer(db vm.StateDB, sender, recipient common.Address, amount *big.Int) {
+	if tg.blocked == nil {
+		tg.blocked = NewBlockedTransfer(tg.params, sender, recipient, amount)
+	}
+
+	core.Transfer(db, sender, recipient, amount)
+}
+
+// CaptureStart implements vm.Tracer.
+func (tg *transferGuard) CaptureStart(common.Address, common.Address, bool, []byte, uint64, *big.Int) error {
+	return nil
+}
+
+// CaptureState implements vm.Tracer. It records the balance sent by the SELFDESTRUCT opcode
+// to its beneficiary as a value transfer, unless the opcode fails before its execution.
+func (tg *transferGuard) CaptureState(
+	env *vm.EVM, _ uint64, op vm.OpCode, _, _ uint64, _ *vm.Memory, stack *vm.Stack,
+	_ *vm.ReturnStack, _ []byte, contract *vm.Contract, _ int, err error,
+) error {
+	if err != nil || op != vm.SELFDESTRUCT || tg.blocked != nil || len(stack.Data()) < 1 {
+		return nil
+	}
+
+	sender := contract.Address()
+	beneficiary := common.Address(stack.Back(0).Bytes20())
+	tg.blocked = NewBlockedTransfer(tg.params, sender, beneficiary, env.StateDB.GetBalance(sender))
+
+	return nil
+}
+
+// CaptureFault implements vm.Tracer.
+func (tg *transferGuard) CaptureFault(
+	*vm.EVM, uint64, vm.OpCode, uint64, uint64, *vm.Memory, *vm.Stack, *vm.ReturnStack, *vm.Contract, int, error,
+) error {
+	return nil
+}
+
+// CaptureEnd implements vm.Tracer.
+func (tg *transferGuard) CaptureEnd([]byte, uint64, time.Duration, error) error {
+	return nil
+}
@@ -12,6 +12,8 @@ var ModuleCdc = codec.New()
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgEthereumTx{}, "ethermint/MsgEthereumTx", nil)
 	cdc.RegisterConcrete(MsgEthermint{}, "ethermint/MsgEthermint", nil)
+	cdc.RegisterConcrete(MsgGrantFeeAllowance{}, "ethermint/MsgGrantFeeAllowance", nil)
+	cdc.RegisterConcrete(MsgRevokeFeeAllowance{}, "ethermint/MsgRevokeFeeAllowance", nil)
 	cdc.RegisterConcrete(TxData{}, "ethermint/TxData", nil)
 	cdc.RegisterConcrete(ChainConfig{}, "ethermint/ChainConfig", nil)
 }
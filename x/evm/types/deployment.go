@@ -0,0 +1,146 @@
+package types
+
+import (
+	"math/big"
+	"time"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var _ vm.Tracer = &deploymentTracer{}
+
+// deploymentTracer is a vm.Tracer that records the contracts deployed with the CREATE and
+// CREATE2 opcodes during the execution of a transaction, in order to enforce the contract
+// deployment allowlists from the parameters.
+type deploymentTracer struct {
+	params Params
+	// err is the error of the first deployment from a deployer that isn't allowed
+	err error
+	// created are the addresses of the contracts deployed by the transaction
+	created []common.Address
+}
+
+// newDeploymentTracer returns a new deploymentTracer for the given parameters.
+func newDeploymentTracer(params Params) *deploymentTracer {
+	return &deploymentTracer{
+		params: params,
+	}
+}
+
+// CaptureStart implements vm.Tracer. The deployer of a contract creation transaction is
+// validated before the execution.
+func (dt *deploymentTracer) CaptureStart(common.Address, common.Address, bool, []byte, uint64, *big.Int) error {
+	return nil
+}
+
+// CaptureState implements vm.Tracer. It validates the deployer of the CREATE and CREATE2
+// opcodes and records the address of the contract to be deployed. The opcodes that fail
+// before their execution (i.e with a stack underflow) don't deploy a contract and are skipped.
+func (dt *deploymentTracer) CaptureState(
+	env *vm.EVM, _ uint64, op vm.OpCode, _, _ uint64, memory *vm.Memory, stack *vm.Stack,
+	_ *vm.ReturnStack, _ []byte, contract *vm.Contract, _ int, err error,
+) error {
+	if err != nil || (op != vm.CREATE && op != vm.CREATE2) {
+		return nil
+	}
+
+	// stack: value, offset, size (, salt)
+	minStack := 3
+	if op == vm.CREATE2 {
+		minStack = 4
+	}
+
+	if len(stack.Data()) < minStack {
+		return nil
+	}
+
+	deployer := contract.Address()
+	if dt.err == nil && !dt.params.IsDeployerAllowed(deployer) {
+		dt.err = sdkerrors.Wrapf(ErrDeployerNotAllowed, "%s (%s)", deployer, op)
+	}
+
+	switch op {
+	case vm.CREATE:
+		nonce := env.StateDB.GetNonce(deployer)
+		dt.created = append(dt.created, crypto.CreateAddress(deployer, nonce))
+	case vm.CREATE2:
+		offset, size := stack.Back(1), stack.Back(2)
+		if !offset.IsUint64() || !size.IsUint64() {
+			return nil
+		}
+
+		initCode, ok := memorySlice(memory, offset.Uint64(), size.Uint64())
+		if !ok {
+			return nil
+		}
+
+		salt := stack.Back(3).Bytes32()
+		dt.created = append(dt.created, crypto.CreateAddress2(deployer, salt, crypto.Keccak256(initCode)))
+	}
+
+	return nil
+}
+
+// memorySlice returns the memory range of the given offset and size. The memory is expanded
+// to fit the range before the opcode is traced, so it's only out of bounds if the opcode
+// fails.
+func memorySlice(memory *vm.Memory, offset, size uint64) ([]byte, bool) {
+	if size == 0 {
+		return nil, true
+	}
+
+	end := offset + size
+	if end < offset || end > uint64(memory.Len()) {
+		return nil, false
+	}
+
+	return memory.GetPtr(int64(offset), int64(size)), true
+}
+
+// CaptureFault implements vm.Tracer.
+func (dt *deploymentTracer) CaptureFault(
+	*vm.EVM, uint64, vm.OpCode, uint64, uint64, *vm.Memory, *vm.Stack, *vm.ReturnStack, *vm.Contract, int, error,
+) error {
+	return nil
+}
+
+// CaptureEnd implements vm.Tracer.
+func (dt *deploymentTracer) CaptureEnd([]byte, uint64, time.Duration, error) error {
+	return nil
+}
+
+// validate returns an error if a contract has been deployed by a deployer that isn't
+// allowed or if the runtime bytecode of a deployed contract doesn't match the code hash
+// allowlist. The contracts that failed to deploy don't have code and are skipped.
+func (dt *deploymentTracer) validate(csdb *CommitStateDB) error {
+	if dt.err != nil {
+		return dt.err
+	}
+
+	for _, addr := range dt.created {
+		if err := validateDeployedCode(csdb, dt.params, addr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateDeployedCode returns an error if the runtime bytecode of the contract deployed at
+// the given address isn't on the code hash allowlist.
+func validateDeployedCode(csdb *CommitStateDB, params Params, addr common.Address) error {
+	if len(params.AllowedCodeHashes) == 0 || len(csdb.GetCode(addr)) == 0 {
+		return nil
+	}
+
+	codeHash := csdb.GetCodeHash(addr)
+	if !params.IsCodeHashAllowed(codeHash) {
+		return sdkerrors.Wrapf(ErrCodeHashNotAllowed, "contract %s, code hash %s", addr, codeHash)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+func TestDeploymentTracerCaptureState(t *testing.T) {
+	deployer := ethcmn.BytesToAddress([]byte("deployer"))
+	contract := vm.NewContract(vm.AccountRef(deployer), vm.AccountRef(deployer), big.NewInt(0), 0)
+
+	params := DefaultParams()
+	params.AllowedDeployers = []string{ethcmn.BytesToAddress([]byte("other")).Hex()}
+
+	testCases := []struct {
+		name string
+		op   vm.OpCode
+		err  error
+	}{
+		{"CREATE2 with a stack underflow", vm.CREATE2, nil},
+		{"CREATE with a stack underflow", vm.CREATE, nil},
+		{"CREATE2 with an error", vm.CREATE2, errors.New("error")},
+	}
+
+	for _, tc := range testCases {
+		dt := newDeploymentTracer(params)
+
+		require.NotPanics(t, func() {
+			err := dt.CaptureState(nil, 0, tc.op, 0, 0, vm.NewMemory(), &vm.Stack{}, nil, nil, contract, 0, tc.err)
+			require.NoError(t, err, tc.name)
+		}, tc.name)
+
+		// the opcodes that fail don't deploy a contract
+		require.NoError(t, dt.err, tc.name)
+		require.Empty(t, dt.created, tc.name)
+	}
+}
+
+func TestMemorySlice(t *testing.T) {
+	memory := vm.NewMemory()
+	memory.Resize(64)
+	memory.Set(0, 2, []byte{1, 2})
+
+	testCases := []struct {
+		name         string
+		offset, size uint64
+		expSlice     []byte
+		expOK        bool
+	}{
+		{"empty", 1000, 0, nil, true},
+		{"in bounds", 0, 2, []byte{1, 2}, true},
+		{"up to the end", 62, 2, []byte{0, 0}, true},
+		{"out of bounds", 63, 2, nil, false},
+		{"overflow", 1, ^uint64(0), nil, false},
+	}
+
+	for _, tc := range testCases {
+		slice, ok := memorySlice(memory, tc.offset, tc.size)
+		require.Equal(t, tc.expOK, ok, tc.name)
+		require.Equal(t, tc.expSlice, slice, tc.name)
+	}
+}
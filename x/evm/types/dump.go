@@ -0,0 +1,63 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethstate "github.com/ethereum/go-ethereum/core/state"
+)
+
+// DumpAccount defines an EthAccount of a state dump. As the EVM state isn't stored on a
+// Merkle Patricia trie, the accounts don't have a storage root and their storage entries
+// are keyed by their store key (i.e the hash of the address and the slot), as the slot
+// preimages are not persisted.
+type DumpAccount struct {
+	Address  string  `json:"address"`
+	Balance  string  `json:"balance"`
+	Nonce    uint64  `json:"nonce"`
+	CodeHash string  `json:"code_hash"`
+	Code     string  `json:"code,omitempty"`
+	Storage  Storage `json:"storage,omitempty"`
+}
+
+// EthDumpAccount returns the address and the geth dump format of the account, where the
+// hashes and the code are hex encoded without the 0x prefix and the storage values are
+// stripped of their leading zeros.
+func (da DumpAccount) EthDumpAccount() (ethcmn.Address, ethstate.DumpAccount) {
+	account := ethstate.DumpAccount{
+		Balance:  da.Balance,
+		Nonce:    da.Nonce,
+		CodeHash: strings.TrimPrefix(da.CodeHash, "0x"),
+		Code:     strings.TrimPrefix(da.Code, "0x"),
+	}
+
+	if da.Storage != nil {
+		account.Storage = make(map[ethcmn.Hash]string, len(da.Storage))
+		for _, state := range da.Storage {
+			value := ethcmn.HexToHash(state.Value)
+			account.Storage[ethcmn.HexToHash(state.Key)] = ethcmn.Bytes2Hex(ethcmn.TrimLeftZeroes(value.Bytes()))
+		}
+	}
+
+	return ethcmn.HexToAddress(da.Address), account
+}
+
+// iterativeDump is a geth DumpCollector that encodes the root and each account on a new
+// line, like the 'geth dump --iterative' format.
+type iterativeDump struct {
+	*json.Encoder
+}
+
+// OnRoot implements the ethstate.DumpCollector interface
+func (d iterativeDump) OnRoot(root ethcmn.Hash) {
+	_ = d.Encode(struct {
+		Root ethcmn.Hash `json:"root"`
+	}{root})
+}
+
+// OnAccount implements the ethstate.DumpCollector interface
+func (d iterativeDump) OnAccount(addr ethcmn.Address, account ethstate.DumpAccount) {
+	account.Address = &addr
+	_ = d.Encode(account)
+}
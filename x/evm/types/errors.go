@@ -21,4 +21,27 @@ var (
 
 	// ErrCallDisabled returns an error if the EnableCall parameter is false.
 	ErrCallDisabled = sdkerrors.Register(ModuleName, 6, "EVM Call operation is disabled")
+
+	// ErrHistoryPruned returns an error if the requested block data has been pruned from the store.
+	ErrHistoryPruned = sdkerrors.Register(ModuleName, 7, "historical EVM data has been pruned")
+
+	// ErrInvalidPaymaster returns an error if the signer of a fee allowance message is not the
+	// paymaster defined on the parameters.
+	ErrInvalidPaymaster = sdkerrors.Register(ModuleName, 8, "invalid paymaster")
+
+	// ErrFeeAllowanceNotFound returns an error if the fee allowance of a sender and contract
+	// pair cannot be found on the store.
+	ErrFeeAllowanceNotFound = sdkerrors.Register(ModuleName, 9, "fee allowance not found")
+
+	// ErrDeployerNotAllowed returns an error if the deployer of a contract is not on the
+	// AllowedDeployers parameter.
+	ErrDeployerNotAllowed = sdkerrors.Register(ModuleName, 10, "contract deployer is not allowed")
+
+	// ErrCodeHashNotAllowed returns an error if the runtime bytecode hash of a deployed contract
+	// is not on the AllowedCodeHashes parameter.
+	ErrCodeHashNotAllowed = sdkerrors.Register(ModuleName, 11, "contract code hash is not allowed")
+
+	// ErrAddressBlocked returns an error if an EVM value transfer is sent from or to an address
+	// on the BlockedAddresses parameter.
+	ErrAddressBlocked = sdkerrors.Register(ModuleName, 12, "address is blocked")
 )
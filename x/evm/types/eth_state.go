@@ -0,0 +1,222 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
+
+	ethermint "github.com/cosmos/ethermint/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	ethparams "github.com/ethereum/go-ethereum/params"
+)
+
+// ParseEthState parses the state of an existing Ethereum network from one of the following
+// JSON formats:
+//
+//   - a geth genesis file, whose chain config is also returned
+//   - the alloc field of a geth genesis file
+//   - a geth state dump (i.e geth dump), either in the collected or in the iterative
+//     (line by line) format
+func ParseEthState(bz []byte) (*core.Genesis, error) {
+	dec := json.NewDecoder(bytes.NewReader(bz))
+
+	var fields map[string]json.RawMessage
+	if err := dec.Decode(&fields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Ethereum state: %w", err)
+	}
+
+	_, hasRoot := fields["root"]
+	_, hasAccounts := fields["accounts"]
+	_, hasAlloc := fields["alloc"]
+
+	switch {
+	case hasAccounts:
+		var dump state.Dump
+		if err := json.Unmarshal(bz, &dump); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal geth state dump: %w", err)
+		}
+
+		alloc := make(core.GenesisAlloc, len(dump.Accounts))
+		for addr, account := range dump.Accounts {
+			if err := addDumpAccount(alloc, addr, account); err != nil {
+				return nil, err
+			}
+		}
+
+		return &core.Genesis{Alloc: alloc}, nil
+
+	case hasRoot:
+		// iterative dump: the root is followed by one account per line
+		alloc := make(core.GenesisAlloc)
+		for {
+			var account state.DumpAccount
+			err := dec.Decode(&account)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to unmarshal geth state dump account: %w", err)
+			}
+
+			if account.Address == nil {
+				return nil, fmt.Errorf("missing address of the dump account with key %s", account.SecureKey)
+			}
+
+			if err := addDumpAccount(alloc, *account.Address, account); err != nil {
+				return nil, err
+			}
+		}
+
+		return &core.Genesis{Alloc: alloc}, nil
+
+	case hasAlloc:
+		// only the chain config and the accounts are migrated, so the other genesis fields
+		// are not required
+		var genesis struct {
+			Config *ethparams.ChainConfig `json:"config"`
+			Alloc  core.GenesisAlloc      `json:"alloc"`
+		}
+		if err := json.Unmarshal(bz, &genesis); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal geth genesis: %w", err)
+		}
+
+		return &core.Genesis{Config: genesis.Config, Alloc: genesis.Alloc}, nil
+
+	default:
+		var alloc core.GenesisAlloc
+		if err := json.Unmarshal(bz, &alloc); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal geth genesis alloc: %w", err)
+		}
+
+		return &core.Genesis{Alloc: alloc}, nil
+	}
+}
+
+// addDumpAccount converts a geth dump account and adds it to the genesis alloc.
+func addDumpAccount(alloc core.GenesisAlloc, addr ethcmn.Address, account state.DumpAccount) error {
+	// the accounts without address preimage can't be migrated
+	if len(account.SecureKey) != 0 {
+		return fmt.Errorf("missing address preimage for the dump account with key %s", account.SecureKey)
+	}
+
+	if _, found := alloc[addr]; found {
+		return fmt.Errorf("duplicated dump account %s", addr.Hex())
+	}
+
+	balance, ok := new(big.Int).SetString(account.Balance, 10)
+	if !ok {
+		return fmt.Errorf("invalid balance %s for dump account %s", account.Balance, addr.Hex())
+	}
+
+	storage := make(map[ethcmn.Hash]ethcmn.Hash, len(account.Storage))
+	for key, value := range account.Storage {
+		// the dump values are the hex encoded bytes of the trimmed storage values
+		storage[key] = ethcmn.HexToHash(value)
+	}
+
+	alloc[addr] = core.GenesisAccount{
+		Code:    ethcmn.FromHex(account.Code),
+		Storage: storage,
+		Balance: balance,
+		Nonce:   account.Nonce,
+	}
+
+	return nil
+}
+
+// EthStateToGenesis converts the accounts of an Ethereum state to the auth EthAccounts, whose
+// balance is set in the EVM denomination, and to the EVM genesis accounts of the contracts.
+// The accounts are sorted by address.
+func EthStateToGenesis(alloc core.GenesisAlloc, evmDenom string) (authexported.GenesisAccounts, []GenesisAccount, error) {
+	if len(alloc) == 0 {
+		return nil, nil, errors.New("the Ethereum state doesn't contain any account")
+	}
+
+	addrs := make([]ethcmn.Address, 0, len(alloc))
+	for addr := range alloc {
+		addrs = append(addrs, addr)
+	}
+
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i].Bytes(), addrs[j].Bytes()) < 0
+	})
+
+	authAccounts := make(authexported.GenesisAccounts, 0, len(addrs))
+	evmAccounts := []GenesisAccount{}
+
+	for _, addr := range addrs {
+		account := alloc[addr]
+
+		coins := sdk.Coins{}
+		if account.Balance != nil && account.Balance.Sign() != 0 {
+			if account.Balance.Sign() < 0 {
+				return nil, nil, fmt.Errorf("negative balance %s for account %s", account.Balance, addr.Hex())
+			}
+
+			coins = sdk.NewCoins(sdk.NewCoin(evmDenom, sdk.NewIntFromBigInt(account.Balance)))
+		}
+
+		ethAccount := ethermint.EthAccount{
+			BaseAccount: auth.NewBaseAccount(sdk.AccAddress(addr.Bytes()), coins, nil, 0, account.Nonce),
+			CodeHash:    ethcrypto.Keccak256(account.Code),
+		}
+
+		if err := ethAccount.Validate(); err != nil {
+			return nil, nil, fmt.Errorf("invalid account %s: %w", addr.Hex(), err)
+		}
+
+		authAccounts = append(authAccounts, ethAccount)
+
+		if len(account.Code) == 0 {
+			if len(account.Storage) != 0 {
+				return nil, nil, fmt.Errorf("account %s has storage but no code", addr.Hex())
+			}
+
+			continue
+		}
+
+		evmAccounts = append(evmAccounts, GenesisAccount{
+			Address: addr.String(),
+			Code:    ethcmn.Bytes2Hex(account.Code),
+			Storage: genesisStorage(account.Storage),
+		})
+	}
+
+	return authAccounts, evmAccounts, nil
+}
+
+// genesisStorage converts the storage of a geth genesis account to a Storage sorted by key.
+// The zero values are omitted.
+func genesisStorage(storage map[ethcmn.Hash]ethcmn.Hash) Storage {
+	keys := make([]ethcmn.Hash, 0, len(storage))
+	for key, value := range storage {
+		if value == (ethcmn.Hash{}) {
+			continue
+		}
+
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i].Bytes(), keys[j].Bytes()) < 0
+	})
+
+	genStorage := make(Storage, len(keys))
+	for i, key := range keys {
+		genStorage[i] = NewState(key, storage[key])
+	}
+
+	return genStorage
+}
@@ -0,0 +1,167 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ethermint "github.com/cosmos/ethermint/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	ethStateAccount  = ethcmn.HexToAddress("0x1000000000000000000000000000000000000001")
+	ethStateContract = ethcmn.HexToAddress("0x2000000000000000000000000000000000000002")
+)
+
+func TestParseEthState(t *testing.T) {
+	testCases := []struct {
+		name    string
+		state   string
+		chainID *big.Int
+		expPass bool
+	}{
+		{
+			"geth genesis",
+			`{"config": {"chainId": 1337}, "alloc": {
+				"0x1000000000000000000000000000000000000001": {"balance": "0x100", "nonce": "0x2"},
+				"0x2000000000000000000000000000000000000002": {"balance": "5", "code": "0x6000", "storage": {"0x00": "0x07"}}
+			}}`,
+			big.NewInt(1337),
+			true,
+		},
+		{
+			"geth genesis alloc",
+			`{
+				"0x1000000000000000000000000000000000000001": {"balance": "256", "nonce": "0x2"},
+				"0x2000000000000000000000000000000000000002": {"balance": "0x5", "code": "0x6000", "storage": {"0x00": "0x07"}}
+			}`,
+			nil,
+			true,
+		},
+		{
+			"geth dump",
+			`{"root": "abcd", "accounts": {
+				"0x1000000000000000000000000000000000000001": {"balance": "256", "nonce": 2, "root": "", "codeHash": ""},
+				"0x2000000000000000000000000000000000000002": {
+					"balance": "5", "nonce": 0, "root": "", "codeHash": "", "code": "6000",
+					"storage": {"0x0000000000000000000000000000000000000000000000000000000000000000": "07"}
+				}
+			}}`,
+			nil,
+			true,
+		},
+		{
+			"geth iterative dump",
+			`{"root": "abcd"}
+			{"balance": "256", "nonce": 2, "root": "", "codeHash": "", "address": "0x1000000000000000000000000000000000000001"}
+			{"balance": "5", "nonce": 0, "root": "", "codeHash": "", "code": "6000", "storage": {"0x0000000000000000000000000000000000000000000000000000000000000000": "07"}, "address": "0x2000000000000000000000000000000000000002"}`,
+			nil,
+			true,
+		},
+		{
+			"dump account without preimage",
+			`{"root": "abcd"}
+			{"balance": "256", "nonce": 2, "root": "", "codeHash": "", "key": "0x01"}`,
+			nil,
+			false,
+		},
+		{
+			"invalid dump balance",
+			`{"root": "abcd", "accounts": {
+				"0x1000000000000000000000000000000000000001": {"balance": "0x100", "nonce": 2, "root": "", "codeHash": ""}
+			}}`,
+			nil,
+			false,
+		},
+		{
+			"invalid json",
+			`[]`,
+			nil,
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			genesis, err := ParseEthState([]byte(tc.state))
+			if !tc.expPass {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			if tc.chainID != nil {
+				require.Equal(t, tc.chainID, genesis.Config.ChainID)
+			}
+
+			require.Len(t, genesis.Alloc, 2)
+			require.Equal(t, big.NewInt(256), genesis.Alloc[ethStateAccount].Balance)
+			require.Equal(t, uint64(2), genesis.Alloc[ethStateAccount].Nonce)
+			require.Empty(t, genesis.Alloc[ethStateAccount].Code)
+
+			contract := genesis.Alloc[ethStateContract]
+			require.Equal(t, big.NewInt(5), contract.Balance)
+			require.Equal(t, []byte{0x60, 0x00}, contract.Code)
+			require.Equal(t, ethcmn.BigToHash(big.NewInt(7)), contract.Storage[ethcmn.Hash{}])
+		})
+	}
+}
+
+func TestEthStateToGenesis(t *testing.T) {
+	alloc := core.GenesisAlloc{
+		ethStateContract: {
+			Balance: big.NewInt(5),
+			Code:    []byte{0x60, 0x00},
+			Storage: map[ethcmn.Hash]ethcmn.Hash{
+				ethcmn.BigToHash(big.NewInt(1)): ethcmn.BigToHash(big.NewInt(8)),
+				{}:                              ethcmn.BigToHash(big.NewInt(7)),
+				ethcmn.BigToHash(big.NewInt(2)): {},
+			},
+		},
+		ethStateAccount: {Balance: big.NewInt(256), Nonce: 2},
+	}
+
+	authAccounts, evmAccounts, err := EthStateToGenesis(alloc, "aphoton")
+	require.NoError(t, err)
+	require.Len(t, authAccounts, 2)
+
+	account, ok := authAccounts[0].(ethermint.EthAccount)
+	require.True(t, ok)
+	require.Equal(t, ethStateAccount, account.EthAddress())
+	require.Equal(t, "256", account.Balance("aphoton").String())
+	require.Equal(t, uint64(2), account.GetSequence())
+	require.Equal(t, ethcrypto.Keccak256(nil), account.CodeHash)
+
+	contract, ok := authAccounts[1].(ethermint.EthAccount)
+	require.True(t, ok)
+	require.Equal(t, ethStateContract, contract.EthAddress())
+	require.Equal(t, ethcrypto.Keccak256([]byte{0x60, 0x00}), contract.CodeHash)
+
+	// the zero storage values are omitted and the keys are sorted
+	expAccounts := []GenesisAccount{{
+		Address: ethStateContract.String(),
+		Code:    "6000",
+		Storage: Storage{
+			NewState(ethcmn.Hash{}, ethcmn.BigToHash(big.NewInt(7))),
+			NewState(ethcmn.BigToHash(big.NewInt(1)), ethcmn.BigToHash(big.NewInt(8))),
+		},
+	}}
+	require.Equal(t, expAccounts, evmAccounts)
+	require.NoError(t, GenesisState{Accounts: evmAccounts, Params: DefaultParams(), ChainConfig: DefaultChainConfig()}.Validate())
+
+	_, _, err = EthStateToGenesis(core.GenesisAlloc{}, "aphoton")
+	require.Error(t, err)
+
+	_, _, err = EthStateToGenesis(core.GenesisAlloc{ethStateAccount: {Balance: big.NewInt(-1)}}, "aphoton")
+	require.Error(t, err)
+
+	_, _, err = EthStateToGenesis(core.GenesisAlloc{
+		ethStateAccount: {Balance: big.NewInt(1), Storage: map[ethcmn.Hash]ethcmn.Hash{{}: ethcmn.BigToHash(big.NewInt(7))}},
+	}, "aphoton")
+	require.Error(t, err)
+}
@@ -5,7 +5,14 @@ const (
 	EventTypeEthermint  = TypeMsgEthermint
 	EventTypeEthereumTx = TypeMsgEthereumTx
 
+	EventTypeGrantFeeAllowance  = TypeMsgGrantFeeAllowance
+	EventTypeRevokeFeeAllowance = TypeMsgRevokeFeeAllowance
+	EventTypeBlockedTransfer    = "blocked_transfer"
+
 	AttributeKeyContractAddress = "contract"
 	AttributeKeyRecipient       = "recipient"
+	AttributeKeySpendLimit      = "spend_limit"
+	AttributeKeyTxHash          = "txHash"
+	AttributeKeyBlockedAddress  = "blocked_address"
 	AttributeValueCategory      = ModuleName
 )
@@ -3,6 +3,8 @@ package types
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
+	stakingexported "github.com/cosmos/cosmos-sdk/x/staking/exported"
+	supplyexported "github.com/cosmos/cosmos-sdk/x/supply/exported"
 )
 
 // AccountKeeper defines the expected account keeper interface
@@ -14,3 +16,17 @@ type AccountKeeper interface {
 	SetAccount(ctx sdk.Context, account authexported.Account)
 	RemoveAccount(ctx sdk.Context, account authexported.Account)
 }
+
+// StakingKeeper defines the expected staking keeper interface used to retrieve the
+// block proposer validator
+type StakingKeeper interface {
+	ValidatorByConsAddr(ctx sdk.Context, consAddr sdk.ConsAddress) stakingexported.ValidatorI
+}
+
+// SupplyKeeper defines the expected supply keeper interface used to refund the unused
+// gas from the fee collector and to verify the total supply
+type SupplyKeeper interface {
+	GetSupply(ctx sdk.Context) supplyexported.SupplyI
+	GetModuleAddress(moduleName string) sdk.AccAddress
+	SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error
+}
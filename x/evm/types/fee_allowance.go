@@ -0,0 +1,78 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+
+	ethermint "github.com/cosmos/ethermint/types"
+)
+
+// feePayerKey is the context key of the account that paid the fees of an Ethereum
+// transaction.
+type feePayerKey struct{}
+
+// FeeAllowance defines the maximum amount of fees that the paymaster pays on behalf of a
+// sender for the Ethereum transactions that call the target contract.
+type FeeAllowance struct {
+	Sender     string  `json:"sender" yaml:"sender"`
+	Contract   string  `json:"contract" yaml:"contract"`
+	SpendLimit sdk.Int `json:"spend_limit" yaml:"spend_limit"`
+}
+
+// NewFeeAllowance creates a new FeeAllowance instance
+func NewFeeAllowance(sender, contract ethcmn.Address, spendLimit sdk.Int) FeeAllowance {
+	return FeeAllowance{
+		Sender:     sender.String(),
+		Contract:   contract.String(),
+		SpendLimit: spendLimit,
+	}
+}
+
+// String implements the fmt.Stringer interface
+func (fa FeeAllowance) String() string {
+	return fmt.Sprintf("sender: %s, contract: %s, spend limit: %s", fa.Sender, fa.Contract, fa.SpendLimit)
+}
+
+// Validate performs a basic validation of the FeeAllowance fields.
+func (fa FeeAllowance) Validate() error {
+	if err := validateAllowancePair(fa.Sender, fa.Contract); err != nil {
+		return err
+	}
+
+	if fa.SpendLimit.IsNil() || !fa.SpendLimit.IsPositive() {
+		return sdkerrors.Wrapf(ethermint.ErrInvalidValue, "spend limit must be positive: %s", fa.SpendLimit)
+	}
+
+	return nil
+}
+
+// validateAllowancePair validates the hex addresses of the sender and target contract of
+// a fee allowance.
+func validateAllowancePair(sender, contract string) error {
+	if !ethcmn.IsHexAddress(sender) || ethermint.IsZeroAddress(sender) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid sender address %s", sender)
+	}
+
+	if !ethcmn.IsHexAddress(contract) || ethermint.IsZeroAddress(contract) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid contract address %s", contract)
+	}
+
+	return nil
+}
+
+// WithFeePayer returns a copy of the context with the account that paid the fees of the
+// Ethereum transaction, so that the unused gas is refunded to it.
+func WithFeePayer(ctx sdk.Context, payer sdk.AccAddress) sdk.Context {
+	return ctx.WithValue(feePayerKey{}, payer)
+}
+
+// FeePayer returns the account that paid the fees of the Ethereum transaction if it's not
+// the sender. It returns nil otherwise.
+func FeePayer(ctx sdk.Context) sdk.AccAddress {
+	payer, _ := ctx.Value(feePayerKey{}).(sdk.AccAddress)
+	return payer
+}
@@ -12,10 +12,11 @@ import (
 type (
 	// GenesisState defines the evm module genesis state
 	GenesisState struct {
-		Accounts    []GenesisAccount  `json:"accounts"`
-		TxsLogs     []TransactionLogs `json:"txs_logs"`
-		ChainConfig ChainConfig       `json:"chain_config"`
-		Params      Params            `json:"params"`
+		Accounts      []GenesisAccount  `json:"accounts"`
+		TxsLogs       []TransactionLogs `json:"txs_logs"`
+		ChainConfig   ChainConfig       `json:"chain_config"`
+		Params        Params            `json:"params"`
+		FeeAllowances []FeeAllowance    `json:"fee_allowances"`
 	}
 
 	// GenesisAccount defines an account to be initialized in the genesis state.
@@ -45,10 +46,11 @@ func (ga GenesisAccount) Validate() error {
 // chain config values.
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
-		Accounts:    []GenesisAccount{},
-		TxsLogs:     []TransactionLogs{},
-		ChainConfig: DefaultChainConfig(),
-		Params:      DefaultParams(),
+		Accounts:      []GenesisAccount{},
+		TxsLogs:       []TransactionLogs{},
+		ChainConfig:   DefaultChainConfig(),
+		Params:        DefaultParams(),
+		FeeAllowances: []FeeAllowance{},
 	}
 }
 
@@ -79,6 +81,20 @@ func (gs GenesisState) Validate() error {
 		seenTxs[tx.Hash] = true
 	}
 
+	seenAllowances := make(map[string]bool)
+	for _, allowance := range gs.FeeAllowances {
+		pair := ethcmn.HexToAddress(allowance.Sender).String() + ethcmn.HexToAddress(allowance.Contract).String()
+		if seenAllowances[pair] {
+			return fmt.Errorf("duplicated fee allowance for sender %s and contract %s", allowance.Sender, allowance.Contract)
+		}
+
+		if err := allowance.Validate(); err != nil {
+			return fmt.Errorf("invalid fee allowance for sender %s and contract %s: %w", allowance.Sender, allowance.Contract, err)
+		}
+
+		seenAllowances[pair] = true
+	}
+
 	if err := gs.ChainConfig.Validate(); err != nil {
 		return err
 	}
@@ -21,13 +21,31 @@ const (
 
 // KVStore key prefixes
 var (
-	KeyPrefixBlockHash   = []byte{0x01}
-	KeyPrefixBloom       = []byte{0x02}
-	KeyPrefixLogs        = []byte{0x03}
-	KeyPrefixCode        = []byte{0x04}
-	KeyPrefixStorage     = []byte{0x05}
-	KeyPrefixChainConfig = []byte{0x06}
-	KeyPrefixHeightHash  = []byte{0x07}
+	KeyPrefixBlockHash    = []byte{0x01}
+	KeyPrefixBloom        = []byte{0x02}
+	KeyPrefixLogs         = []byte{0x03}
+	KeyPrefixCode         = []byte{0x04}
+	KeyPrefixStorage      = []byte{0x05}
+	KeyPrefixChainConfig  = []byte{0x06}
+	KeyPrefixHeightHash   = []byte{0x07}
+	KeyPrefixBlockGas     = []byte{0x08}
+	KeyPrefixReceipts     = []byte{0x09}
+	KeyPrefixLogsHeight   = []byte{0x0A}
+	KeyPrefixFeeAllowance = []byte{0x0B}
+	KeyPrefixStateDiff    = []byte{0x0C}
+)
+
+// KVStore keys of the EVM history pruning
+var (
+	// KeyHistoryPrunedHeight is the key of the lowest block height whose transaction logs,
+	// bloom filter, receipts, gas used and state diff are kept on the store.
+	KeyHistoryPrunedHeight = []byte{0x0D}
+	// KeyLogsIndexCursor is the key of the next transaction hash to index by height among the
+	// logs stored before the logs height index was introduced.
+	KeyLogsIndexCursor = []byte{0x0E}
+	// KeyLogsIndexed is set once all the logs stored before the logs height index was
+	// introduced have been indexed.
+	KeyLogsIndexed = []byte{0x0F}
 )
 
 // HeightHashKey returns the key for the given chain epoch and height.
@@ -44,6 +62,36 @@ func BloomKey(height int64) []byte {
 	return sdk.Uint64ToBigEndian(uint64(height))
 }
 
+// BlockGasKey defines the store key for the cumulative gas used on a block
+func BlockGasKey(height int64) []byte {
+	return sdk.Uint64ToBigEndian(uint64(height))
+}
+
+// ReceiptsKey defines the store key for the transaction receipts of a block
+func ReceiptsKey(height int64) []byte {
+	return sdk.Uint64ToBigEndian(uint64(height))
+}
+
+// StateDiffKey defines the store key for the accounts and storage slots changed on a block
+func StateDiffKey(height int64) []byte {
+	return sdk.Uint64ToBigEndian(uint64(height))
+}
+
+// LogsHeightKey defines the store key that indexes the logs of a transaction by the
+// block height. The key will be composed in the following order:
+//   key = prefix + bytes(height) + txHash
+// This ordering facilitates the pruning of the logs by height.
+func LogsHeightKey(height int64, txHash ethcmn.Hash) []byte {
+	return append(sdk.Uint64ToBigEndian(uint64(height)), txHash.Bytes()...)
+}
+
+// FeeAllowanceKey defines the store key for the fee allowance of a sender and target
+// contract pair. The key will be composed in the following order:
+//   key = prefix + sender + contract
+func FeeAllowanceKey(sender, contract ethcmn.Address) []byte {
+	return append(sender.Bytes(), contract.Bytes()...)
+}
+
 // AddressStoragePrefix returns a prefix to iterate over a given account storage.
 func AddressStoragePrefix(address ethcmn.Address) []byte {
 	return append(KeyPrefixStorage, address.Bytes()...)
@@ -24,6 +24,8 @@ var (
 	_ sdk.Msg = MsgEthermint{}
 	_ sdk.Msg = MsgEthereumTx{}
 	_ sdk.Tx  = MsgEthereumTx{}
+	_ sdk.Msg = MsgGrantFeeAllowance{}
+	_ sdk.Msg = MsgRevokeFeeAllowance{}
 )
 
 var big8 = big.NewInt(8)
@@ -34,6 +36,10 @@ const (
 	TypeMsgEthereumTx = "ethereum"
 	// TypeMsgEthermint defines the type string of Ethermint message
 	TypeMsgEthermint = "ethermint"
+	// TypeMsgGrantFeeAllowance defines the type string of the fee allowance grant message
+	TypeMsgGrantFeeAllowance = "grant_fee_allowance"
+	// TypeMsgRevokeFeeAllowance defines the type string of the fee allowance revoke message
+	TypeMsgRevokeFeeAllowance = "revoke_fee_allowance"
 )
 
 // MsgEthermint implements a cosmos equivalent structure for Ethereum transactions
@@ -115,6 +121,99 @@ func (msg MsgEthermint) To() *ethcmn.Address {
 	return &addr
 }
 
+// MsgGrantFeeAllowance sets the fees that the paymaster pays on behalf of a sender for the
+// Ethereum transactions that call the target contract. It must be signed by the paymaster
+// defined on the parameters.
+type MsgGrantFeeAllowance struct {
+	Paymaster  sdk.AccAddress `json:"paymaster" yaml:"paymaster"`
+	Sender     string         `json:"sender" yaml:"sender"`
+	Contract   string         `json:"contract" yaml:"contract"`
+	SpendLimit sdk.Int        `json:"spend_limit" yaml:"spend_limit"`
+}
+
+// NewMsgGrantFeeAllowance returns a new MsgGrantFeeAllowance instance
+func NewMsgGrantFeeAllowance(paymaster sdk.AccAddress, sender, contract ethcmn.Address, spendLimit sdk.Int) MsgGrantFeeAllowance {
+	return MsgGrantFeeAllowance{
+		Paymaster:  paymaster,
+		Sender:     sender.String(),
+		Contract:   contract.String(),
+		SpendLimit: spendLimit,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgGrantFeeAllowance) Route() string { return RouterKey }
+
+// Type returns the action of the message
+func (msg MsgGrantFeeAllowance) Type() string { return TypeMsgGrantFeeAllowance }
+
+// GetSignBytes encodes the message for signing
+func (msg MsgGrantFeeAllowance) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgGrantFeeAllowance) ValidateBasic() error {
+	if msg.Paymaster.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "paymaster address cannot be empty")
+	}
+
+	allowance := FeeAllowance{
+		Sender:     msg.Sender,
+		Contract:   msg.Contract,
+		SpendLimit: msg.SpendLimit,
+	}
+
+	return allowance.Validate()
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgGrantFeeAllowance) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Paymaster}
+}
+
+// MsgRevokeFeeAllowance removes the fee allowance of a sender and target contract pair. It
+// must be signed by the paymaster defined on the parameters.
+type MsgRevokeFeeAllowance struct {
+	Paymaster sdk.AccAddress `json:"paymaster" yaml:"paymaster"`
+	Sender    string         `json:"sender" yaml:"sender"`
+	Contract  string         `json:"contract" yaml:"contract"`
+}
+
+// NewMsgRevokeFeeAllowance returns a new MsgRevokeFeeAllowance instance
+func NewMsgRevokeFeeAllowance(paymaster sdk.AccAddress, sender, contract ethcmn.Address) MsgRevokeFeeAllowance {
+	return MsgRevokeFeeAllowance{
+		Paymaster: paymaster,
+		Sender:    sender.String(),
+		Contract:  contract.String(),
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgRevokeFeeAllowance) Route() string { return RouterKey }
+
+// Type returns the action of the message
+func (msg MsgRevokeFeeAllowance) Type() string { return TypeMsgRevokeFeeAllowance }
+
+// GetSignBytes encodes the message for signing
+func (msg MsgRevokeFeeAllowance) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgRevokeFeeAllowance) ValidateBasic() error {
+	if msg.Paymaster.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "paymaster address cannot be empty")
+	}
+
+	return validateAllowancePair(msg.Sender, msg.Contract)
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgRevokeFeeAllowance) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Paymaster}
+}
+
 // MsgEthereumTx encapsulates an Ethereum transaction as an SDK message.
 type MsgEthereumTx struct {
 	Data TxData
@@ -252,7 +351,20 @@ func (msg MsgEthereumTx) GetSignBytes() []byte {
 // RLPSignBytes returns the RLP hash of an Ethereum transaction message with a
 // given chainID used for signing.
 func (msg MsgEthereumTx) RLPSignBytes(chainID *big.Int) ethcmn.Hash {
-	return rlpHash([]interface{}{
+	return rlpHash(msg.eip155SignFields(chainID))
+}
+
+// RLPSignPayload returns the RLP encoded EIP-155 payload of an Ethereum transaction
+// message with a given chainID, i.e the preimage of RLPSignBytes. It's the payload
+// signed by the external signers such as hardware wallets.
+func (msg MsgEthereumTx) RLPSignPayload(chainID *big.Int) ([]byte, error) {
+	return rlp.EncodeToBytes(msg.eip155SignFields(chainID))
+}
+
+// eip155SignFields returns the fields of an Ethereum transaction message that are signed
+// according to the EIP155 standard.
+func (msg MsgEthereumTx) eip155SignFields(chainID *big.Int) []interface{} {
+	return []interface{}{
 		msg.Data.AccountNonce,
 		msg.Data.Price.BigInt(),
 		msg.Data.GasLimit,
@@ -260,7 +372,7 @@ func (msg MsgEthereumTx) RLPSignBytes(chainID *big.Int) ethcmn.Hash {
 		msg.Data.Amount.BigInt(),
 		msg.Data.Payload,
 		chainID, uint(0), uint(0),
-	})
+	}
 }
 
 // EncodeRLP implements the rlp.Encoder interface.
@@ -368,6 +480,13 @@ func (msg *MsgEthereumTx) Sign(chainID *big.Int, priv *ecdsa.PrivateKey) error {
 		return err
 	}
 
+	return msg.SetSignature(chainID, sig)
+}
+
+// SetSignature populates the V, R, S fields of the Transaction's Signature from a
+// 65 byte [R || S || V] secp256k1 signature, with V in {0, 1}, over the RLPSignBytes
+// of the given chainID. It's used to sign the transaction with external signers.
+func (msg *MsgEthereumTx) SetSignature(chainID *big.Int, sig []byte) error {
 	if len(sig) != 65 {
 		return fmt.Errorf("wrong size for signature: got %d, want 65", len(sig))
 	}
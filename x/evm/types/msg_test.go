@@ -14,6 +14,7 @@ import (
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
 
 	"github.com/tendermint/tendermint/crypto/secp256k1"
@@ -131,6 +132,59 @@ func TestMsgEthereumTxValidation(t *testing.T) {
 	}
 }
 
+func TestMsgFeeAllowanceValidation(t *testing.T) {
+	paymaster := newSdkAddress()
+	sender := ethcmn.BytesToAddress([]byte("sender"))
+	contract := ethcmn.BytesToAddress([]byte("contract"))
+
+	testCases := []struct {
+		msg        string
+		grant      MsgGrantFeeAllowance
+		revoke     MsgRevokeFeeAllowance
+		expectPass bool
+	}{
+		{
+			"pass",
+			NewMsgGrantFeeAllowance(paymaster, sender, contract, sdk.NewInt(100)),
+			NewMsgRevokeFeeAllowance(paymaster, sender, contract),
+			true,
+		},
+		{
+			"empty paymaster",
+			NewMsgGrantFeeAllowance(nil, sender, contract, sdk.NewInt(100)),
+			NewMsgRevokeFeeAllowance(nil, sender, contract),
+			false,
+		},
+		{
+			"zero sender",
+			NewMsgGrantFeeAllowance(paymaster, ethcmn.Address{}, contract, sdk.NewInt(100)),
+			NewMsgRevokeFeeAllowance(paymaster, ethcmn.Address{}, contract),
+			false,
+		},
+		{
+			"invalid contract",
+			MsgGrantFeeAllowance{Paymaster: paymaster, Sender: sender.String(), Contract: "contract", SpendLimit: sdk.NewInt(100)},
+			MsgRevokeFeeAllowance{Paymaster: paymaster, Sender: sender.String(), Contract: "contract"},
+			false,
+		},
+	}
+
+	for i, tc := range testCases {
+		if tc.expectPass {
+			require.Nil(t, tc.grant.ValidateBasic(), "valid test %d failed: %s", i, tc.msg)
+			require.Nil(t, tc.revoke.ValidateBasic(), "valid test %d failed: %s", i, tc.msg)
+		} else {
+			require.NotNil(t, tc.grant.ValidateBasic(), "invalid test %d passed: %s", i, tc.msg)
+			require.NotNil(t, tc.revoke.ValidateBasic(), "invalid test %d passed: %s", i, tc.msg)
+		}
+	}
+
+	msg := NewMsgGrantFeeAllowance(paymaster, sender, contract, sdk.ZeroInt())
+	require.NotNil(t, msg.ValidateBasic(), "zero spend limit")
+	require.Equal(t, []sdk.AccAddress{paymaster}, msg.GetSigners())
+	require.Equal(t, RouterKey, msg.Route())
+}
+
 func TestMsgEthereumTxRLPSignBytes(t *testing.T) {
 	addr := ethcmn.BytesToAddress([]byte("test_address"))
 	chainID := big.NewInt(3)
@@ -140,6 +194,16 @@ func TestMsgEthereumTxRLPSignBytes(t *testing.T) {
 	require.Equal(t, "5BD30E35AD27449390B14C91E6BCFDCAADF8FE44EF33680E3BC200FC0DC083C7", fmt.Sprintf("%X", hash))
 }
 
+func TestMsgEthereumTxRLPSignPayload(t *testing.T) {
+	addr := ethcmn.BytesToAddress([]byte("test_address"))
+	chainID := big.NewInt(3)
+
+	msg := NewMsgEthereumTx(0, &addr, nil, 100000, nil, []byte("test"))
+	payload, err := msg.RLPSignPayload(chainID)
+	require.NoError(t, err)
+	require.Equal(t, msg.RLPSignBytes(chainID), ethcrypto.Keccak256Hash(payload))
+}
+
 func TestMsgEthereumTxRLPEncode(t *testing.T) {
 	addr := ethcmn.BytesToAddress([]byte("test_address"))
 	msg := NewMsgEthereumTx(0, &addr, nil, 100000, nil, []byte("test"))
@@ -189,6 +253,33 @@ func TestMsgEthereumTxSig(t *testing.T) {
 	require.Equal(t, ethcmn.Address{}, signer)
 }
 
+func TestMsgEthereumTxSetSignature(t *testing.T) {
+	chainID := big.NewInt(3)
+
+	priv, _ := ethsecp256k1.GenerateKey()
+	addr := ethcmn.BytesToAddress(priv.PubKey().Address().Bytes())
+
+	// the signature of an external signer over the RLP payload
+	msg := NewMsgEthereumTx(0, &addr, nil, 100000, nil, []byte("test"))
+	payload, err := msg.RLPSignPayload(chainID)
+	require.NoError(t, err)
+
+	sig, err := ethcrypto.Sign(ethcrypto.Keccak256(payload), priv.ToECDSA())
+	require.NoError(t, err)
+
+	require.Error(t, msg.SetSignature(chainID, sig[:64]))
+	require.NoError(t, msg.SetSignature(chainID, sig))
+
+	signer, err := msg.VerifySig(chainID)
+	require.NoError(t, err)
+	require.Equal(t, addr, signer)
+
+	// the signature matches the private key signer
+	expMsg := NewMsgEthereumTx(0, &addr, nil, 100000, nil, []byte("test"))
+	require.NoError(t, expMsg.Sign(chainID, priv.ToECDSA()))
+	require.Equal(t, expMsg.Data, msg.Data)
+}
+
 func TestMarshalAndUnmarshalLogs(t *testing.T) {
 	var cdc = codec.New()
 
@@ -2,12 +2,15 @@ package types
 
 import (
 	"fmt"
+	"math/big"
 
 	"gopkg.in/yaml.v2"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
 
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/vm"
 
 	ethermint "github.com/cosmos/ethermint/types"
@@ -24,6 +27,12 @@ var (
 	ParamStoreKeyEnableCreate = []byte("EnableCreate")
 	ParamStoreKeyEnableCall   = []byte("EnableCall")
 	ParamStoreKeyExtraEIPs    = []byte("EnableExtraEIPs")
+	ParamStoreKeyPaymaster    = []byte("Paymaster")
+	ParamStoreKeyDeployers    = []byte("AllowedDeployers")
+	ParamStoreKeyCodeHashes   = []byte("AllowedCodeHashes")
+	ParamStoreKeyBlocklist    = []byte("BlockedAddresses")
+	ParamStoreKeyChainID      = []byte("ChainID")
+	ParamStoreKeyRetainBlocks = []byte("RetainBlocks")
 )
 
 // ParamKeyTable returns the parameter key table.
@@ -42,6 +51,29 @@ type Params struct {
 	EnableCall bool `json:"enable_call" yaml:"enable_call"`
 	// ExtraEIPs defines the additional EIPs for the vm.Config
 	ExtraEIPs []int64 `json:"extra_eips" yaml:"extra_eips"`
+	// Paymaster defines the bech32 address of the account that pays the fees of the
+	// Ethereum transactions covered by a fee allowance. An empty value disables the
+	// sponsored transactions.
+	Paymaster string `json:"paymaster" yaml:"paymaster"`
+	// AllowedDeployers defines the hex addresses that can deploy contracts, either with a
+	// contract creation transaction or with the CREATE and CREATE2 opcodes. An empty list
+	// allows any address to deploy contracts.
+	AllowedDeployers []string `json:"allowed_deployers" yaml:"allowed_deployers"`
+	// AllowedCodeHashes defines the hashes of the runtime bytecode (i.e EXTCODEHASH) of the
+	// contracts that can be deployed. An empty list allows any contract to be deployed.
+	AllowedCodeHashes []string `json:"allowed_code_hashes" yaml:"allowed_code_hashes"`
+	// BlockedAddresses defines the hex addresses that can't send nor receive EVM value
+	// transfers, either on the transaction itself or on the internal calls.
+	BlockedAddresses []string `json:"blocked_addresses" yaml:"blocked_addresses"`
+	// ChainID defines the EIP-155 chain ID used to sign and verify the Ethereum
+	// transactions, independently of the Cosmos chain-id. A value of 0 uses the epoch
+	// number of the Cosmos chain-id (i.e {identifier}-{epoch}) instead.
+	ChainID uint64 `json:"chain_id" yaml:"chain_id"`
+	// RetainBlocks defines the number of recent blocks for which the transaction logs, bloom
+	// filters, receipts, gas used and state diffs are kept on the latest state. Older entries
+	// are pruned on EndBlock, but they remain available on the previous versions of the state
+	// kept by the node (e.g archive nodes). A value of 0 disables the pruning.
+	RetainBlocks uint64 `json:"retain_blocks" yaml:"retain_blocks"`
 }
 
 // NewParams creates a new Params instance
@@ -57,10 +89,16 @@ func NewParams(evmDenom string, enableCreate, enableCall bool, extraEIPs ...int6
 // DefaultParams returns default evm parameters
 func DefaultParams() Params {
 	return Params{
-		EvmDenom:     ethermint.AttoPhoton,
-		EnableCreate: true,
-		EnableCall:   true,
-		ExtraEIPs:    []int64(nil), // TODO: define default values
+		EvmDenom:          ethermint.AttoPhoton,
+		EnableCreate:      true,
+		EnableCall:        true,
+		ExtraEIPs:         []int64(nil), // TODO: define default values
+		Paymaster:         "",
+		AllowedDeployers:  []string(nil),
+		AllowedCodeHashes: []string(nil),
+		BlockedAddresses:  []string(nil),
+		ChainID:           0,
+		RetainBlocks:      0,
 	}
 }
 
@@ -77,6 +115,12 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 		params.NewParamSetPair(ParamStoreKeyEnableCreate, &p.EnableCreate, validateBool),
 		params.NewParamSetPair(ParamStoreKeyEnableCall, &p.EnableCall, validateBool),
 		params.NewParamSetPair(ParamStoreKeyExtraEIPs, &p.ExtraEIPs, validateEIPs),
+		params.NewParamSetPair(ParamStoreKeyPaymaster, &p.Paymaster, validatePaymaster),
+		params.NewParamSetPair(ParamStoreKeyDeployers, &p.AllowedDeployers, validateDeployers),
+		params.NewParamSetPair(ParamStoreKeyCodeHashes, &p.AllowedCodeHashes, validateCodeHashes),
+		params.NewParamSetPair(ParamStoreKeyBlocklist, &p.BlockedAddresses, validateBlockedAddresses),
+		params.NewParamSetPair(ParamStoreKeyChainID, &p.ChainID, validateUint64),
+		params.NewParamSetPair(ParamStoreKeyRetainBlocks, &p.RetainBlocks, validateUint64),
 	}
 }
 
@@ -86,9 +130,93 @@ func (p Params) Validate() error {
 		return err
 	}
 
+	if err := validatePaymaster(p.Paymaster); err != nil {
+		return err
+	}
+
+	if err := validateDeployers(p.AllowedDeployers); err != nil {
+		return err
+	}
+
+	if err := validateCodeHashes(p.AllowedCodeHashes); err != nil {
+		return err
+	}
+
+	if err := validateBlockedAddresses(p.BlockedAddresses); err != nil {
+		return err
+	}
+
 	return validateEIPs(p.ExtraEIPs)
 }
 
+// HasDeploymentAllowlist returns true if the contract deployments are restricted by either
+// the deployer or the code hash allowlists.
+func (p Params) HasDeploymentAllowlist() bool {
+	return len(p.AllowedDeployers) > 0 || len(p.AllowedCodeHashes) > 0
+}
+
+// IsDeployerAllowed returns true if the address is allowed to deploy contracts.
+func (p Params) IsDeployerAllowed(deployer ethcmn.Address) bool {
+	return len(p.AllowedDeployers) == 0 || containsAddress(p.AllowedDeployers, deployer)
+}
+
+// IsBlocked returns true if the address can't send nor receive EVM value transfers.
+func (p Params) IsBlocked(address ethcmn.Address) bool {
+	return containsAddress(p.BlockedAddresses, address)
+}
+
+// containsAddress returns true if the hex addresses contain the given address.
+func containsAddress(addresses []string, address ethcmn.Address) bool {
+	for _, addr := range addresses {
+		if ethcmn.HexToAddress(addr) == address {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsCodeHashAllowed returns true if a contract with the given runtime bytecode hash is
+// allowed to be deployed.
+func (p Params) IsCodeHashAllowed(codeHash ethcmn.Hash) bool {
+	if len(p.AllowedCodeHashes) == 0 {
+		return true
+	}
+
+	for _, hash := range p.AllowedCodeHashes {
+		if ethcmn.HexToHash(hash) == codeHash {
+			return true
+		}
+	}
+
+	return false
+}
+
+// EIP155ChainID returns the EIP-155 chain ID of the Ethereum transactions. If the ChainID
+// parameter is not set, it's parsed from the epoch of the given Cosmos chain-id.
+func (p Params) EIP155ChainID(chainID string) (*big.Int, error) {
+	if p.ChainID != 0 {
+		return new(big.Int).SetUint64(p.ChainID), nil
+	}
+
+	return ethermint.ParseChainID(chainID)
+}
+
+// PaymasterAddress returns the address of the paymaster account. It returns false if the
+// paymaster is not set.
+func (p Params) PaymasterAddress() (sdk.AccAddress, bool) {
+	if p.Paymaster == "" {
+		return nil, false
+	}
+
+	addr, err := sdk.AccAddressFromBech32(p.Paymaster)
+	if err != nil {
+		return nil, false
+	}
+
+	return addr, true
+}
+
 func validateEVMDenom(i interface{}) error {
 	denom, ok := i.(string)
 	if !ok {
@@ -106,6 +234,89 @@ func validateBool(i interface{}) error {
 	return nil
 }
 
+func validateUint64(i interface{}) error {
+	_, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validatePaymaster(i interface{}) error {
+	paymaster, ok := i.(string)
+	if !ok {
+		return fmt.Errorf("invalid parameter paymaster type: %T", i)
+	}
+
+	if paymaster == "" {
+		return nil
+	}
+
+	if _, err := sdk.AccAddressFromBech32(paymaster); err != nil {
+		return fmt.Errorf("invalid paymaster address %s: %w", paymaster, err)
+	}
+
+	return nil
+}
+
+func validateDeployers(i interface{}) error {
+	deployers, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid deployer slice type: %T", i)
+	}
+
+	return validateAddresses("deployer", deployers)
+}
+
+func validateBlockedAddresses(i interface{}) error {
+	addresses, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid blocked address slice type: %T", i)
+	}
+
+	return validateAddresses("blocked", addresses)
+}
+
+func validateAddresses(name string, addresses []string) error {
+	seen := make(map[ethcmn.Address]bool)
+	for _, address := range addresses {
+		if !ethcmn.IsHexAddress(address) {
+			return fmt.Errorf("invalid %s address %s", name, address)
+		}
+
+		addr := ethcmn.HexToAddress(address)
+		if seen[addr] {
+			return fmt.Errorf("duplicated %s address %s", name, address)
+		}
+		seen[addr] = true
+	}
+
+	return nil
+}
+
+func validateCodeHashes(i interface{}) error {
+	hashes, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid code hash slice type: %T", i)
+	}
+
+	seen := make(map[ethcmn.Hash]bool)
+	for _, hash := range hashes {
+		bz, err := hexutil.Decode(hash)
+		if err != nil || len(bz) != ethcmn.HashLength {
+			return fmt.Errorf("invalid code hash %s", hash)
+		}
+
+		codeHash := ethcmn.BytesToHash(bz)
+		if seen[codeHash] {
+			return fmt.Errorf("duplicated code hash %s", hash)
+		}
+		seen[codeHash] = true
+	}
+
+	return nil
+}
+
 func validateEIPs(i interface{}) error {
 	eips, ok := i.([]int64)
 	if !ok {
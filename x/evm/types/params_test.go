@@ -1,9 +1,13 @@
 package types
 
 import (
+	"errors"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
 )
 
 func TestParamsValidate(t *testing.T) {
@@ -30,6 +34,54 @@ func TestParamsValidate(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"invalid paymaster",
+			Params{
+				EvmDenom:  "stake",
+				Paymaster: "0x0000000000000000000000000000000000000001",
+			},
+			true,
+		},
+		{
+			"invalid deployer",
+			Params{
+				EvmDenom:         "stake",
+				AllowedDeployers: []string{"cosmos1"},
+			},
+			true,
+		},
+		{
+			"duplicated deployer",
+			Params{
+				EvmDenom:         "stake",
+				AllowedDeployers: []string{"0x0000000000000000000000000000000000000001", "0x0000000000000000000000000000000000000001"},
+			},
+			true,
+		},
+		{
+			"invalid code hash",
+			Params{
+				EvmDenom:          "stake",
+				AllowedCodeHashes: []string{"0x01"},
+			},
+			true,
+		},
+		{
+			"invalid blocked address",
+			Params{
+				EvmDenom:         "stake",
+				BlockedAddresses: []string{"0x01"},
+			},
+			true,
+		},
+		{
+			"duplicated blocked address",
+			Params{
+				EvmDenom:         "stake",
+				BlockedAddresses: []string{"0x0000000000000000000000000000000000000001", "0x0000000000000000000000000000000000000001"},
+			},
+			true,
+		},
 		{
 			"invalid eip",
 			Params{
@@ -58,8 +110,75 @@ func TestParamsValidatePriv(t *testing.T) {
 	require.NoError(t, validateBool(true))
 	require.Error(t, validateEIPs(""))
 	require.NoError(t, validateEIPs([]int64{1884}))
+	require.Error(t, validatePaymaster(false))
+	require.NoError(t, validatePaymaster(""))
+	require.Error(t, validateDeployers(""))
+	require.NoError(t, validateDeployers([]string{"0x0000000000000000000000000000000000000001"}))
+	require.Error(t, validateCodeHashes(""))
+	require.NoError(t, validateCodeHashes([]string{"0x0000000000000000000000000000000000000000000000000000000000000001"}))
+	require.Error(t, validateUint64(int64(1)))
+	require.NoError(t, validateUint64(uint64(1)))
 }
 
 func TestParams_String(t *testing.T) {
-	require.Equal(t, "evm_denom: aphoton\nenable_create: true\nenable_call: true\nextra_eips: []\n", DefaultParams().String())
+	require.Equal(t, "evm_denom: aphoton\nenable_create: true\nenable_call: true\nextra_eips: []\npaymaster: \"\"\nallowed_deployers: []\nallowed_code_hashes: []\nblocked_addresses: []\nchain_id: 0\nretain_blocks: 0\n", DefaultParams().String())
+}
+
+func TestParamsDeploymentAllowlist(t *testing.T) {
+	deployer := ethcmn.BytesToAddress([]byte("deployer"))
+	codeHash := ethcmn.BytesToHash([]byte("code"))
+
+	params := DefaultParams()
+	require.False(t, params.HasDeploymentAllowlist())
+	require.True(t, params.IsDeployerAllowed(deployer))
+	require.True(t, params.IsCodeHashAllowed(codeHash))
+
+	params.AllowedDeployers = []string{deployer.Hex()}
+	params.AllowedCodeHashes = []string{codeHash.Hex()}
+	require.True(t, params.HasDeploymentAllowlist())
+	require.True(t, params.IsDeployerAllowed(deployer))
+	require.False(t, params.IsDeployerAllowed(ethcmn.Address{}))
+	require.True(t, params.IsCodeHashAllowed(codeHash))
+	require.False(t, params.IsCodeHashAllowed(ethcmn.Hash{}))
+}
+
+func TestParamsBlocklist(t *testing.T) {
+	blocked := ethcmn.BytesToAddress([]byte("blocked"))
+	other := ethcmn.BytesToAddress([]byte("other"))
+
+	params := DefaultParams()
+	require.False(t, params.IsBlocked(blocked))
+	require.Nil(t, NewBlockedTransfer(params, blocked, other, big.NewInt(1)))
+
+	params.BlockedAddresses = []string{blocked.Hex()}
+	require.NoError(t, params.Validate())
+	require.True(t, params.IsBlocked(blocked))
+	require.False(t, params.IsBlocked(other))
+
+	// zero value transfers aren't blocked
+	require.Nil(t, NewBlockedTransfer(params, blocked, other, big.NewInt(0)))
+	require.Nil(t, NewBlockedTransfer(params, other, other, big.NewInt(1)))
+
+	transfer := NewBlockedTransfer(params, other, blocked, big.NewInt(1))
+	require.NotNil(t, transfer)
+	require.Equal(t, blocked, transfer.Blocked)
+	require.True(t, errors.Is(transfer, ErrAddressBlocked))
+}
+
+func TestParamsEIP155ChainID(t *testing.T) {
+	params := DefaultParams()
+
+	chainID, err := params.EIP155ChainID("ethermint-3")
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(3), chainID)
+
+	_, err = params.EIP155ChainID("ethermint")
+	require.Error(t, err)
+
+	params.ChainID = 9000
+	for _, cosmosChainID := range []string{"ethermint-3", "ethermint-4", "ethermint", "my_chain.v2"} {
+		chainID, err = params.EIP155ChainID(cosmosChainID)
+		require.NoError(t, err, cosmosChainID)
+		require.Equal(t, big.NewInt(9000), chainID, cosmosChainID)
+	}
 }
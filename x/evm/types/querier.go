@@ -18,6 +18,28 @@ const (
 	QueryBloom           = "bloom"
 	QueryLogs            = "logs"
 	QueryAccount         = "account"
+	QueryBlockGasUsed    = "blockGasUsed"
+	QueryCoinbase        = "coinbase"
+	QueryBlockReceipts   = "blockReceipts"
+	QueryFeeAllowance    = "feeAllowance"
+	QueryFeeAllowances   = "feeAllowances"
+	QueryParams          = "params"
+	QueryStorageRange    = "storageRange"
+	QueryDump            = "dump"
+	QueryBlockLogs       = "blockLogs"
+	QueryStateDiff       = "stateDiff"
+)
+
+// Limits of the number of storage entries returned by the storage range query
+const (
+	DefaultStorageRangeLimit = 100
+	MaxStorageRangeLimit     = 1000
+)
+
+// Limits of the number of accounts returned by the dump query
+const (
+	DefaultDumpLimit = 100
+	MaxDumpLimit     = 1000
 )
 
 // QueryResBalance is response type for balance query
@@ -89,6 +111,129 @@ func (q QueryBloomFilter) String() string {
 	return string(q.Bloom.Bytes())
 }
 
+// QueryResBlockGasUsed is response type for block gas used query
+type QueryResBlockGasUsed struct {
+	GasUsed uint64 `json:"gasUsed"`
+}
+
+func (q QueryResBlockGasUsed) String() string {
+	return fmt.Sprint(q.GasUsed)
+}
+
+// QueryResCoinbase is response type for the validator coinbase query
+type QueryResCoinbase struct {
+	Coinbase string `json:"coinbase"`
+}
+
+func (q QueryResCoinbase) String() string {
+	return q.Coinbase
+}
+
+// QueryResBlockReceipts is response type for the block receipts query. It contains
+// all the EVM related data required to assemble a block and its receipts.
+type QueryResBlockReceipts struct {
+	Height   int64          `json:"height"`
+	Bloom    ethtypes.Bloom `json:"bloom"`
+	GasUsed  uint64         `json:"gasUsed"`
+	Receipts []TxReceipt    `json:"receipts"`
+}
+
+func (q QueryResBlockReceipts) String() string {
+	return fmt.Sprintf("height: %d, gas used: %d, receipts: %d", q.Height, q.GasUsed, len(q.Receipts))
+}
+
+// QueryResFeeAllowances is response type for the fee allowances query
+type QueryResFeeAllowances struct {
+	Paymaster  string         `json:"paymaster"`
+	Allowances []FeeAllowance `json:"allowances"`
+}
+
+func (q QueryResFeeAllowances) String() string {
+	out := fmt.Sprintf("paymaster: %s", q.Paymaster)
+	for _, allowance := range q.Allowances {
+		out = fmt.Sprintf("%s\n%s", out, allowance)
+	}
+
+	return out
+}
+
+// QueryStorageRangeParams defines the params of the storage range query. The range starts at
+// the first storage entry if the start key is empty, and the default limit is used if the
+// limit is 0.
+type QueryStorageRangeParams struct {
+	Address  string `json:"address"`
+	StartKey string `json:"start_key"`
+	Limit    uint64 `json:"limit"`
+}
+
+// NewQueryStorageRangeParams creates a new instance of QueryStorageRangeParams
+func NewQueryStorageRangeParams(address, startKey string, limit uint64) QueryStorageRangeParams {
+	return QueryStorageRangeParams{
+		Address:  address,
+		StartKey: startKey,
+		Limit:    limit,
+	}
+}
+
+// QueryResStorageRange is response type for the storage range query. The storage entries are
+// sorted by store key and the next key is empty if the range reached the end of the storage.
+type QueryResStorageRange struct {
+	Storage Storage `json:"storage"`
+	NextKey string  `json:"next_key"`
+}
+
+func (q QueryResStorageRange) String() string {
+	return fmt.Sprintf("%s\nnext key: %s", q.Storage, q.NextKey)
+}
+
+// QueryDumpParams defines the params of the dump query. The range starts at the first account
+// if the start key is empty, and the default limit is used if the limit is 0.
+type QueryDumpParams struct {
+	StartKey       string `json:"start_key"`
+	Limit          uint64 `json:"limit"`
+	ExcludeCode    bool   `json:"exclude_code"`
+	ExcludeStorage bool   `json:"exclude_storage"`
+}
+
+// NewQueryDumpParams creates a new instance of QueryDumpParams
+func NewQueryDumpParams(startKey string, limit uint64, excludeCode, excludeStorage bool) QueryDumpParams {
+	return QueryDumpParams{
+		StartKey:       startKey,
+		Limit:          limit,
+		ExcludeCode:    excludeCode,
+		ExcludeStorage: excludeStorage,
+	}
+}
+
+// QueryResDump is response type for the dump query. The accounts are sorted by address and the
+// next key is empty if the range reached the last account.
+type QueryResDump struct {
+	Accounts []DumpAccount `json:"accounts"`
+	NextKey  string        `json:"next_key"`
+}
+
+func (q QueryResDump) String() string {
+	return fmt.Sprintf("accounts: %d, next key: %s", len(q.Accounts), q.NextKey)
+}
+
+// QueryResBlockLogs is response type for the block logs query
+type QueryResBlockLogs struct {
+	TxLogs []TransactionLogs `json:"tx_logs"`
+}
+
+func (q QueryResBlockLogs) String() string {
+	return fmt.Sprintf("%v", q.TxLogs)
+}
+
+// QueryResStateDiff is response type for the block state diff query
+type QueryResStateDiff struct {
+	Accounts []AccountDiff `json:"accounts"`
+}
+
+func (q QueryResStateDiff) String() string {
+	return fmt.Sprintf("%v", q.Accounts)
+}
+
 // QueryAccount is response type for querying Ethereum state objects
 type QueryResAccount struct {
 	Balance  string `json:"balance"`
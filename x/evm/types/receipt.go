@@ -0,0 +1,80 @@
+package types
+
+import (
+	"fmt"
+
+	ethermint "github.com/cosmos/ethermint/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// TxReceipt defines the compact receipt of an EVM transaction that is persisted for
+// every block. It allows the Web3 API to assemble blocks and receipts without decoding
+// every Tendermint transaction of the block.
+type TxReceipt struct {
+	Hash            string `json:"hash"`
+	GasUsed         uint64 `json:"gasUsed"`
+	Status          uint64 `json:"status"`
+	ContractAddress string `json:"contractAddress,omitempty"`
+}
+
+// NewTxReceipt creates a new TxReceipt instance. The contract address is only set if
+// it's not nil (i.e contract creation).
+func NewTxReceipt(hash ethcmn.Hash, gasUsed, status uint64, contractAddress *ethcmn.Address) TxReceipt { // nolint: interfacer
+	receipt := TxReceipt{
+		Hash:    hash.String(),
+		GasUsed: gasUsed,
+		Status:  status,
+	}
+
+	if contractAddress != nil {
+		receipt.ContractAddress = contractAddress.String()
+	}
+
+	return receipt
+}
+
+// TxHash returns the transaction hash of the receipt.
+func (r TxReceipt) TxHash() ethcmn.Hash {
+	return ethcmn.HexToHash(r.Hash)
+}
+
+// Contract returns the address of the contract created by the transaction. It returns
+// nil if the transaction wasn't a contract creation.
+func (r TxReceipt) Contract() *ethcmn.Address {
+	if r.ContractAddress == "" {
+		return nil
+	}
+
+	addr := ethcmn.HexToAddress(r.ContractAddress)
+	return &addr
+}
+
+// Validate performs a basic validation of the TxReceipt fields.
+func (r TxReceipt) Validate() error {
+	if ethermint.IsEmptyHash(r.Hash) {
+		return fmt.Errorf("hash cannot be the empty %s", r.Hash)
+	}
+
+	if r.Status != ethtypes.ReceiptStatusFailed && r.Status != ethtypes.ReceiptStatusSuccessful {
+		return fmt.Errorf("invalid receipt status %d", r.Status)
+	}
+
+	if r.ContractAddress != "" && ethermint.IsZeroAddress(r.ContractAddress) {
+		return fmt.Errorf("contract address cannot be the zero address %s", r.ContractAddress)
+	}
+
+	return nil
+}
+
+// MarshalReceipts encodes an array of receipts using amino
+func MarshalReceipts(receipts []TxReceipt) ([]byte, error) {
+	return ModuleCdc.MarshalBinaryLengthPrefixed(receipts)
+}
+
+// UnmarshalReceipts decodes an amino-encoded byte array into an array of receipts
+func UnmarshalReceipts(in []byte) ([]TxReceipt, error) {
+	receipts := []TxReceipt{}
+	err := ModuleCdc.UnmarshalBinaryLengthPrefixed(in, &receipts)
+	return receipts, err
+}
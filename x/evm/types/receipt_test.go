@@ -0,0 +1,71 @@
+package types
+
+import (
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+func (suite *GenesisTestSuite) TestTxReceiptValidate() {
+	testCases := []struct {
+		name    string
+		receipt TxReceipt
+		expPass bool
+	}{
+		{
+			"valid receipt",
+			NewTxReceipt(suite.hash, 21000, ethtypes.ReceiptStatusSuccessful, nil),
+			true,
+		},
+		{
+			"valid contract creation receipt",
+			NewTxReceipt(suite.hash, 53000, ethtypes.ReceiptStatusSuccessful, &suite.address),
+			true,
+		},
+		{
+			"valid failed receipt",
+			NewTxReceipt(suite.hash, 21000, ethtypes.ReceiptStatusFailed, nil),
+			true,
+		},
+		{
+			"empty hash",
+			NewTxReceipt(ethcmn.Hash{}, 21000, ethtypes.ReceiptStatusSuccessful, nil),
+			false,
+		},
+		{
+			"invalid status",
+			NewTxReceipt(suite.hash, 21000, 2, nil),
+			false,
+		},
+		{
+			"zero contract address",
+			NewTxReceipt(suite.hash, 21000, ethtypes.ReceiptStatusSuccessful, &ethcmn.Address{}),
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.receipt.Validate()
+		if tc.expPass {
+			suite.Require().NoError(err, tc.name)
+		} else {
+			suite.Require().Error(err, tc.name)
+		}
+	}
+}
+
+func (suite *GenesisTestSuite) TestMarshalReceipts() {
+	receipts := []TxReceipt{
+		NewTxReceipt(suite.hash, 21000, ethtypes.ReceiptStatusSuccessful, nil),
+		NewTxReceipt(suite.hash, 53000, ethtypes.ReceiptStatusFailed, &suite.address),
+	}
+
+	bz, err := MarshalReceipts(receipts)
+	suite.Require().NoError(err)
+
+	res, err := UnmarshalReceipts(bz)
+	suite.Require().NoError(err)
+	suite.Require().Equal(receipts, res)
+	suite.Require().Equal(suite.hash, res[0].TxHash())
+	suite.Require().Nil(res[0].Contract())
+	suite.Require().Equal(suite.address, *res[1].Contract())
+}
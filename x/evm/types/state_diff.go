@@ -0,0 +1,100 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// AccountDiff defines the changes of an account on a transaction or a block. It contains
+// the storage slots written, keyed by their store key (i.e the hash of the address and
+// the slot), with their value after the changes. A zero value denotes a deleted slot.
+// The changes of the balance, nonce or code of the account are not detailed.
+type AccountDiff struct {
+	Address string  `json:"address"`
+	Storage Storage `json:"storage"`
+}
+
+// String implements the fmt.Stringer interface
+func (ad AccountDiff) String() string {
+	out := ad.Address
+	for _, state := range ad.Storage {
+		out = fmt.Sprintf("%s\n  %s: %s", out, state.Key, state.Value)
+	}
+
+	return out
+}
+
+// MergeStateDiffs merges the state diffs of the transactions of a block, in execution
+// order, into a single diff per account. The later values of a storage slot override the
+// earlier ones. The accounts and their storage slots are sorted.
+func MergeStateDiffs(diffs []AccountDiff) []AccountDiff {
+	storages := make(map[ethcmn.Address]map[ethcmn.Hash]State)
+	for _, diff := range diffs {
+		addr := ethcmn.HexToAddress(diff.Address)
+
+		storage, ok := storages[addr]
+		if !ok {
+			storage = make(map[ethcmn.Hash]State)
+			storages[addr] = storage
+		}
+
+		for _, state := range diff.Storage {
+			storage[ethcmn.HexToHash(state.Key)] = state
+		}
+	}
+
+	addrs := make([]ethcmn.Address, 0, len(storages))
+	for addr := range storages {
+		addrs = append(addrs, addr)
+	}
+
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i].Bytes(), addrs[j].Bytes()) < 0
+	})
+
+	merged := make([]AccountDiff, len(addrs))
+	for i, addr := range addrs {
+		keys := make([]ethcmn.Hash, 0, len(storages[addr]))
+		for key := range storages[addr] {
+			keys = append(keys, key)
+		}
+
+		sort.Slice(keys, func(i, j int) bool {
+			return bytes.Compare(keys[i].Bytes(), keys[j].Bytes()) < 0
+		})
+
+		storage := make(Storage, len(keys))
+		for j, key := range keys {
+			storage[j] = storages[addr][key]
+		}
+
+		merged[i] = AccountDiff{Address: addr.String(), Storage: storage}
+	}
+
+	return merged
+}
+
+// MarshalStateDiff encodes the account diffs of a block using amino
+func MarshalStateDiff(diffs []AccountDiff) ([]byte, error) {
+	return ModuleCdc.MarshalBinaryLengthPrefixed(diffs)
+}
+
+// UnmarshalStateDiff decodes an amino-encoded byte array into the account diffs of a block
+func UnmarshalStateDiff(in []byte) ([]AccountDiff, error) {
+	diffs := []AccountDiff{}
+	if err := ModuleCdc.UnmarshalBinaryLengthPrefixed(in, &diffs); err != nil {
+		return nil, err
+	}
+
+	// amino decodes the empty storages as nil
+	for i := range diffs {
+		if diffs[i].Storage == nil {
+			diffs[i].Storage = Storage{}
+		}
+	}
+
+	return diffs, nil
+}
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+func TestMergeStateDiffs(t *testing.T) {
+	addr1 := ethcmn.HexToAddress("0x1000000000000000000000000000000000000001")
+	addr2 := ethcmn.HexToAddress("0x2000000000000000000000000000000000000002")
+	key1 := ethcmn.HexToHash("0x01")
+	key2 := ethcmn.HexToHash("0x02")
+
+	diffs := []AccountDiff{
+		{Address: addr2.String(), Storage: Storage{NewState(key2, ethcmn.HexToHash("0x05")), NewState(key1, ethcmn.HexToHash("0x06"))}},
+		{Address: addr1.String(), Storage: Storage{}},
+		{Address: addr2.String(), Storage: Storage{NewState(key2, ethcmn.Hash{})}},
+	}
+
+	expDiffs := []AccountDiff{
+		{Address: addr1.String(), Storage: Storage{}},
+		{Address: addr2.String(), Storage: Storage{NewState(key1, ethcmn.HexToHash("0x06")), NewState(key2, ethcmn.Hash{})}},
+	}
+
+	merged := MergeStateDiffs(diffs)
+	require.Equal(t, expDiffs, merged)
+	require.Empty(t, MergeStateDiffs(nil))
+
+	bz, err := MarshalStateDiff(merged)
+	require.NoError(t, err)
+
+	res, err := UnmarshalStateDiff(bz)
+	require.NoError(t, err)
+	require.Equal(t, merged, res)
+}
@@ -280,6 +280,24 @@ func (so *stateObject) commitState() {
 	so.dirtyStorage = Storage{}
 }
 
+// storageDiff returns the dirty storage entries whose value differs from the committed
+// one.
+func (so *stateObject) storageDiff() Storage {
+	diff := Storage{}
+	for _, state := range so.dirtyStorage {
+		committed := ethcmn.Hash{}
+		if idx, ok := so.keyToOriginStorageIndex[ethcmn.HexToHash(state.Key)]; ok {
+			committed = ethcmn.HexToHash(so.originStorage[idx].Value)
+		}
+
+		if ethcmn.HexToHash(state.Value) != committed {
+			diff = append(diff, state)
+		}
+	}
+
+	return diff
+}
+
 // commitCode persists the state object's code to the KVStore.
 func (so *stateObject) commitCode() {
 	ctx := so.stateDB.ctx
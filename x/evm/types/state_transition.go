@@ -12,6 +12,8 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	ethermint "github.com/cosmos/ethermint/types"
 )
 
 // StateTransition defines data to transitionDB in evm
@@ -28,11 +30,12 @@ type StateTransition struct {
 	Csdb     *CommitStateDB // state
 	TxHash   *common.Hash
 	Sender   common.Address
-	Simulate bool // i.e CheckTx execution
+	Coinbase common.Address // block proposer validator operator address
+	Simulate bool           // i.e CheckTx execution
 }
 
 // GasInfo returns the gas limit, gas consumed and gas refunded from the EVM transition
-// execution
+// execution. The gas consumed doesn't include the refunded gas.
 type GasInfo struct {
 	GasLimit    uint64
 	GasConsumed uint64
@@ -41,10 +44,11 @@ type GasInfo struct {
 
 // ExecutionResult represents what's returned from a transition
 type ExecutionResult struct {
-	Logs    []*ethtypes.Log
-	Bloom   *big.Int
-	Result  *sdk.Result
-	GasInfo GasInfo
+	Logs      []*ethtypes.Log
+	Bloom     *big.Int
+	Result    *sdk.Result
+	GasInfo   GasInfo
+	StateDiff []AccountDiff
 }
 
 // GetHashFn implements vm.GetHashFunc for Ethermint. It handles 3 cases:
@@ -74,22 +78,23 @@ func GetHashFn(ctx sdk.Context, csdb *CommitStateDB) vm.GetHashFunc {
 func (st StateTransition) newEVM(
 	ctx sdk.Context,
 	csdb *CommitStateDB,
-	gasLimit uint64,
 	gasPrice *big.Int,
 	config ChainConfig,
 	extraEIPs []int64,
+	transfer vm.TransferFunc,
+	tracer vm.Tracer,
 ) *vm.EVM {
 	// Create contexts for evm
 
 	blockCtx := vm.BlockContext{
 		CanTransfer: core.CanTransfer,
-		Transfer:    core.Transfer,
+		Transfer:    transfer,
 		GetHash:     GetHashFn(ctx, csdb),
-		Coinbase:    common.Address{}, // there's no beneficiary since we're not mining
+		Coinbase:    st.Coinbase,
 		BlockNumber: big.NewInt(ctx.BlockHeight()),
 		Time:        big.NewInt(ctx.BlockHeader().Time.Unix()),
 		Difficulty:  big.NewInt(0), // unused. Only required in PoW context
-		GasLimit:    gasLimit,
+		GasLimit:    ethermint.BlockGasLimit(ctx),
 	}
 
 	txCtx := vm.TxContext{
@@ -106,29 +111,23 @@ func (st StateTransition) newEVM(
 		ExtraEips: eips,
 	}
 
+	if tracer != nil {
+		vmConfig.Debug = true
+		vmConfig.Tracer = tracer
+	}
+
 	return vm.NewEVM(blockCtx, txCtx, csdb, config.EthereumConfig(st.ChainID), vmConfig)
 }
 
-func (st StateTransition) refundGas(gasInfo GasInfo) {
-	// Apply refund counter, capped to half of the used gas.
-	refund := gasInfo.GasConsumed / 2
-	if refund > gasInfo.GasRefunded {
-		refund = gasInfo.GasRefunded
+// gasRefund returns the gas refunded by the refund counter of the state (i.e SSTORE
+// refunds), capped to half of the consumed gas.
+func gasRefund(csdb *CommitStateDB, gasConsumed uint64) uint64 {
+	refund := gasConsumed / 2
+	if refund > csdb.GetRefund() {
+		refund = csdb.GetRefund()
 	}
 
-	// Return ETH for remaining gas, exchanged at the original rate.
-	gasRefund := new(big.Int).Mul(new(big.Int).SetUint64(refund), st.Price)
-
-	// add refund back to counter.
-	// add gas information into state db
-	st.Csdb.AddRefund(refund)
-	st.Csdb.AddBalance(st.Sender, gasRefund)
-
-	// Also return remaining gas to the block gas counter so it is
-	// available for the next transaction.
-	// st.gp.AddGas(st.gas)
-	// do we have a block gas counter?
-	// will we need to track gas using gasmeter for the blocks?
+	return refund
 }
 
 // TransitionDb will transition the state by applying the current transaction and
@@ -175,7 +174,27 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (*Ex
 		return nil, errors.New("gas price cannot be nil")
 	}
 
-	evm := st.newEVM(ctx, csdb, gasLimit, gasPrice.Int, config, params.ExtraEIPs)
+	// the deployments from inside contracts are only traced if they are restricted
+	var (
+		tracer   *deploymentTracer
+		vmTracer vm.Tracer
+	)
+
+	if params.HasDeploymentAllowlist() {
+		tracer = newDeploymentTracer(params)
+		vmTracer = tracer
+	}
+
+	// the value transfers and self-destructs are only guarded if there are blocked addresses
+	var guard *transferGuard
+	transfer := core.Transfer
+	if len(params.BlockedAddresses) > 0 {
+		guard = &transferGuard{params: params}
+		transfer = guard.transfer
+		vmTracer = newTracer(vmTracer, guard)
+	}
+
+	evm := st.newEVM(ctx, csdb, gasPrice.Int, config, params.ExtraEIPs, transfer, vmTracer)
 
 	var (
 		ret             []byte
@@ -197,6 +216,10 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (*Ex
 			return nil, ErrCreateDisabled
 		}
 
+		if !params.IsDeployerAllowed(st.Sender) {
+			return nil, sdkerrors.Wrapf(ErrDeployerNotAllowed, "%s", st.Sender)
+		}
+
 		ret, contractAddress, leftOverGas, err = evm.Create(senderRef, st.Payload, gasLimit, st.Amount)
 		recipientLog = fmt.Sprintf("contract address %s", contractAddress.String())
 	default:
@@ -212,6 +235,19 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (*Ex
 
 	gasConsumed := gasLimit - leftOverGas
 
+	// a blocked transfer fails the transaction regardless of the execution outcome
+	if guard != nil && guard.blocked != nil {
+		err = guard.blocked
+	}
+
+	if err == nil && contractCreation {
+		err = validateDeployedCode(csdb, params, contractAddress)
+	}
+
+	if err == nil && tracer != nil {
+		err = tracer.validate(csdb)
+	}
+
 	if err != nil {
 		// Consume gas before returning
 		ctx.GasMeter().ConsumeGas(gasConsumed, "evm execution consumption")
@@ -239,7 +275,18 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (*Ex
 		bloomFilter = ethtypes.BytesToBloom(bloomInt.Bytes())
 	}
 
+	// NOTE: the refund is not applied to simulations, as the gas limit needs to cover the gas
+	// consumed before the refund.
+	var (
+		refund    uint64
+		stateDiff []AccountDiff
+	)
+
 	if !st.Simulate {
+		// the refund counter and the journal are reset when the state is finalised
+		refund = gasRefund(csdb, gasConsumed)
+		stateDiff = csdb.StateDiff()
+
 		// Finalise state if not a simulated transaction
 		// TODO: change to depend on config
 		if err := csdb.Finalise(true); err != nil {
@@ -268,22 +315,24 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (*Ex
 		"executed EVM state transition; sender address %s; %s", st.Sender.String(), recipientLog,
 	)
 
-	// Consume gas from evm execution
+	// Consume gas from evm execution, minus the refunded gas
 	// Out of gas check does not need to be done here since it is done within the EVM execution
+	gasConsumed -= refund
 	ctx.WithGasMeter(currentGasMeter).GasMeter().ConsumeGas(gasConsumed, "EVM execution consumption")
 
+	// NOTE: the fees of the unused gas are refunded to the sender by the EVM keeper. The remaining
+	// gas doesn't need to be returned to the block gas counter as only the consumed gas is added to
+	// the block gas used tracked by the EVM keeper.
 	gasInfo := GasInfo{
 		GasConsumed: gasConsumed,
 		GasLimit:    gasLimit,
-		GasRefunded: leftOverGas,
+		GasRefunded: refund,
 	}
 
-	// refund gas to sender
-	st.refundGas(gasInfo)
-
 	executionResult := &ExecutionResult{
-		Logs:  logs,
-		Bloom: bloomInt,
+		Logs:      logs,
+		Bloom:     bloomInt,
+		StateDiff: stateDiff,
 		Result: &sdk.Result{
 			Data: resBz,
 			Log:  resultLog,
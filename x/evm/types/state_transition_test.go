@@ -1,6 +1,7 @@
 package types_test
 
 import (
+	"errors"
 	"math/big"
 
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -275,15 +276,195 @@ func (suite *StateDBTestSuite) TestTransitionDb() {
 			suite.Require().NoError(err, tc.name)
 			fromBalance := suite.app.EvmKeeper.GetBalance(suite.ctx, suite.address)
 			toBalance := suite.app.EvmKeeper.GetBalance(suite.ctx, recipient)
-			if tc.name == "contract creation" || tc.name == "state transition simulation" {
-				// accounts for refunded amount
-				suite.Require().Equal(big.NewInt(4960), fromBalance, tc.name)
-			} else {
-				suite.Require().Equal(big.NewInt(4950), fromBalance, tc.name)
-			}
+			// the unused gas is refunded by the EVM keeper from the fee collector
+			suite.Require().Equal(big.NewInt(4950), fromBalance, tc.name)
 			suite.Require().Equal(big.NewInt(50), toBalance, tc.name)
 		} else {
 			suite.Require().Error(err, tc.name)
 		}
 	}
 }
+
+func (suite *StateDBTestSuite) TestTransitionDbDeploymentAllowlist() {
+	// init code of a contract with the STOP opcode as runtime bytecode
+	initCode := ethcmn.FromHex("600060005360016000f3")
+	codeHash := ethcrypto.Keccak256Hash([]byte{0x00}).Hex()
+	// init code of a factory that deploys the contract above with CREATE
+	factoryCode := ethcmn.FromHex("69600060005360016000f3600052600a60166000f000")
+	other := ethcmn.BytesToAddress([]byte("other"))
+
+	testCases := []struct {
+		name     string
+		params   func(params *types.Params, sender ethcmn.Address)
+		payload  []byte
+		expError error
+	}{
+		{
+			"no allowlist",
+			func(*types.Params, ethcmn.Address) {},
+			factoryCode,
+			nil,
+		},
+		{
+			"deployer allowed",
+			func(params *types.Params, sender ethcmn.Address) {
+				params.AllowedDeployers = []string{other.Hex(), sender.Hex()}
+			},
+			initCode,
+			nil,
+		},
+		{
+			"deployer not allowed",
+			func(params *types.Params, _ ethcmn.Address) {
+				params.AllowedDeployers = []string{other.Hex()}
+			},
+			initCode,
+			types.ErrDeployerNotAllowed,
+		},
+		{
+			"code hash allowed",
+			func(params *types.Params, _ ethcmn.Address) {
+				params.AllowedCodeHashes = []string{codeHash}
+			},
+			initCode,
+			nil,
+		},
+		{
+			"code hash not allowed",
+			func(params *types.Params, _ ethcmn.Address) {
+				params.AllowedCodeHashes = []string{ethcrypto.Keccak256Hash([]byte{0x01}).Hex()}
+			},
+			initCode,
+			types.ErrCodeHashNotAllowed,
+		},
+		{
+			"CREATE from an allowed deployer",
+			func(params *types.Params, sender ethcmn.Address) {
+				factory := ethcrypto.CreateAddress(sender, 0)
+				params.AllowedDeployers = []string{sender.Hex(), factory.Hex()}
+				params.AllowedCodeHashes = []string{codeHash}
+			},
+			factoryCode,
+			nil,
+		},
+		{
+			"CREATE from a deployer not allowed",
+			func(params *types.Params, sender ethcmn.Address) {
+				params.AllowedDeployers = []string{sender.Hex()}
+			},
+			factoryCode,
+			types.ErrDeployerNotAllowed,
+		},
+		{
+			"CREATE of a code hash not allowed",
+			func(params *types.Params, _ ethcmn.Address) {
+				params.AllowedCodeHashes = []string{ethcrypto.Keccak256Hash([]byte{0x01}).Hex()}
+			},
+			factoryCode,
+			types.ErrCodeHashNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+
+			params := types.DefaultParams()
+			tc.params(&params, suite.address)
+			suite.stateDB.SetParams(params)
+
+			st := types.StateTransition{
+				AccountNonce: 0,
+				Price:        big.NewInt(10),
+				GasLimit:     1000000,
+				Amount:       big.NewInt(0),
+				Payload:      tc.payload,
+				ChainID:      big.NewInt(1),
+				Csdb:         suite.stateDB,
+				TxHash:       &ethcmn.Hash{},
+				Sender:       suite.address,
+				Simulate:     true,
+			}
+
+			_, err := st.TransitionDb(suite.ctx, types.DefaultChainConfig())
+			if tc.expError == nil {
+				suite.Require().NoError(err)
+			} else {
+				suite.Require().True(errors.Is(err, tc.expError), err)
+			}
+		})
+	}
+}
+
+func (suite *StateDBTestSuite) TestTransitionDbBlocklist() {
+	sender := ethcmn.BytesToAddress([]byte("sender"))
+	blocked := ethcmn.BytesToAddress([]byte("blocked"))
+	recipient := ethcmn.BytesToAddress([]byte("recipient"))
+	// contract that forwards the call value to the blocked address:
+	// CALL(GAS, blocked, CALLVALUE, 0, 0, 0, 0)
+	forwarder := ethcmn.BytesToAddress([]byte("forwarder"))
+	forwarderCode := append(append(ethcmn.FromHex("60006000600060003473"), blocked.Bytes()...), ethcmn.FromHex("5af100")...)
+	// contracts that self-destruct with the blocked or the recipient address as beneficiary:
+	// SELFDESTRUCT(beneficiary)
+	destructor := ethcmn.BytesToAddress([]byte("destructor"))
+	destructorCode := append(append(ethcmn.FromHex("73"), blocked.Bytes()...), ethcmn.FromHex("ff")...)
+	allowedDestructor := ethcmn.BytesToAddress([]byte("allowed destructor"))
+	allowedDestructorCode := append(append(ethcmn.FromHex("73"), recipient.Bytes()...), ethcmn.FromHex("ff")...)
+
+	testCases := []struct {
+		name       string
+		blocklist  []string
+		recipient  ethcmn.Address
+		amount     int64
+		expBlocked *ethcmn.Address
+	}{
+		{"no blocklist", nil, blocked, 10, nil},
+		{"recipient not blocked", []string{blocked.Hex()}, recipient, 10, nil},
+		{"zero value to a blocked recipient", []string{blocked.Hex()}, blocked, 0, nil},
+		{"blocked recipient", []string{blocked.Hex()}, blocked, 10, &blocked},
+		{"blocked sender", []string{sender.Hex()}, recipient, 10, &sender},
+		{"internal transfer to a blocked address", []string{blocked.Hex()}, forwarder, 10, &blocked},
+		{"self-destruct to a recipient not blocked", []string{blocked.Hex()}, allowedDestructor, 10, nil},
+		{"self-destruct to a blocked address", []string{blocked.Hex()}, destructor, 10, &blocked},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+
+			params := types.DefaultParams()
+			params.BlockedAddresses = tc.blocklist
+			suite.stateDB.SetParams(params)
+			suite.stateDB.SetBalance(sender, big.NewInt(1000000000))
+			suite.stateDB.SetCode(forwarder, forwarderCode)
+			suite.stateDB.SetCode(destructor, destructorCode)
+			suite.stateDB.SetCode(allowedDestructor, allowedDestructorCode)
+
+			st := types.StateTransition{
+				AccountNonce: 0,
+				Price:        big.NewInt(10),
+				GasLimit:     1000000,
+				Recipient:    &tc.recipient,
+				Amount:       big.NewInt(tc.amount),
+				Payload:      []byte{},
+				ChainID:      big.NewInt(1),
+				Csdb:         suite.stateDB,
+				TxHash:       &ethcmn.Hash{},
+				Sender:       sender,
+				Simulate:     true,
+			}
+
+			_, err := st.TransitionDb(suite.ctx, types.DefaultChainConfig())
+			if tc.expBlocked == nil {
+				suite.Require().NoError(err)
+				return
+			}
+
+			suite.Require().True(errors.Is(err, types.ErrAddressBlocked), err)
+
+			var transfer *types.BlockedTransfer
+			suite.Require().True(errors.As(err, &transfer))
+			suite.Require().Equal(*tc.expBlocked, transfer.Blocked)
+		})
+	}
+}
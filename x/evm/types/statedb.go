@@ -1,6 +1,8 @@
 package types
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"sort"
@@ -8,6 +10,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
 
 	ethermint "github.com/cosmos/ethermint/types"
@@ -43,6 +46,7 @@ type CommitStateDB struct {
 	ctx sdk.Context
 
 	storeKey      sdk.StoreKey
+	accountKey    sdk.StoreKey // auth module store key, used to iterate the accounts by address
 	paramSpace    params.Subspace
 	accountKeeper AccountKeeper
 
@@ -90,11 +94,12 @@ type CommitStateDB struct {
 // CONTRACT: Stores used for state must be cache-wrapped as the ordering of the
 // key/value space matters in determining the merkle root.
 func NewCommitStateDB(
-	ctx sdk.Context, storeKey sdk.StoreKey, paramSpace params.Subspace, ak AccountKeeper,
+	ctx sdk.Context, storeKey, accountKey sdk.StoreKey, paramSpace params.Subspace, ak AccountKeeper,
 ) *CommitStateDB {
 	return &CommitStateDB{
 		ctx:                  ctx,
 		storeKey:             storeKey,
+		accountKey:           accountKey,
 		paramSpace:           paramSpace,
 		accountKeeper:        ak,
 		stateObjects:         []stateEntry{},
@@ -194,6 +199,10 @@ func (csdb *CommitStateDB) SetLogs(hash ethcmn.Hash, logs []*ethtypes.Log) error
 
 	store.Set(hash.Bytes(), bz)
 	csdb.logSize = uint(len(logs))
+
+	// index the logs by block height so that they can be pruned
+	heightStore := prefix.NewStore(csdb.ctx.KVStore(csdb.storeKey), KeyPrefixLogsHeight)
+	heightStore.Set(LogsHeightKey(csdb.ctx.BlockHeight(), hash), []byte{0x1})
 	return nil
 }
 
@@ -201,6 +210,9 @@ func (csdb *CommitStateDB) SetLogs(hash ethcmn.Hash, logs []*ethtypes.Log) error
 func (csdb *CommitStateDB) DeleteLogs(hash ethcmn.Hash) {
 	store := prefix.NewStore(csdb.ctx.KVStore(csdb.storeKey), KeyPrefixLogs)
 	store.Delete(hash.Bytes())
+
+	heightStore := prefix.NewStore(csdb.ctx.KVStore(csdb.storeKey), KeyPrefixLogsHeight)
+	heightStore.Delete(LogsHeightKey(csdb.ctx.BlockHeight(), hash))
 }
 
 // AddLog adds a new log to the state and sets the log metadata from the state.
@@ -305,9 +317,13 @@ func (csdb *CommitStateDB) GetHeightHash(height uint64) ethcmn.Hash {
 	return ethcmn.BytesToHash(bz)
 }
 
-// GetParams returns the total set of evm parameters.
+// GetParams returns the total set of evm parameters. The parameters that aren't set on the
+// param store (i.e the ones added after the chain genesis) fall back to their default value.
 func (csdb *CommitStateDB) GetParams() (params Params) {
-	csdb.paramSpace.GetParamSet(csdb.ctx, &params)
+	params = DefaultParams()
+	for _, pair := range params.ParamSetPairs() {
+		csdb.paramSpace.GetIfExists(csdb.ctx, pair.Key, pair.Value)
+	}
 	return params
 }
 
@@ -509,6 +525,27 @@ func (csdb *CommitStateDB) Commit(deleteEmptyObjects bool) (ethcmn.Hash, error)
 	return ethcmn.Hash{}, nil
 }
 
+// StateDiff returns the accounts changed since the last call to Finalise (i.e the dirty
+// accounts of the journal), with the storage slots whose value differs from the committed
+// one. It must be called before finalising the state, which clears the journal.
+func (csdb *CommitStateDB) StateDiff() []AccountDiff {
+	diffs := []AccountDiff{}
+	for _, dirty := range csdb.journal.dirties {
+		idx, exist := csdb.addressToObjectIndex[dirty.address]
+		// skip the accounts whose changes have all been reverted
+		if !exist || dirty.changes == 0 {
+			continue
+		}
+
+		diffs = append(diffs, AccountDiff{
+			Address: dirty.address.String(),
+			Storage: csdb.stateObjects[idx].stateObject.storageDiff(),
+		})
+	}
+
+	return diffs
+}
+
 // Finalise finalizes the state objects (accounts) state by setting their state,
 // removing the csdb destructed objects and clearing the journal as well as the
 // refunds.
@@ -778,6 +815,7 @@ func CopyCommitStateDB(from, to *CommitStateDB) {
 
 	to.ctx = from.ctx
 	to.storeKey = from.storeKey
+	to.accountKey = from.accountKey
 	to.paramSpace = from.paramSpace
 	to.accountKeeper = from.accountKeeper
 	to.stateObjects = []stateEntry{}
@@ -949,11 +987,111 @@ func (csdb *CommitStateDB) setStateObject(so *stateObject) {
 	csdb.addressToObjectIndex[se.address] = len(csdb.stateObjects) - 1
 }
 
-// RawDump returns a raw state dump.
-//
-// TODO: Implement if we need it, especially for the RPC API.
-func (csdb *CommitStateDB) RawDump() ethstate.Dump {
-	return ethstate.Dump{}
+// DumpAccounts iterates over the committed EthAccounts in address order, starting at the
+// start address, and calls cb with the dump of each one. The code and storage of the
+// accounts are omitted if excludeCode and excludeStorage are set. If maxResults is
+// positive, at most maxResults accounts are dumped and the address of the next account, if
+// any, is returned. The pending changes of the state objects are not included.
+func (csdb *CommitStateDB) DumpAccounts(
+	excludeCode, excludeStorage bool, start []byte, maxResults int, cb func(account DumpAccount),
+) (nextKey []byte) {
+	evmDenom := csdb.GetParams().EvmDenom
+	store := csdb.ctx.KVStore(csdb.storeKey)
+	codeStore := prefix.NewStore(store, KeyPrefixCode)
+
+	// the accounts are iterated in address order, from the start address
+	accountStore := prefix.NewStore(csdb.ctx.KVStore(csdb.accountKey), authtypes.AddressStoreKeyPrefix)
+	iterator := accountStore.Iterator(start, nil)
+	defer iterator.Close()
+
+	var count int
+	for ; iterator.Valid(); iterator.Next() {
+		ethAccount, ok := csdb.accountKeeper.GetAccount(csdb.ctx, iterator.Key()).(*ethermint.EthAccount)
+		if !ok {
+			// ignore non EthAccounts
+			continue
+		}
+
+		addr := ethAccount.EthAddress()
+		if maxResults > 0 && count >= maxResults {
+			return addr.Bytes()
+		}
+
+		codeHash := ethAccount.CodeHash
+		if codeHash == nil {
+			codeHash = emptyCodeHash
+		}
+
+		dump := DumpAccount{
+			Address:  addr.String(),
+			Balance:  ethAccount.Balance(evmDenom).String(),
+			Nonce:    ethAccount.GetSequence(),
+			CodeHash: ethcmn.BytesToHash(codeHash).String(),
+		}
+
+		if !excludeCode && !bytes.Equal(codeHash, emptyCodeHash) {
+			dump.Code = ethcmn.Bytes2Hex(codeStore.Get(codeHash))
+		}
+
+		if !excludeStorage {
+			dump.Storage = Storage{}
+
+			storageIterator := sdk.KVStorePrefixIterator(store, AddressStoragePrefix(addr))
+			for ; storageIterator.Valid(); storageIterator.Next() {
+				key := ethcmn.BytesToHash(storageIterator.Key())
+				dump.Storage = append(dump.Storage, NewState(key, ethcmn.BytesToHash(storageIterator.Value())))
+			}
+			storageIterator.Close()
+		}
+
+		cb(dump)
+		count++
+	}
+
+	return nil
+}
+
+// DumpToCollector passes the committed EthAccounts to a geth dump collector, in the geth dump
+// format, and returns the address of the next account if the dump is limited by maxResults
+// (see DumpAccounts). As the EVM state isn't stored on a trie, the root is always empty.
+func (csdb *CommitStateDB) DumpToCollector(
+	c ethstate.DumpCollector, excludeCode, excludeStorage bool, start []byte, maxResults int,
+) (nextKey []byte) {
+	c.OnRoot(ethcmn.Hash{})
+
+	return csdb.DumpAccounts(excludeCode, excludeStorage, start, maxResults, func(account DumpAccount) {
+		c.OnAccount(account.EthDumpAccount())
+	})
+}
+
+// RawDump returns the dump of all the committed EthAccounts as a single object.
+func (csdb *CommitStateDB) RawDump(excludeCode, excludeStorage bool) ethstate.Dump {
+	dump := &ethstate.Dump{
+		Accounts: make(map[ethcmn.Address]ethstate.DumpAccount),
+	}
+
+	csdb.DumpToCollector(dump, excludeCode, excludeStorage, nil, 0)
+	return *dump
+}
+
+// IteratorDump returns the dump of a batch of committed EthAccounts, starting at the start
+// address, along with the address of the next batch.
+func (csdb *CommitStateDB) IteratorDump(excludeCode, excludeStorage bool, start []byte, maxResults int) ethstate.IteratorDump {
+	dump := &ethstate.IteratorDump{
+		Accounts: make(map[ethcmn.Address]ethstate.DumpAccount),
+	}
+
+	dump.Next = csdb.DumpToCollector(dump, excludeCode, excludeStorage, start, maxResults)
+	return *dump
+}
+
+// IterativeDump encodes the committed EthAccounts, starting at the start address, as one
+// JSON object per line to the output, and returns the address of the next account if the
+// dump is limited by maxResults.
+func (csdb *CommitStateDB) IterativeDump(
+	excludeCode, excludeStorage bool, start []byte, maxResults int, output *json.Encoder,
+) (nextKey []byte) {
+	return csdb.DumpToCollector(iterativeDump{output}, excludeCode, excludeStorage, start, maxResults)
 }
 
 type preimageEntry struct {
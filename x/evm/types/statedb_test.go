@@ -1,8 +1,11 @@
 package types_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -11,6 +14,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethstate "github.com/ethereum/go-ethereum/core/state"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 
@@ -624,6 +628,95 @@ func (suite *StateDBTestSuite) TestCommitStateDB_Finalize() {
 		suite.Require().NotNil(acc, tc.name)
 	}
 }
+
+func (suite *StateDBTestSuite) TestCommitStateDB_StateDiff() {
+	key1 := ethcmn.BytesToHash([]byte("key1"))
+	key2 := ethcmn.BytesToHash([]byte("key2"))
+	value := ethcmn.BytesToHash([]byte("value"))
+	reverted := ethcmn.BytesToAddress([]byte("reverted"))
+
+	suite.stateDB.SetState(suite.address, key1, value)
+	suite.Require().NoError(suite.stateDB.Finalise(false))
+
+	// the value of key1 is restored and key2 is written
+	suite.stateDB.SetState(suite.address, key1, ethcmn.Hash{})
+	suite.stateDB.SetState(suite.address, key1, value)
+	suite.stateDB.SetState(suite.address, key2, value)
+
+	id := suite.stateDB.Snapshot()
+	suite.stateDB.AddBalance(reverted, big.NewInt(5))
+	suite.stateDB.RevertToSnapshot(id)
+
+	storeKey := ethcrypto.Keccak256Hash(append(suite.address.Bytes(), key2.Bytes()...))
+	expDiffs := []types.AccountDiff{
+		{Address: suite.address.String(), Storage: types.Storage{types.NewState(storeKey, value)}},
+	}
+	suite.Require().Equal(expDiffs, suite.stateDB.StateDiff())
+
+	// the journal is cleared once the state is finalised
+	suite.Require().NoError(suite.stateDB.Finalise(false))
+	suite.Require().Empty(suite.stateDB.StateDiff())
+}
+
+func (suite *StateDBTestSuite) TestCommitStateDB_Dump() {
+	contract := ethcmn.BytesToAddress([]byte("contract"))
+	code := []byte("code")
+	key := ethcmn.BigToHash(big.NewInt(1))
+
+	suite.stateDB.SetBalance(suite.address, big.NewInt(100))
+	suite.stateDB.SetNonce(suite.address, 2)
+	suite.stateDB.SetCode(contract, code)
+	suite.stateDB.SetState(contract, key, ethcmn.BigToHash(big.NewInt(7)))
+	suite.Require().NoError(suite.stateDB.Finalise(false))
+	_, err := suite.stateDB.Commit(false)
+	suite.Require().NoError(err)
+
+	storeKey := ethcrypto.Keccak256Hash(append(contract.Bytes(), key.Bytes()...))
+
+	dump := suite.stateDB.RawDump(false, false)
+	suite.Require().Equal(fmt.Sprintf("%x", ethcmn.Hash{}), dump.Root)
+	suite.Require().Len(dump.Accounts, 2)
+	suite.Require().Equal("100", dump.Accounts[suite.address].Balance)
+	suite.Require().Equal(uint64(2), dump.Accounts[suite.address].Nonce)
+	suite.Require().Empty(dump.Accounts[suite.address].Code)
+	suite.Require().Empty(dump.Accounts[suite.address].Storage)
+	suite.Require().Equal(ethcmn.Bytes2Hex(ethcrypto.Keccak256(code)), dump.Accounts[contract].CodeHash)
+	suite.Require().Equal(ethcmn.Bytes2Hex(code), dump.Accounts[contract].Code)
+	suite.Require().Equal(map[ethcmn.Hash]string{storeKey: "07"}, dump.Accounts[contract].Storage)
+
+	dump = suite.stateDB.RawDump(true, true)
+	suite.Require().Empty(dump.Accounts[contract].Code)
+	suite.Require().Nil(dump.Accounts[contract].Storage)
+
+	// the accounts are dumped in address order
+	first, second := suite.address, contract
+	if bytes.Compare(contract.Bytes(), suite.address.Bytes()) < 0 {
+		first, second = contract, suite.address
+	}
+
+	page := suite.stateDB.IteratorDump(false, false, nil, 1)
+	suite.Require().Len(page.Accounts, 1)
+	suite.Require().Contains(page.Accounts, first)
+	suite.Require().Equal(second.Bytes(), page.Next)
+
+	page = suite.stateDB.IteratorDump(false, false, page.Next, 1)
+	suite.Require().Len(page.Accounts, 1)
+	suite.Require().Contains(page.Accounts, second)
+	suite.Require().Nil(page.Next)
+
+	// the iterative dump encodes the root and each account on a line
+	var output bytes.Buffer
+	suite.Require().Nil(suite.stateDB.IterativeDump(false, true, nil, 0, json.NewEncoder(&output)))
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	suite.Require().Len(lines, 3)
+
+	var account ethstate.DumpAccount
+	suite.Require().NoError(json.Unmarshal([]byte(lines[2]), &account))
+	suite.Require().Equal(second, *account.Address)
+	suite.Require().Nil(account.Storage)
+}
+
 func (suite *StateDBTestSuite) TestCommitStateDB_GetCommittedState() {
 	hash := suite.stateDB.GetCommittedState(ethcmn.Address{}, ethcmn.BytesToHash([]byte("key")))
 	suite.Require().Equal(ethcmn.Hash{}, hash)
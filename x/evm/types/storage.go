@@ -2,11 +2,10 @@ package types
 
 import (
 	"fmt"
+	"strings"
 
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
-	ethermint "github.com/cosmos/ethermint/types"
-
 	ethcmn "github.com/ethereum/go-ethereum/common"
 )
 
@@ -57,7 +56,8 @@ type State struct {
 
 // Validate performs a basic validation of the State fields.
 func (s State) Validate() error {
-	if ethermint.IsEmptyHash(s.Key) {
+	// NOTE: the zero hash is a valid key (i.e storage slot 0)
+	if strings.TrimSpace(s.Key) == "" {
 		return sdkerrors.Wrap(ErrInvalidState, "state key hash cannot be empty")
 	}
 	// NOTE: state value can be empty
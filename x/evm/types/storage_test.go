@@ -22,10 +22,17 @@ func TestStorageValidate(t *testing.T) {
 			true,
 		},
 		{
-			"empty storage key bytes",
+			"zero storage key (slot 0)",
 			Storage{
 				{Key: ethcmn.Hash{}.String()},
 			},
+			true,
+		},
+		{
+			"empty storage key",
+			Storage{
+				{Key: ""},
+			},
 			false,
 		},
 		{
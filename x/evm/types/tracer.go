@@ -0,0 +1,85 @@
+package types
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+var _ vm.Tracer = multiTracer{}
+
+// multiTracer is a vm.Tracer that calls each one of its tracers, as the EVM only takes a
+// single tracer. The first error returned by a tracer is returned.
+type multiTracer []vm.Tracer
+
+// newTracer returns a vm.Tracer that calls the given tracers, skipping the nil ones. It
+// returns nil if there aren't any tracers.
+func newTracer(tracers ...vm.Tracer) vm.Tracer {
+	var mt multiTracer
+	for _, tracer := range tracers {
+		if tracer != nil {
+			mt = append(mt, tracer)
+		}
+	}
+
+	switch len(mt) {
+	case 0:
+		return nil
+	case 1:
+		return mt[0]
+	default:
+		return mt
+	}
+}
+
+// CaptureStart implements vm.Tracer.
+func (mt multiTracer) CaptureStart(from, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	for _, tracer := range mt {
+		if err := tracer.CaptureStart(from, to, create, input, gas, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CaptureState implements vm.Tracer.
+func (mt multiTracer) CaptureState(
+	env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack,
+	rStack *vm.ReturnStack, rData []byte, contract *vm.Contract, depth int, err error,
+) error {
+	for _, tracer := range mt {
+		if err := tracer.CaptureState(env, pc, op, gas, cost, memory, stack, rStack, rData, contract, depth, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CaptureFault implements vm.Tracer.
+func (mt multiTracer) CaptureFault(
+	env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack,
+	rStack *vm.ReturnStack, contract *vm.Contract, depth int, err error,
+) error {
+	for _, tracer := range mt {
+		if err := tracer.CaptureFault(env, pc, op, gas, cost, memory, stack, rStack, contract, depth, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CaptureEnd implements vm.Tracer.
+func (mt multiTracer) CaptureEnd(output []byte, gasUsed uint64, t time.Duration, err error) error {
+	for _, tracer := range mt {
+		if err := tracer.CaptureEnd(output, gasUsed, t, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
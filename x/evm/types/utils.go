@@ -13,6 +13,7 @@ import (
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -45,6 +46,21 @@ func ValidateSigner(signBytes, sig []byte, signer ethcmn.Address) error {
 	return nil
 }
 
+// ValidateHash returns an error if the given string is not a 0x prefixed hex encoded
+// 32 byte hash.
+func ValidateHash(hash string) error {
+	bz, err := hexutil.Decode(hash)
+	if err != nil {
+		return fmt.Errorf("invalid hash %s: %w", hash, err)
+	}
+
+	if len(bz) != ethcmn.HashLength {
+		return fmt.Errorf("invalid hash length for %s, expected %d bytes, got %d", hash, ethcmn.HashLength, len(bz))
+	}
+
+	return nil
+}
+
 func rlpHash(x interface{}) (hash ethcmn.Hash) {
 	hasher := sha3.NewLegacyKeccak256()
 	_ = rlp.Encode(hasher, x)